@@ -11,11 +11,14 @@ import (
 	"connectrpc.com/connect"
 	connectcors "connectrpc.com/cors"
 	authrpc "github.com/frank2889/mazechase/generated/auth/v1/v1connect"
+	leaderboardrpc "github.com/frank2889/mazechase/generated/leaderboard/v1/v1connect"
 	lobbyrpc "github.com/frank2889/mazechase/generated/lobby/v1/v1connect"
 	"github.com/frank2889/mazechase/internal/config"
 	"github.com/frank2889/mazechase/internal/database"
 	"github.com/frank2889/mazechase/internal/game"
+	"github.com/frank2889/mazechase/internal/leaderboard"
 	"github.com/frank2889/mazechase/internal/lobby"
+	"github.com/frank2889/mazechase/internal/match"
 	"github.com/frank2889/mazechase/internal/user"
 	"github.com/rs/cors"
 	"github.com/rs/zerolog/log"
@@ -31,11 +34,11 @@ func StartServer(frontendPath string) {
 }
 
 func setupServer(baseUrl, frontendPath string) error {
-	authSrv, lobSrv := initServices()
+	authSrv, lobSrv, matchSrv, lbSrv := initServices()
 
 	router := http.NewServeMux()
 
-	registerHandlers(router, authSrv, lobSrv)
+	registerHandlers(router, authSrv, lobSrv, matchSrv, lbSrv)
 
 	rootCloser := registerFrontend(router, frontendPath, authSrv)
 	defer func(rootCloser io.Closer) {
@@ -66,16 +69,19 @@ func setupServer(baseUrl, frontendPath string) error {
 	)
 }
 
-func initServices() (*user.Service, *lobby.Service) {
+func initServices() (*user.Service, *lobby.Service, *match.Service, *leaderboard.Service) {
 	db := database.InitDB()
 
 	authService := user.NewService(db, config.Opts.DisableAuth)
 	lobSrv := lobby.NewLobbyService(db)
+	lobSrv.SetMapNames(game.MapNames())
+	matchSrv := match.NewMatchService(db)
+	lbSrv := leaderboard.NewLeaderboardService(db)
 
-	return authService, lobSrv
+	return authService, lobSrv, matchSrv, lbSrv
 }
 
-func registerHandlers(mux *http.ServeMux, as *user.Service, ls *lobby.Service) {
+func registerHandlers(mux *http.ServeMux, as *user.Service, ls *lobby.Service, ms *match.Service, lbs *leaderboard.Service) {
 	authInterceptor := connect.WithInterceptors(user.NewInterceptor(as))
 
 	services := []func() (string, http.Handler){
@@ -85,6 +91,9 @@ func registerHandlers(mux *http.ServeMux, as *user.Service, ls *lobby.Service) {
 		func() (string, http.Handler) {
 			return lobbyrpc.NewLobbyServiceHandler(lobby.NewLobbyHandler(ls), authInterceptor)
 		},
+		func() (string, http.Handler) {
+			return leaderboardrpc.NewLeaderboardServiceHandler(leaderboard.NewLeaderboardHandler(lbs), authInterceptor)
+		},
 	}
 
 	for _, svc := range services {
@@ -92,7 +101,7 @@ func registerHandlers(mux *http.ServeMux, as *user.Service, ls *lobby.Service) {
 		mux.Handle(path, handler)
 	}
 
-	game.RegisterGameWSHandler(mux, as, ls)
+	game.RegisterGameWSHandler(mux, as, ls, ms)
 }
 
 func registerFrontend(router *http.ServeMux, frontEndPath string, auth *user.Service) io.Closer {
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frank2889/mazechase/internal/game"
+	"github.com/frank2889/mazechase/pkg"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Plays bot-only matches on virtual time and prints the outcome, e.g.
+//
+//	go run ./cmd/simulate -games 1000 -map generated -bots chaser:0.9,ambush:0.7 -format csv
+func main() {
+	mapName := flag.String("map", "", "map name, or \"generated\" for a maze per seed")
+	mode := flag.String("mode", string(game.ModeClassic), "game mode: classic, race or battle")
+	bots := flag.String("bots", "", "strategy:aggression per bot in slot order, e.g. chaser:0.9,ambush:0.7")
+	difficulty := flag.String("difficulty", game.DifficultyMedium.String(), "bot difficulty: easy, normal or hard")
+	seed := flag.Int64("seed", 1, "seed of the first game")
+	games := flag.Int("games", 100, "number of games")
+	maxDuration := flag.Duration("max-duration", game.SimDefaultMaxDuration, "virtual time after which a game counts as a timeout")
+	workers := flag.Int("workers", runtime.NumCPU(), "games played at the same time")
+	format := flag.String("format", "json", "output format: json or csv")
+	flag.Parse()
+
+	pkg.ConsoleLogger()
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+
+	profiles, err := parseBotProfiles(*bots)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid bots")
+	}
+	level, err := game.ParseDifficulty(*difficulty)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid difficulty")
+	}
+
+	started := time.Now()
+	result, err := game.Simulate(game.SimConfig{
+		Map:         *mapName,
+		Mode:        game.GameMode(*mode),
+		Bots:        profiles,
+		Difficulty:  level,
+		Seed:        *seed,
+		Games:       *games,
+		MaxDuration: *maxDuration,
+		Workers:     *workers,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Simulation failed")
+	}
+
+	switch *format {
+	case "csv":
+		err = result.WriteCSV(os.Stdout)
+	case "json":
+		err = result.WriteJSON(os.Stdout)
+	default:
+		err = fmt.Errorf("unknown format %s", *format)
+	}
+	if err != nil {
+		log.Fatal().Err(err).Msg("Unable to write result")
+	}
+	log.Warn().Int("games", *games).Dur("took", time.Since(started)).Msg("Simulation done")
+}
+
+// parseBotProfiles reads a comma separated list of strategy:aggression pairs
+func parseBotProfiles(value string) ([]game.BotProfile, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var profiles []game.BotProfile
+	for _, entry := range strings.Split(value, ",") {
+		name, aggression, found := strings.Cut(entry, ":")
+		strategy, err := game.ParseBotStrategy(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+
+		profile := game.BotProfile{Strategy: strategy, Aggression: 0.5}
+		if found {
+			if profile.Aggression, err = strconv.ParseFloat(aggression, 64); err != nil {
+				return nil, fmt.Errorf("invalid aggression %q: %v", aggression, err)
+			}
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
@@ -0,0 +1,4265 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: game/v1/game.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Envelope wraps every message the server sends on the game WebSocket. The
+// type of a message is the name of its payload field. On the JSON wire the
+// payload goes under "payload" next to "v", "type", "seq" and "ts", see
+// docs/ws-protocol.md.
+type Envelope struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// protocol version, clients pick one with ?v= when they connect
+	Version uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// increases by one per message of a match; 0 outside a match and for the
+	// messages inside a snapshot
+	Seq uint64 `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	// server time in ms since the epoch; 0 inside a snapshot
+	Ts int64 `protobuf:"varint,3,opt,name=ts,proto3" json:"ts,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*Envelope_State
+	//	*Envelope_Snapshot
+	//	*Envelope_Pos
+	//	*Envelope_Active
+	//	*Envelope_Dis
+	//	*Envelope_Pel
+	//	*Envelope_Pow
+	//	*Envelope_Powend
+	//	*Envelope_Kill
+	//	*Envelope_Eliminated
+	//	*Envelope_Reconnecting
+	//	*Envelope_Resumed
+	//	*Envelope_Lobbystatus
+	//	*Envelope_Countdown
+	//	*Envelope_Countdownstarted
+	//	*Envelope_Gamestart
+	//	*Envelope_Gameover
+	//	*Envelope_Error
+	//	*Envelope_PhaseUpdate
+	//	*Envelope_PhaseChange
+	//	*Envelope_MazeUpdate
+	//	*Envelope_EntitiesUpdate
+	//	*Envelope_EntityNear
+	//	*Envelope_EntityCollision
+	//	*Envelope_ZoneQuery
+	//	*Envelope_DynamicState
+	//	*Envelope_Chat
+	//	*Envelope_Replayinfo
+	//	*Envelope_Replaystatus
+	//	*Envelope_Timer
+	//	*Envelope_Suddendeath
+	//	*Envelope_Chaser
+	//	*Envelope_Lifelost
+	//	*Envelope_Respawn
+	//	*Envelope_Level
+	Payload       isEnvelope_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Envelope) Reset() {
+	*x = Envelope{}
+	mi := &file_game_v1_game_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Envelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Envelope) ProtoMessage() {}
+
+func (x *Envelope) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Envelope.ProtoReflect.Descriptor instead.
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Envelope) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Envelope) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Envelope) GetTs() int64 {
+	if x != nil {
+		return x.Ts
+	}
+	return 0
+}
+
+func (x *Envelope) GetPayload() isEnvelope_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Envelope) GetState() *State {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_State); ok {
+			return x.State
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetSnapshot() *Snapshot {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Snapshot); ok {
+			return x.Snapshot
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetPos() *PlayerUpdate {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Pos); ok {
+			return x.Pos
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetActive() *PlayerUpdate {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Active); ok {
+			return x.Active
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetDis() *PlayerUpdate {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Dis); ok {
+			return x.Dis
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetPel() *Pellet {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Pel); ok {
+			return x.Pel
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetPow() *PowerUp {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Pow); ok {
+			return x.Pow
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetPowend() *PowerUpEnd {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Powend); ok {
+			return x.Powend
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetKill() *Kill {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Kill); ok {
+			return x.Kill
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetEliminated() *Eliminated {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Eliminated); ok {
+			return x.Eliminated
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetReconnecting() *Reconnecting {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Reconnecting); ok {
+			return x.Reconnecting
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetResumed() *Resumed {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Resumed); ok {
+			return x.Resumed
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetLobbystatus() *LobbyStatus {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Lobbystatus); ok {
+			return x.Lobbystatus
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetCountdown() *Countdown {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Countdown); ok {
+			return x.Countdown
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetCountdownstarted() *CountdownStarted {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Countdownstarted); ok {
+			return x.Countdownstarted
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetGamestart() *GameStart {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Gamestart); ok {
+			return x.Gamestart
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetGameover() *GameOver {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Gameover); ok {
+			return x.Gameover
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetError() *ErrorMessage {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetPhaseUpdate() *PhaseUpdate {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_PhaseUpdate); ok {
+			return x.PhaseUpdate
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetPhaseChange() *PhaseChange {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_PhaseChange); ok {
+			return x.PhaseChange
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetMazeUpdate() *MazeUpdate {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_MazeUpdate); ok {
+			return x.MazeUpdate
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetEntitiesUpdate() *EntitiesUpdate {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_EntitiesUpdate); ok {
+			return x.EntitiesUpdate
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetEntityNear() *EntityNear {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_EntityNear); ok {
+			return x.EntityNear
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetEntityCollision() *EntityCollision {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_EntityCollision); ok {
+			return x.EntityCollision
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetZoneQuery() *ZoneQuery {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_ZoneQuery); ok {
+			return x.ZoneQuery
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetDynamicState() *DynamicState {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_DynamicState); ok {
+			return x.DynamicState
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetChat() *Chat {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Chat); ok {
+			return x.Chat
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetReplayinfo() *ReplayInfo {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Replayinfo); ok {
+			return x.Replayinfo
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetReplaystatus() *ReplayStatus {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Replaystatus); ok {
+			return x.Replaystatus
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetTimer() *Timer {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Timer); ok {
+			return x.Timer
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetSuddendeath() *SuddenDeath {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Suddendeath); ok {
+			return x.Suddendeath
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetChaser() *ChaserState {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Chaser); ok {
+			return x.Chaser
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetLifelost() *LifeLost {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Lifelost); ok {
+			return x.Lifelost
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetRespawn() *Respawn {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Respawn); ok {
+			return x.Respawn
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetLevel() *Level {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_Level); ok {
+			return x.Level
+		}
+	}
+	return nil
+}
+
+type isEnvelope_Payload interface {
+	isEnvelope_Payload()
+}
+
+type Envelope_State struct {
+	State *State `protobuf:"bytes,10,opt,name=state,proto3,oneof"`
+}
+
+type Envelope_Snapshot struct {
+	Snapshot *Snapshot `protobuf:"bytes,11,opt,name=snapshot,proto3,oneof"`
+}
+
+type Envelope_Pos struct {
+	Pos *PlayerUpdate `protobuf:"bytes,12,opt,name=pos,proto3,oneof"`
+}
+
+type Envelope_Active struct {
+	Active *PlayerUpdate `protobuf:"bytes,13,opt,name=active,proto3,oneof"`
+}
+
+type Envelope_Dis struct {
+	Dis *PlayerUpdate `protobuf:"bytes,14,opt,name=dis,proto3,oneof"`
+}
+
+type Envelope_Pel struct {
+	Pel *Pellet `protobuf:"bytes,15,opt,name=pel,proto3,oneof"`
+}
+
+type Envelope_Pow struct {
+	Pow *PowerUp `protobuf:"bytes,16,opt,name=pow,proto3,oneof"`
+}
+
+type Envelope_Powend struct {
+	Powend *PowerUpEnd `protobuf:"bytes,17,opt,name=powend,proto3,oneof"`
+}
+
+type Envelope_Kill struct {
+	Kill *Kill `protobuf:"bytes,18,opt,name=kill,proto3,oneof"`
+}
+
+type Envelope_Eliminated struct {
+	Eliminated *Eliminated `protobuf:"bytes,19,opt,name=eliminated,proto3,oneof"`
+}
+
+type Envelope_Reconnecting struct {
+	Reconnecting *Reconnecting `protobuf:"bytes,20,opt,name=reconnecting,proto3,oneof"`
+}
+
+type Envelope_Resumed struct {
+	Resumed *Resumed `protobuf:"bytes,21,opt,name=resumed,proto3,oneof"`
+}
+
+type Envelope_Lobbystatus struct {
+	Lobbystatus *LobbyStatus `protobuf:"bytes,22,opt,name=lobbystatus,proto3,oneof"`
+}
+
+type Envelope_Countdown struct {
+	Countdown *Countdown `protobuf:"bytes,23,opt,name=countdown,proto3,oneof"`
+}
+
+type Envelope_Countdownstarted struct {
+	Countdownstarted *CountdownStarted `protobuf:"bytes,24,opt,name=countdownstarted,proto3,oneof"`
+}
+
+type Envelope_Gamestart struct {
+	Gamestart *GameStart `protobuf:"bytes,25,opt,name=gamestart,proto3,oneof"`
+}
+
+type Envelope_Gameover struct {
+	Gameover *GameOver `protobuf:"bytes,26,opt,name=gameover,proto3,oneof"`
+}
+
+type Envelope_Error struct {
+	Error *ErrorMessage `protobuf:"bytes,27,opt,name=error,proto3,oneof"`
+}
+
+type Envelope_PhaseUpdate struct {
+	PhaseUpdate *PhaseUpdate `protobuf:"bytes,28,opt,name=phase_update,json=phaseUpdate,proto3,oneof"`
+}
+
+type Envelope_PhaseChange struct {
+	PhaseChange *PhaseChange `protobuf:"bytes,29,opt,name=phase_change,json=phaseChange,proto3,oneof"`
+}
+
+type Envelope_MazeUpdate struct {
+	MazeUpdate *MazeUpdate `protobuf:"bytes,30,opt,name=maze_update,json=mazeUpdate,proto3,oneof"`
+}
+
+type Envelope_EntitiesUpdate struct {
+	EntitiesUpdate *EntitiesUpdate `protobuf:"bytes,31,opt,name=entities_update,json=entitiesUpdate,proto3,oneof"`
+}
+
+type Envelope_EntityNear struct {
+	EntityNear *EntityNear `protobuf:"bytes,32,opt,name=entity_near,json=entityNear,proto3,oneof"`
+}
+
+type Envelope_EntityCollision struct {
+	EntityCollision *EntityCollision `protobuf:"bytes,33,opt,name=entity_collision,json=entityCollision,proto3,oneof"`
+}
+
+type Envelope_ZoneQuery struct {
+	ZoneQuery *ZoneQuery `protobuf:"bytes,34,opt,name=zone_query,json=zoneQuery,proto3,oneof"`
+}
+
+type Envelope_DynamicState struct {
+	DynamicState *DynamicState `protobuf:"bytes,35,opt,name=dynamic_state,json=dynamicState,proto3,oneof"`
+}
+
+type Envelope_Chat struct {
+	Chat *Chat `protobuf:"bytes,36,opt,name=chat,proto3,oneof"`
+}
+
+type Envelope_Replayinfo struct {
+	Replayinfo *ReplayInfo `protobuf:"bytes,37,opt,name=replayinfo,proto3,oneof"`
+}
+
+type Envelope_Replaystatus struct {
+	Replaystatus *ReplayStatus `protobuf:"bytes,38,opt,name=replaystatus,proto3,oneof"`
+}
+
+type Envelope_Timer struct {
+	Timer *Timer `protobuf:"bytes,39,opt,name=timer,proto3,oneof"`
+}
+
+type Envelope_Suddendeath struct {
+	Suddendeath *SuddenDeath `protobuf:"bytes,40,opt,name=suddendeath,proto3,oneof"`
+}
+
+type Envelope_Chaser struct {
+	Chaser *ChaserState `protobuf:"bytes,41,opt,name=chaser,proto3,oneof"`
+}
+
+type Envelope_Lifelost struct {
+	Lifelost *LifeLost `protobuf:"bytes,42,opt,name=lifelost,proto3,oneof"`
+}
+
+type Envelope_Respawn struct {
+	Respawn *Respawn `protobuf:"bytes,43,opt,name=respawn,proto3,oneof"`
+}
+
+type Envelope_Level struct {
+	Level *Level `protobuf:"bytes,44,opt,name=level,proto3,oneof"`
+}
+
+func (*Envelope_State) isEnvelope_Payload() {}
+
+func (*Envelope_Snapshot) isEnvelope_Payload() {}
+
+func (*Envelope_Pos) isEnvelope_Payload() {}
+
+func (*Envelope_Active) isEnvelope_Payload() {}
+
+func (*Envelope_Dis) isEnvelope_Payload() {}
+
+func (*Envelope_Pel) isEnvelope_Payload() {}
+
+func (*Envelope_Pow) isEnvelope_Payload() {}
+
+func (*Envelope_Powend) isEnvelope_Payload() {}
+
+func (*Envelope_Kill) isEnvelope_Payload() {}
+
+func (*Envelope_Eliminated) isEnvelope_Payload() {}
+
+func (*Envelope_Reconnecting) isEnvelope_Payload() {}
+
+func (*Envelope_Resumed) isEnvelope_Payload() {}
+
+func (*Envelope_Lobbystatus) isEnvelope_Payload() {}
+
+func (*Envelope_Countdown) isEnvelope_Payload() {}
+
+func (*Envelope_Countdownstarted) isEnvelope_Payload() {}
+
+func (*Envelope_Gamestart) isEnvelope_Payload() {}
+
+func (*Envelope_Gameover) isEnvelope_Payload() {}
+
+func (*Envelope_Error) isEnvelope_Payload() {}
+
+func (*Envelope_PhaseUpdate) isEnvelope_Payload() {}
+
+func (*Envelope_PhaseChange) isEnvelope_Payload() {}
+
+func (*Envelope_MazeUpdate) isEnvelope_Payload() {}
+
+func (*Envelope_EntitiesUpdate) isEnvelope_Payload() {}
+
+func (*Envelope_EntityNear) isEnvelope_Payload() {}
+
+func (*Envelope_EntityCollision) isEnvelope_Payload() {}
+
+func (*Envelope_ZoneQuery) isEnvelope_Payload() {}
+
+func (*Envelope_DynamicState) isEnvelope_Payload() {}
+
+func (*Envelope_Chat) isEnvelope_Payload() {}
+
+func (*Envelope_Replayinfo) isEnvelope_Payload() {}
+
+func (*Envelope_Replaystatus) isEnvelope_Payload() {}
+
+func (*Envelope_Timer) isEnvelope_Payload() {}
+
+func (*Envelope_Suddendeath) isEnvelope_Payload() {}
+
+func (*Envelope_Chaser) isEnvelope_Payload() {}
+
+func (*Envelope_Lifelost) isEnvelope_Payload() {}
+
+func (*Envelope_Respawn) isEnvelope_Payload() {}
+
+func (*Envelope_Level) isEnvelope_Payload() {}
+
+// Snapshot carries every event of one server tick in the order they happened
+type Snapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tick          uint64                 `protobuf:"varint,1,opt,name=tick,proto3" json:"tick,omitempty"`
+	Messages      []*Envelope            `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	mi := &file_game_v1_game_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Snapshot) GetTick() uint64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *Snapshot) GetMessages() []*Envelope {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+// Point is a position in pixels
+type Point struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             float64                `protobuf:"fixed64,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             float64                `protobuf:"fixed64,2,opt,name=y,proto3" json:"y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Point) Reset() {
+	*x = Point{}
+	mi := &file_game_v1_game_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Point) ProtoMessage() {}
+
+func (x *Point) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Point.ProtoReflect.Descriptor instead.
+func (*Point) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Point) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Point) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+// TilePos is a position in tiles
+type TilePos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             int32                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             int32                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TilePos) Reset() {
+	*x = TilePos{}
+	mi := &file_game_v1_game_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TilePos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TilePos) ProtoMessage() {}
+
+func (x *TilePos) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TilePos.ProtoReflect.Descriptor instead.
+func (*TilePos) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TilePos) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *TilePos) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+// PlayerUpdate is a player that moved (pos), joined (active) or left (dis)
+type PlayerUpdate struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Playerid    string                 `protobuf:"bytes,1,opt,name=playerid,proto3" json:"playerid,omitempty"`
+	User        string                 `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	SpriteType  string                 `protobuf:"bytes,3,opt,name=sprite_type,json=spriteType,proto3" json:"sprite_type,omitempty"`
+	X           float64                `protobuf:"fixed64,4,opt,name=x,proto3" json:"x,omitempty"`
+	Y           float64                `protobuf:"fixed64,5,opt,name=y,proto3" json:"y,omitempty"`
+	Dir         string                 `protobuf:"bytes,6,opt,name=dir,proto3" json:"dir,omitempty"`
+	IsReady     bool                   `protobuf:"varint,7,opt,name=is_ready,json=isReady,proto3" json:"is_ready,omitempty"`
+	IsHost      bool                   `protobuf:"varint,8,opt,name=is_host,json=isHost,proto3" json:"is_host,omitempty"`
+	IsSpectator bool                   `protobuf:"varint,9,opt,name=is_spectator,json=isSpectator,proto3" json:"is_spectator,omitempty"`
+	// pellet or power-up eaten by the move
+	Pellet  *TilePos `protobuf:"bytes,10,opt,name=pellet,proto3" json:"pellet,omitempty"`
+	PowerUp *TilePos `protobuf:"bytes,11,opt,name=power_up,json=powerUp,proto3" json:"power_up,omitempty"`
+	Powered *bool    `protobuf:"varint,12,opt,name=powered,proto3,oneof" json:"powered,omitempty"`
+	// score after the move, only set when it changed
+	Score         *int32 `protobuf:"varint,13,opt,name=score,proto3,oneof" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerUpdate) Reset() {
+	*x = PlayerUpdate{}
+	mi := &file_game_v1_game_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerUpdate) ProtoMessage() {}
+
+func (x *PlayerUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerUpdate.ProtoReflect.Descriptor instead.
+func (*PlayerUpdate) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PlayerUpdate) GetPlayerid() string {
+	if x != nil {
+		return x.Playerid
+	}
+	return ""
+}
+
+func (x *PlayerUpdate) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *PlayerUpdate) GetSpriteType() string {
+	if x != nil {
+		return x.SpriteType
+	}
+	return ""
+}
+
+func (x *PlayerUpdate) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *PlayerUpdate) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *PlayerUpdate) GetDir() string {
+	if x != nil {
+		return x.Dir
+	}
+	return ""
+}
+
+func (x *PlayerUpdate) GetIsReady() bool {
+	if x != nil {
+		return x.IsReady
+	}
+	return false
+}
+
+func (x *PlayerUpdate) GetIsHost() bool {
+	if x != nil {
+		return x.IsHost
+	}
+	return false
+}
+
+func (x *PlayerUpdate) GetIsSpectator() bool {
+	if x != nil {
+		return x.IsSpectator
+	}
+	return false
+}
+
+func (x *PlayerUpdate) GetPellet() *TilePos {
+	if x != nil {
+		return x.Pellet
+	}
+	return nil
+}
+
+func (x *PlayerUpdate) GetPowerUp() *TilePos {
+	if x != nil {
+		return x.PowerUp
+	}
+	return nil
+}
+
+func (x *PlayerUpdate) GetPowered() bool {
+	if x != nil && x.Powered != nil {
+		return *x.Powered
+	}
+	return false
+}
+
+func (x *PlayerUpdate) GetScore() int32 {
+	if x != nil && x.Score != nil {
+		return *x.Score
+	}
+	return 0
+}
+
+type Pellet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             int32                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             int32                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,3,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Score         int32                  `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pellet) Reset() {
+	*x = Pellet{}
+	mi := &file_game_v1_game_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pellet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pellet) ProtoMessage() {}
+
+func (x *Pellet) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pellet.ProtoReflect.Descriptor instead.
+func (*Pellet) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Pellet) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Pellet) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Pellet) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *Pellet) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type PowerUp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// empty when the power-up is global (classic)
+	PlayerId string  `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	X        float64 `protobuf:"fixed64,2,opt,name=x,proto3" json:"x,omitempty"`
+	Y        float64 `protobuf:"fixed64,3,opt,name=y,proto3" json:"y,omitempty"`
+	// seconds
+	Duration      int32 `protobuf:"varint,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PowerUp) Reset() {
+	*x = PowerUp{}
+	mi := &file_game_v1_game_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PowerUp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PowerUp) ProtoMessage() {}
+
+func (x *PowerUp) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PowerUp.ProtoReflect.Descriptor instead.
+func (*PowerUp) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PowerUp) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PowerUp) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *PowerUp) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *PowerUp) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+type PowerUpEnd struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// empty when the power-up is global (classic)
+	PlayerId      string `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PowerUpEnd) Reset() {
+	*x = PowerUpEnd{}
+	mi := &file_game_v1_game_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PowerUpEnd) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PowerUpEnd) ProtoMessage() {}
+
+func (x *PowerUpEnd) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PowerUpEnd.ProtoReflect.Descriptor instead.
+func (*PowerUpEnd) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PowerUpEnd) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type Kill struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// sprite that got caught
+	SpriteId string `protobuf:"bytes,1,opt,name=sprite_id,json=spriteId,proto3" json:"sprite_id,omitempty"`
+	// chaser that caught the runner; empty when the runner ate a chaser
+	ChaserId      string `protobuf:"bytes,2,opt,name=chaser_id,json=chaserId,proto3" json:"chaser_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Kill) Reset() {
+	*x = Kill{}
+	mi := &file_game_v1_game_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Kill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Kill) ProtoMessage() {}
+
+func (x *Kill) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Kill.ProtoReflect.Descriptor instead.
+func (*Kill) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Kill) GetSpriteId() string {
+	if x != nil {
+		return x.SpriteId
+	}
+	return ""
+}
+
+func (x *Kill) GetChaserId() string {
+	if x != nil {
+		return x.ChaserId
+	}
+	return ""
+}
+
+type Eliminated struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	By       string                 `protobuf:"bytes,2,opt,name=by,proto3" json:"by,omitempty"`
+	// score of the player that eliminated
+	Score         int32 `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Eliminated) Reset() {
+	*x = Eliminated{}
+	mi := &file_game_v1_game_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Eliminated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Eliminated) ProtoMessage() {}
+
+func (x *Eliminated) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Eliminated.ProtoReflect.Descriptor instead.
+func (*Eliminated) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Eliminated) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *Eliminated) GetBy() string {
+	if x != nil {
+		return x.By
+	}
+	return ""
+}
+
+func (x *Eliminated) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type Reconnecting struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	SpriteType    string                 `protobuf:"bytes,2,opt,name=sprite_type,json=spriteType,proto3" json:"sprite_type,omitempty"`
+	GraceSec      int32                  `protobuf:"varint,3,opt,name=grace_sec,json=graceSec,proto3" json:"grace_sec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reconnecting) Reset() {
+	*x = Reconnecting{}
+	mi := &file_game_v1_game_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reconnecting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reconnecting) ProtoMessage() {}
+
+func (x *Reconnecting) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reconnecting.ProtoReflect.Descriptor instead.
+func (*Reconnecting) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Reconnecting) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *Reconnecting) GetSpriteType() string {
+	if x != nil {
+		return x.SpriteType
+	}
+	return ""
+}
+
+func (x *Reconnecting) GetGraceSec() int32 {
+	if x != nil {
+		return x.GraceSec
+	}
+	return 0
+}
+
+type Resumed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	SpriteType    string                 `protobuf:"bytes,2,opt,name=sprite_type,json=spriteType,proto3" json:"sprite_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Resumed) Reset() {
+	*x = Resumed{}
+	mi := &file_game_v1_game_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Resumed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resumed) ProtoMessage() {}
+
+func (x *Resumed) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resumed.ProtoReflect.Descriptor instead.
+func (*Resumed) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Resumed) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *Resumed) GetSpriteType() string {
+	if x != nil {
+		return x.SpriteType
+	}
+	return ""
+}
+
+type LobbyPlayer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	SpriteType    string                 `protobuf:"bytes,3,opt,name=sprite_type,json=spriteType,proto3" json:"sprite_type,omitempty"`
+	IsReady       bool                   `protobuf:"varint,4,opt,name=is_ready,json=isReady,proto3" json:"is_ready,omitempty"`
+	IsHost        bool                   `protobuf:"varint,5,opt,name=is_host,json=isHost,proto3" json:"is_host,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LobbyPlayer) Reset() {
+	*x = LobbyPlayer{}
+	mi := &file_game_v1_game_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LobbyPlayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LobbyPlayer) ProtoMessage() {}
+
+func (x *LobbyPlayer) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LobbyPlayer.ProtoReflect.Descriptor instead.
+func (*LobbyPlayer) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LobbyPlayer) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *LobbyPlayer) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LobbyPlayer) GetSpriteType() string {
+	if x != nil {
+		return x.SpriteType
+	}
+	return ""
+}
+
+func (x *LobbyPlayer) GetIsReady() bool {
+	if x != nil {
+		return x.IsReady
+	}
+	return false
+}
+
+func (x *LobbyPlayer) GetIsHost() bool {
+	if x != nil {
+		return x.IsHost
+	}
+	return false
+}
+
+type LobbyStatus struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Players        []*LobbyPlayer         `protobuf:"bytes,1,rep,name=players,proto3" json:"players,omitempty"`
+	Spectators     []*LobbyPlayer         `protobuf:"bytes,2,rep,name=spectators,proto3" json:"spectators,omitempty"`
+	SpectatorCount int32                  `protobuf:"varint,3,opt,name=spectator_count,json=spectatorCount,proto3" json:"spectator_count,omitempty"`
+	PlayerCount    int32                  `protobuf:"varint,4,opt,name=player_count,json=playerCount,proto3" json:"player_count,omitempty"`
+	ReadyCount     int32                  `protobuf:"varint,5,opt,name=ready_count,json=readyCount,proto3" json:"ready_count,omitempty"`
+	MatchStarted   bool                   `protobuf:"varint,6,opt,name=match_started,json=matchStarted,proto3" json:"match_started,omitempty"`
+	HostId         string                 `protobuf:"bytes,7,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
+	// easy, normal, hard
+	BotDifficulty string `protobuf:"bytes,8,opt,name=bot_difficulty,json=botDifficulty,proto3" json:"bot_difficulty,omitempty"`
+	// seconds, 0 plays without a round timer
+	RoundDuration int32 `protobuf:"varint,9,opt,name=round_duration,json=roundDuration,proto3" json:"round_duration,omitempty"`
+	SuddenDeath   bool  `protobuf:"varint,10,opt,name=sudden_death,json=suddenDeath,proto3" json:"sudden_death,omitempty"`
+	// lives of the runner in classic mode
+	Lives         int32 `protobuf:"varint,11,opt,name=lives,proto3" json:"lives,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LobbyStatus) Reset() {
+	*x = LobbyStatus{}
+	mi := &file_game_v1_game_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LobbyStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LobbyStatus) ProtoMessage() {}
+
+func (x *LobbyStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LobbyStatus.ProtoReflect.Descriptor instead.
+func (*LobbyStatus) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *LobbyStatus) GetPlayers() []*LobbyPlayer {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *LobbyStatus) GetSpectators() []*LobbyPlayer {
+	if x != nil {
+		return x.Spectators
+	}
+	return nil
+}
+
+func (x *LobbyStatus) GetSpectatorCount() int32 {
+	if x != nil {
+		return x.SpectatorCount
+	}
+	return 0
+}
+
+func (x *LobbyStatus) GetPlayerCount() int32 {
+	if x != nil {
+		return x.PlayerCount
+	}
+	return 0
+}
+
+func (x *LobbyStatus) GetReadyCount() int32 {
+	if x != nil {
+		return x.ReadyCount
+	}
+	return 0
+}
+
+func (x *LobbyStatus) GetMatchStarted() bool {
+	if x != nil {
+		return x.MatchStarted
+	}
+	return false
+}
+
+func (x *LobbyStatus) GetHostId() string {
+	if x != nil {
+		return x.HostId
+	}
+	return ""
+}
+
+func (x *LobbyStatus) GetBotDifficulty() string {
+	if x != nil {
+		return x.BotDifficulty
+	}
+	return ""
+}
+
+func (x *LobbyStatus) GetRoundDuration() int32 {
+	if x != nil {
+		return x.RoundDuration
+	}
+	return 0
+}
+
+func (x *LobbyStatus) GetSuddenDeath() bool {
+	if x != nil {
+		return x.SuddenDeath
+	}
+	return false
+}
+
+func (x *LobbyStatus) GetLives() int32 {
+	if x != nil {
+		return x.Lives
+	}
+	return 0
+}
+
+type Countdown struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Countdown) Reset() {
+	*x = Countdown{}
+	mi := &file_game_v1_game_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Countdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Countdown) ProtoMessage() {}
+
+func (x *Countdown) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Countdown.ProtoReflect.Descriptor instead.
+func (*Countdown) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Countdown) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CountdownStarted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountdownStarted) Reset() {
+	*x = CountdownStarted{}
+	mi := &file_game_v1_game_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountdownStarted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountdownStarted) ProtoMessage() {}
+
+func (x *CountdownStarted) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountdownStarted.ProtoReflect.Descriptor instead.
+func (*CountdownStarted) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{15}
+}
+
+type GameStart struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Mode         string                 `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	DynamicState *DynamicState          `protobuf:"bytes,2,opt,name=dynamic_state,json=dynamicState,proto3" json:"dynamic_state,omitempty"`
+	// seconds, only set for matches with a round timer
+	RoundDuration *int32 `protobuf:"varint,3,opt,name=round_duration,json=roundDuration,proto3,oneof" json:"round_duration,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameStart) Reset() {
+	*x = GameStart{}
+	mi := &file_game_v1_game_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameStart) ProtoMessage() {}
+
+func (x *GameStart) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameStart.ProtoReflect.Descriptor instead.
+func (*GameStart) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GameStart) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *GameStart) GetDynamicState() *DynamicState {
+	if x != nil {
+		return x.DynamicState
+	}
+	return nil
+}
+
+func (x *GameStart) GetRoundDuration() int32 {
+	if x != nil && x.RoundDuration != nil {
+		return *x.RoundDuration
+	}
+	return 0
+}
+
+// Timer is the round timer, sent every second of a match with a round timer
+type Timer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RemainingMs   uint32                 `protobuf:"varint,1,opt,name=remaining_ms,json=remainingMs,proto3" json:"remaining_ms,omitempty"`
+	DurationMs    uint32                 `protobuf:"varint,2,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	SuddenDeath   bool                   `protobuf:"varint,3,opt,name=sudden_death,json=suddenDeath,proto3" json:"sudden_death,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Timer) Reset() {
+	*x = Timer{}
+	mi := &file_game_v1_game_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Timer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Timer) ProtoMessage() {}
+
+func (x *Timer) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Timer.ProtoReflect.Descriptor instead.
+func (*Timer) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Timer) GetRemainingMs() uint32 {
+	if x != nil {
+		return x.RemainingMs
+	}
+	return 0
+}
+
+func (x *Timer) GetDurationMs() uint32 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *Timer) GetSuddenDeath() bool {
+	if x != nil {
+		return x.SuddenDeath
+	}
+	return false
+}
+
+// SuddenDeath starts the last part of a round: everyone moves faster and the
+// power-ups vanish
+type SuddenDeath struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// tiles of the power-ups that vanished from the maze
+	PowerUps      []*Point `protobuf:"bytes,1,rep,name=power_ups,json=powerUps,proto3" json:"power_ups,omitempty"`
+	SpeedFactor   float64  `protobuf:"fixed64,2,opt,name=speed_factor,json=speedFactor,proto3" json:"speed_factor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuddenDeath) Reset() {
+	*x = SuddenDeath{}
+	mi := &file_game_v1_game_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuddenDeath) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuddenDeath) ProtoMessage() {}
+
+func (x *SuddenDeath) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuddenDeath.ProtoReflect.Descriptor instead.
+func (*SuddenDeath) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SuddenDeath) GetPowerUps() []*Point {
+	if x != nil {
+		return x.PowerUps
+	}
+	return nil
+}
+
+func (x *SuddenDeath) GetSpeedFactor() float64 {
+	if x != nil {
+		return x.SpeedFactor
+	}
+	return 0
+}
+
+// ChaserState follows a chaser through the chaser house: frightened by a
+// power-up, eyes on the way back after being eaten, waiting in the house and
+// active again once released
+type ChaserState struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	SpriteId string                 `protobuf:"bytes,1,opt,name=sprite_id,json=spriteId,proto3" json:"sprite_id,omitempty"`
+	// active, frightened, eyes, house
+	State string `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	// frightened: until it ends, house: until the chaser can be released
+	Ms            uint32 `protobuf:"varint,3,opt,name=ms,proto3" json:"ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChaserState) Reset() {
+	*x = ChaserState{}
+	mi := &file_game_v1_game_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChaserState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChaserState) ProtoMessage() {}
+
+func (x *ChaserState) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChaserState.ProtoReflect.Descriptor instead.
+func (*ChaserState) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ChaserState) GetSpriteId() string {
+	if x != nil {
+		return x.SpriteId
+	}
+	return ""
+}
+
+func (x *ChaserState) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ChaserState) GetMs() uint32 {
+	if x != nil {
+		return x.Ms
+	}
+	return 0
+}
+
+// LifeLost follows the kill of a runner that has lives left, everyone stands
+// still for freeze_ms and then respawns
+type LifeLost struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lives         int32                  `protobuf:"varint,1,opt,name=lives,proto3" json:"lives,omitempty"`
+	FreezeMs      uint32                 `protobuf:"varint,2,opt,name=freeze_ms,json=freezeMs,proto3" json:"freeze_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LifeLost) Reset() {
+	*x = LifeLost{}
+	mi := &file_game_v1_game_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LifeLost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LifeLost) ProtoMessage() {}
+
+func (x *LifeLost) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LifeLost.ProtoReflect.Descriptor instead.
+func (*LifeLost) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *LifeLost) GetLives() int32 {
+	if x != nil {
+		return x.Lives
+	}
+	return 0
+}
+
+func (x *LifeLost) GetFreezeMs() uint32 {
+	if x != nil {
+		return x.FreezeMs
+	}
+	return 0
+}
+
+// Respawn puts every player back on its spawn after a lost life or a new level
+type Respawn struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// by sprite type, in pixels
+	Positions     map[string]*Point `protobuf:"bytes,1,rep,name=positions,proto3" json:"positions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Respawn) Reset() {
+	*x = Respawn{}
+	mi := &file_game_v1_game_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Respawn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Respawn) ProtoMessage() {}
+
+func (x *Respawn) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Respawn.ProtoReflect.Descriptor instead.
+func (*Respawn) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Respawn) GetPositions() map[string]*Point {
+	if x != nil {
+		return x.Positions
+	}
+	return nil
+}
+
+// Level starts the next level: the maze is full again, chasers are faster and
+// power-ups shorter
+type Level struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Level             int32                  `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	ChaserSpeedFactor float64                `protobuf:"fixed64,2,opt,name=chaser_speed_factor,json=chaserSpeedFactor,proto3" json:"chaser_speed_factor,omitempty"`
+	// seconds
+	PowerUpDuration int32  `protobuf:"varint,3,opt,name=power_up_duration,json=powerUpDuration,proto3" json:"power_up_duration,omitempty"`
+	FreezeMs        uint32 `protobuf:"varint,4,opt,name=freeze_ms,json=freezeMs,proto3" json:"freeze_ms,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Level) Reset() {
+	*x = Level{}
+	mi := &file_game_v1_game_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Level) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Level) ProtoMessage() {}
+
+func (x *Level) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Level.ProtoReflect.Descriptor instead.
+func (*Level) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Level) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Level) GetChaserSpeedFactor() float64 {
+	if x != nil {
+		return x.ChaserSpeedFactor
+	}
+	return 0
+}
+
+func (x *Level) GetPowerUpDuration() int32 {
+	if x != nil {
+		return x.PowerUpDuration
+	}
+	return 0
+}
+
+func (x *Level) GetFreezeMs() uint32 {
+	if x != nil {
+		return x.FreezeMs
+	}
+	return 0
+}
+
+type GameOver struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Reason string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	Winner string                 `protobuf:"bytes,2,opt,name=winner,proto3" json:"winner,omitempty"`
+	// by player id
+	Scores map[string]int32 `protobuf:"bytes,3,rep,name=scores,proto3" json:"scores,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// classic mode: the level reached and the runner's lives left
+	Level int32 `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"`
+	Lives int32 `protobuf:"varint,5,opt,name=lives,proto3" json:"lives,omitempty"`
+	// usernames by player id, race and battle name their winner by player id
+	Names         map[string]string `protobuf:"bytes,6,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameOver) Reset() {
+	*x = GameOver{}
+	mi := &file_game_v1_game_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameOver) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameOver) ProtoMessage() {}
+
+func (x *GameOver) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameOver.ProtoReflect.Descriptor instead.
+func (*GameOver) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GameOver) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *GameOver) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+func (x *GameOver) GetScores() map[string]int32 {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *GameOver) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *GameOver) GetLives() int32 {
+	if x != nil {
+		return x.Lives
+	}
+	return 0
+}
+
+func (x *GameOver) GetNames() map[string]string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type ErrorMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorMessage) Reset() {
+	*x = ErrorMessage{}
+	mi := &file_game_v1_game_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorMessage) ProtoMessage() {}
+
+func (x *ErrorMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorMessage.ProtoReflect.Descriptor instead.
+func (*ErrorMessage) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ErrorMessage) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ActivePlayer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	X             float64                `protobuf:"fixed64,2,opt,name=x,proto3" json:"x,omitempty"`
+	Y             float64                `protobuf:"fixed64,3,opt,name=y,proto3" json:"y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActivePlayer) Reset() {
+	*x = ActivePlayer{}
+	mi := &file_game_v1_game_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivePlayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivePlayer) ProtoMessage() {}
+
+func (x *ActivePlayer) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivePlayer.ProtoReflect.Descriptor instead.
+func (*ActivePlayer) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ActivePlayer) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ActivePlayer) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *ActivePlayer) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+type Tunnel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	A             *TilePos               `protobuf:"bytes,1,opt,name=a,proto3" json:"a,omitempty"`
+	B             *TilePos               `protobuf:"bytes,2,opt,name=b,proto3" json:"b,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tunnel) Reset() {
+	*x = Tunnel{}
+	mi := &file_game_v1_game_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tunnel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tunnel) ProtoMessage() {}
+
+func (x *Tunnel) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tunnel.ProtoReflect.Descriptor instead.
+func (*Tunnel) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Tunnel) GetA() *TilePos {
+	if x != nil {
+		return x.A
+	}
+	return nil
+}
+
+func (x *Tunnel) GetB() *TilePos {
+	if x != nil {
+		return x.B
+	}
+	return nil
+}
+
+// MapInfo is the map as clients draw it, one character per tile
+type MapInfo struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Name         string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Width        int32                  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height       int32                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Tiles        []string               `protobuf:"bytes,4,rep,name=tiles,proto3" json:"tiles,omitempty"`
+	Tunnels      []*Tunnel              `protobuf:"bytes,5,rep,name=tunnels,proto3" json:"tunnels,omitempty"`
+	TotalPellets int32                  `protobuf:"varint,6,opt,name=total_pellets,json=totalPellets,proto3" json:"total_pellets,omitempty"`
+	// seed the map was generated from, 0 for map files
+	Seed          int64 `protobuf:"varint,7,opt,name=seed,proto3" json:"seed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapInfo) Reset() {
+	*x = MapInfo{}
+	mi := &file_game_v1_game_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapInfo) ProtoMessage() {}
+
+func (x *MapInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapInfo.ProtoReflect.Descriptor instead.
+func (*MapInfo) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *MapInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MapInfo) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *MapInfo) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *MapInfo) GetTiles() []string {
+	if x != nil {
+		return x.Tiles
+	}
+	return nil
+}
+
+func (x *MapInfo) GetTunnels() []*Tunnel {
+	if x != nil {
+		return x.Tunnels
+	}
+	return nil
+}
+
+func (x *MapInfo) GetTotalPellets() int32 {
+	if x != nil {
+		return x.TotalPellets
+	}
+	return 0
+}
+
+func (x *MapInfo) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+// State is the full match state a client gets when it connects, and the
+// first message of a replay
+type State struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProtocolVersion uint32                 `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Mode            string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	ChasersEaten    []string               `protobuf:"bytes,3,rep,name=chasers_eaten,json=chasersEaten,proto3" json:"chasers_eaten,omitempty"`
+	Eliminated      []string               `protobuf:"bytes,4,rep,name=eliminated,proto3" json:"eliminated,omitempty"`
+	// by sprite type
+	ActivePlayers map[string]*ActivePlayer `protobuf:"bytes,5,rep,name=active_players,json=activePlayers,proto3" json:"active_players,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	PlayersList   []*LobbyPlayer           `protobuf:"bytes,6,rep,name=players_list,json=playersList,proto3" json:"players_list,omitempty"`
+	PelletsEaten  []*Point                 `protobuf:"bytes,7,rep,name=pellets_eaten,json=pelletsEaten,proto3" json:"pellets_eaten,omitempty"`
+	PowerUpsEaten []*Point                 `protobuf:"bytes,8,rep,name=power_ups_eaten,json=powerUpsEaten,proto3" json:"power_ups_eaten,omitempty"`
+	SecretToken   string                   `protobuf:"bytes,9,opt,name=secret_token,json=secretToken,proto3" json:"secret_token,omitempty"`
+	SpriteId      string                   `protobuf:"bytes,10,opt,name=sprite_id,json=spriteId,proto3" json:"sprite_id,omitempty"`
+	SpriteType    string                   `protobuf:"bytes,11,opt,name=sprite_type,json=spriteType,proto3" json:"sprite_type,omitempty"`
+	Username      string                   `protobuf:"bytes,12,opt,name=username,proto3" json:"username,omitempty"`
+	PlayerId      string                   `protobuf:"bytes,13,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	MatchStarted  bool                     `protobuf:"varint,14,opt,name=match_started,json=matchStarted,proto3" json:"match_started,omitempty"`
+	HostId        string                   `protobuf:"bytes,15,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
+	IsHost        bool                     `protobuf:"varint,16,opt,name=is_host,json=isHost,proto3" json:"is_host,omitempty"`
+	PlayerCount   int32                    `protobuf:"varint,17,opt,name=player_count,json=playerCount,proto3" json:"player_count,omitempty"`
+	ReadyCount    int32                    `protobuf:"varint,18,opt,name=ready_count,json=readyCount,proto3" json:"ready_count,omitempty"`
+	// by player id
+	Scores map[string]int32 `protobuf:"bytes,19,rep,name=scores,proto3" json:"scores,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// by sprite type
+	SpawnPositions map[string]*Point `protobuf:"bytes,20,rep,name=spawn_positions,json=spawnPositions,proto3" json:"spawn_positions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Map            *MapInfo          `protobuf:"bytes,21,opt,name=map,proto3" json:"map,omitempty"`
+	IsSpectator    bool              `protobuf:"varint,22,opt,name=is_spectator,json=isSpectator,proto3" json:"is_spectator,omitempty"`
+	SpectatorCount int32             `protobuf:"varint,23,opt,name=spectator_count,json=spectatorCount,proto3" json:"spectator_count,omitempty"`
+	Replay         bool              `protobuf:"varint,24,opt,name=replay,proto3" json:"replay,omitempty"`
+	// set for players, the token to resume the session with after a drop
+	ResumeToken *string  `protobuf:"bytes,25,opt,name=resume_token,json=resumeToken,proto3,oneof" json:"resume_token,omitempty"`
+	X           *float64 `protobuf:"fixed64,26,opt,name=x,proto3,oneof" json:"x,omitempty"`
+	Y           *float64 `protobuf:"fixed64,27,opt,name=y,proto3,oneof" json:"y,omitempty"`
+	// by sprite type, chasers that are not active
+	Chasers map[string]string `protobuf:"bytes,28,rep,name=chasers,proto3" json:"chasers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// classic mode: the current level and the runner's lives left
+	Level         int32 `protobuf:"varint,29,opt,name=level,proto3" json:"level,omitempty"`
+	Lives         int32 `protobuf:"varint,30,opt,name=lives,proto3" json:"lives,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *State) Reset() {
+	*x = State{}
+	mi := &file_game_v1_game_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *State) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*State) ProtoMessage() {}
+
+func (x *State) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use State.ProtoReflect.Descriptor instead.
+func (*State) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *State) GetProtocolVersion() uint32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+func (x *State) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *State) GetChasersEaten() []string {
+	if x != nil {
+		return x.ChasersEaten
+	}
+	return nil
+}
+
+func (x *State) GetEliminated() []string {
+	if x != nil {
+		return x.Eliminated
+	}
+	return nil
+}
+
+func (x *State) GetActivePlayers() map[string]*ActivePlayer {
+	if x != nil {
+		return x.ActivePlayers
+	}
+	return nil
+}
+
+func (x *State) GetPlayersList() []*LobbyPlayer {
+	if x != nil {
+		return x.PlayersList
+	}
+	return nil
+}
+
+func (x *State) GetPelletsEaten() []*Point {
+	if x != nil {
+		return x.PelletsEaten
+	}
+	return nil
+}
+
+func (x *State) GetPowerUpsEaten() []*Point {
+	if x != nil {
+		return x.PowerUpsEaten
+	}
+	return nil
+}
+
+func (x *State) GetSecretToken() string {
+	if x != nil {
+		return x.SecretToken
+	}
+	return ""
+}
+
+func (x *State) GetSpriteId() string {
+	if x != nil {
+		return x.SpriteId
+	}
+	return ""
+}
+
+func (x *State) GetSpriteType() string {
+	if x != nil {
+		return x.SpriteType
+	}
+	return ""
+}
+
+func (x *State) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *State) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *State) GetMatchStarted() bool {
+	if x != nil {
+		return x.MatchStarted
+	}
+	return false
+}
+
+func (x *State) GetHostId() string {
+	if x != nil {
+		return x.HostId
+	}
+	return ""
+}
+
+func (x *State) GetIsHost() bool {
+	if x != nil {
+		return x.IsHost
+	}
+	return false
+}
+
+func (x *State) GetPlayerCount() int32 {
+	if x != nil {
+		return x.PlayerCount
+	}
+	return 0
+}
+
+func (x *State) GetReadyCount() int32 {
+	if x != nil {
+		return x.ReadyCount
+	}
+	return 0
+}
+
+func (x *State) GetScores() map[string]int32 {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *State) GetSpawnPositions() map[string]*Point {
+	if x != nil {
+		return x.SpawnPositions
+	}
+	return nil
+}
+
+func (x *State) GetMap() *MapInfo {
+	if x != nil {
+		return x.Map
+	}
+	return nil
+}
+
+func (x *State) GetIsSpectator() bool {
+	if x != nil {
+		return x.IsSpectator
+	}
+	return false
+}
+
+func (x *State) GetSpectatorCount() int32 {
+	if x != nil {
+		return x.SpectatorCount
+	}
+	return 0
+}
+
+func (x *State) GetReplay() bool {
+	if x != nil {
+		return x.Replay
+	}
+	return false
+}
+
+func (x *State) GetResumeToken() string {
+	if x != nil && x.ResumeToken != nil {
+		return *x.ResumeToken
+	}
+	return ""
+}
+
+func (x *State) GetX() float64 {
+	if x != nil && x.X != nil {
+		return *x.X
+	}
+	return 0
+}
+
+func (x *State) GetY() float64 {
+	if x != nil && x.Y != nil {
+		return *x.Y
+	}
+	return 0
+}
+
+func (x *State) GetChasers() map[string]string {
+	if x != nil {
+		return x.Chasers
+	}
+	return nil
+}
+
+func (x *State) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *State) GetLives() int32 {
+	if x != nil {
+		return x.Lives
+	}
+	return 0
+}
+
+type Zone struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// safe, neutral, danger
+	Type          string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	X             int32  `protobuf:"varint,3,opt,name=x,proto3" json:"x,omitempty"`
+	Y             int32  `protobuf:"varint,4,opt,name=y,proto3" json:"y,omitempty"`
+	Width         int32  `protobuf:"varint,5,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32  `protobuf:"varint,6,opt,name=height,proto3" json:"height,omitempty"`
+	IsActive      bool   `protobuf:"varint,7,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Zone) Reset() {
+	*x = Zone{}
+	mi := &file_game_v1_game_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Zone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Zone) ProtoMessage() {}
+
+func (x *Zone) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Zone.ProtoReflect.Descriptor instead.
+func (*Zone) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Zone) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Zone) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Zone) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Zone) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Zone) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Zone) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Zone) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type PhaseUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// day, dusk, night, dawn
+	Phase string `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"`
+	// 0-1 through the phase
+	Progress      float64 `protobuf:"fixed64,2,opt,name=progress,proto3" json:"progress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhaseUpdate) Reset() {
+	*x = PhaseUpdate{}
+	mi := &file_game_v1_game_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhaseUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhaseUpdate) ProtoMessage() {}
+
+func (x *PhaseUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhaseUpdate.ProtoReflect.Descriptor instead.
+func (*PhaseUpdate) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PhaseUpdate) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *PhaseUpdate) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+type PhaseChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewPhase      string                 `protobuf:"bytes,1,opt,name=new_phase,json=newPhase,proto3" json:"new_phase,omitempty"`
+	Zones         []*Zone                `protobuf:"bytes,2,rep,name=zones,proto3" json:"zones,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhaseChange) Reset() {
+	*x = PhaseChange{}
+	mi := &file_game_v1_game_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhaseChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhaseChange) ProtoMessage() {}
+
+func (x *PhaseChange) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhaseChange.ProtoReflect.Descriptor instead.
+func (*PhaseChange) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PhaseChange) GetNewPhase() string {
+	if x != nil {
+		return x.NewPhase
+	}
+	return ""
+}
+
+func (x *PhaseChange) GetZones() []*Zone {
+	if x != nil {
+		return x.Zones
+	}
+	return nil
+}
+
+type MazeUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// wall_add, wall_remove, wall_move
+	Type    string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	X       int32  `protobuf:"varint,2,opt,name=x,proto3" json:"x,omitempty"`
+	Y       int32  `protobuf:"varint,3,opt,name=y,proto3" json:"y,omitempty"`
+	TargetX *int32 `protobuf:"varint,4,opt,name=target_x,json=targetX,proto3,oneof" json:"target_x,omitempty"`
+	TargetY *int32 `protobuf:"varint,5,opt,name=target_y,json=targetY,proto3,oneof" json:"target_y,omitempty"`
+	// animation duration in ms
+	Duration int32 `protobuf:"varint,6,opt,name=duration,proto3" json:"duration,omitempty"`
+	// ms until the server undoes the update, unset on the undo itself
+	RevertIn      *int32 `protobuf:"varint,7,opt,name=revert_in,json=revertIn,proto3,oneof" json:"revert_in,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MazeUpdate) Reset() {
+	*x = MazeUpdate{}
+	mi := &file_game_v1_game_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MazeUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MazeUpdate) ProtoMessage() {}
+
+func (x *MazeUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MazeUpdate.ProtoReflect.Descriptor instead.
+func (*MazeUpdate) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *MazeUpdate) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *MazeUpdate) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *MazeUpdate) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *MazeUpdate) GetTargetX() int32 {
+	if x != nil && x.TargetX != nil {
+		return *x.TargetX
+	}
+	return 0
+}
+
+func (x *MazeUpdate) GetTargetY() int32 {
+	if x != nil && x.TargetY != nil {
+		return *x.TargetY
+	}
+	return 0
+}
+
+func (x *MazeUpdate) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *MazeUpdate) GetRevertIn() int32 {
+	if x != nil && x.RevertIn != nil {
+		return *x.RevertIn
+	}
+	return 0
+}
+
+type Entity struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// hunter, scanner, sweeper
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// patrol, alert, chase, return, dormant
+	State     string  `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	X         float64 `protobuf:"fixed64,4,opt,name=x,proto3" json:"x,omitempty"`
+	Y         float64 `protobuf:"fixed64,5,opt,name=y,proto3" json:"y,omitempty"`
+	Dir       string  `protobuf:"bytes,6,opt,name=dir,proto3" json:"dir,omitempty"`
+	Glow      float64 `protobuf:"fixed64,7,opt,name=glow,proto3" json:"glow,omitempty"`
+	GlowColor string  `protobuf:"bytes,8,opt,name=glow_color,json=glowColor,proto3" json:"glow_color,omitempty"`
+	// 0-1
+	Alert          float64 `protobuf:"fixed64,9,opt,name=alert,proto3" json:"alert,omitempty"`
+	ScanDirection  float64 `protobuf:"fixed64,10,opt,name=scan_direction,json=scanDirection,proto3" json:"scan_direction,omitempty"`
+	ScanAngle      float64 `protobuf:"fixed64,11,opt,name=scan_angle,json=scanAngle,proto3" json:"scan_angle,omitempty"`
+	DetectionRange float64 `protobuf:"fixed64,12,opt,name=detection_range,json=detectionRange,proto3" json:"detection_range,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Entity) Reset() {
+	*x = Entity{}
+	mi := &file_game_v1_game_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Entity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Entity) ProtoMessage() {}
+
+func (x *Entity) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Entity.ProtoReflect.Descriptor instead.
+func (*Entity) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Entity) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Entity) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Entity) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Entity) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Entity) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Entity) GetDir() string {
+	if x != nil {
+		return x.Dir
+	}
+	return ""
+}
+
+func (x *Entity) GetGlow() float64 {
+	if x != nil {
+		return x.Glow
+	}
+	return 0
+}
+
+func (x *Entity) GetGlowColor() string {
+	if x != nil {
+		return x.GlowColor
+	}
+	return ""
+}
+
+func (x *Entity) GetAlert() float64 {
+	if x != nil {
+		return x.Alert
+	}
+	return 0
+}
+
+func (x *Entity) GetScanDirection() float64 {
+	if x != nil {
+		return x.ScanDirection
+	}
+	return 0
+}
+
+func (x *Entity) GetScanAngle() float64 {
+	if x != nil {
+		return x.ScanAngle
+	}
+	return 0
+}
+
+func (x *Entity) GetDetectionRange() float64 {
+	if x != nil {
+		return x.DetectionRange
+	}
+	return 0
+}
+
+// EntitiesUpdate is the entities a player is interested in. With a baseline
+// it only holds the entities that changed since the snapshot of that tick,
+// which the client acknowledged, and the ones it no longer gets in removed.
+type EntitiesUpdate struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Entities []*Entity              `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	// tick of the acknowledged snapshot, 0 when entities is the full set
+	Baseline uint64 `protobuf:"varint,2,opt,name=baseline,proto3" json:"baseline,omitempty"`
+	// ids of entities out of interest since the baseline
+	Removed       []string `protobuf:"bytes,3,rep,name=removed,proto3" json:"removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntitiesUpdate) Reset() {
+	*x = EntitiesUpdate{}
+	mi := &file_game_v1_game_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntitiesUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntitiesUpdate) ProtoMessage() {}
+
+func (x *EntitiesUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntitiesUpdate.ProtoReflect.Descriptor instead.
+func (*EntitiesUpdate) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *EntitiesUpdate) GetEntities() []*Entity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+func (x *EntitiesUpdate) GetBaseline() uint64 {
+	if x != nil {
+		return x.Baseline
+	}
+	return 0
+}
+
+func (x *EntitiesUpdate) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+// EntityNear warns that an entity touches a player it may not catch, like a
+// player in an active safe zone
+type EntityNear struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntityId      string                 `protobuf:"bytes,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Warning       bool                   `protobuf:"varint,2,opt,name=warning,proto3" json:"warning,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,3,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntityNear) Reset() {
+	*x = EntityNear{}
+	mi := &file_game_v1_game_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityNear) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityNear) ProtoMessage() {}
+
+func (x *EntityNear) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityNear.ProtoReflect.Descriptor instead.
+func (*EntityNear) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *EntityNear) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *EntityNear) GetWarning() bool {
+	if x != nil {
+		return x.Warning
+	}
+	return false
+}
+
+func (x *EntityNear) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+// EntityCollision is a player caught by a danger entity, the server detects
+// catches every entity tick
+type EntityCollision struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	EntityId   string                 `protobuf:"bytes,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	EntityType string                 `protobuf:"bytes,2,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	Caught     bool                   `protobuf:"varint,3,opt,name=caught,proto3" json:"caught,omitempty"`
+	PlayerId   string                 `protobuf:"bytes,4,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	// stun or eliminated, depending on the game mode
+	Outcome string `protobuf:"bytes,5,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	// how long a stunned player cannot move
+	StunMs        uint32 `protobuf:"varint,6,opt,name=stun_ms,json=stunMs,proto3" json:"stun_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntityCollision) Reset() {
+	*x = EntityCollision{}
+	mi := &file_game_v1_game_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityCollision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityCollision) ProtoMessage() {}
+
+func (x *EntityCollision) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityCollision.ProtoReflect.Descriptor instead.
+func (*EntityCollision) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *EntityCollision) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *EntityCollision) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *EntityCollision) GetCaught() bool {
+	if x != nil {
+		return x.Caught
+	}
+	return false
+}
+
+func (x *EntityCollision) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *EntityCollision) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *EntityCollision) GetStunMs() uint32 {
+	if x != nil {
+		return x.StunMs
+	}
+	return 0
+}
+
+type ZoneQuery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// unset outside every zone
+	Zone          *Zone `protobuf:"bytes,1,opt,name=zone,proto3" json:"zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZoneQuery) Reset() {
+	*x = ZoneQuery{}
+	mi := &file_game_v1_game_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZoneQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZoneQuery) ProtoMessage() {}
+
+func (x *ZoneQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZoneQuery.ProtoReflect.Descriptor instead.
+func (*ZoneQuery) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ZoneQuery) GetZone() *Zone {
+	if x != nil {
+		return x.Zone
+	}
+	return nil
+}
+
+type ZonesState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Zones         []*Zone                `protobuf:"bytes,1,rep,name=zones,proto3" json:"zones,omitempty"`
+	Phase         string                 `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"`
+	Progress      float64                `protobuf:"fixed64,3,opt,name=progress,proto3" json:"progress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZonesState) Reset() {
+	*x = ZonesState{}
+	mi := &file_game_v1_game_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZonesState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZonesState) ProtoMessage() {}
+
+func (x *ZonesState) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZonesState.ProtoReflect.Descriptor instead.
+func (*ZonesState) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ZonesState) GetZones() []*Zone {
+	if x != nil {
+		return x.Zones
+	}
+	return nil
+}
+
+func (x *ZonesState) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *ZonesState) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+// DynamicState is the state of zones, entities and maze changes, for clients
+// that join or resync
+type DynamicState struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Zones    *ZonesState            `protobuf:"bytes,1,opt,name=zones,proto3" json:"zones,omitempty"`
+	Entities []*Entity              `protobuf:"bytes,2,rep,name=entities,proto3" json:"entities,omitempty"`
+	// maze updates in effect, not yet reverted
+	MazeUpdates   []*MazeUpdate `protobuf:"bytes,3,rep,name=maze_updates,json=mazeUpdates,proto3" json:"maze_updates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DynamicState) Reset() {
+	*x = DynamicState{}
+	mi := &file_game_v1_game_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DynamicState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DynamicState) ProtoMessage() {}
+
+func (x *DynamicState) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DynamicState.ProtoReflect.Descriptor instead.
+func (*DynamicState) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DynamicState) GetZones() *ZonesState {
+	if x != nil {
+		return x.Zones
+	}
+	return nil
+}
+
+func (x *DynamicState) GetEntities() []*Entity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+func (x *DynamicState) GetMazeUpdates() []*MazeUpdate {
+	if x != nil {
+		return x.MazeUpdates
+	}
+	return nil
+}
+
+type Chat struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Username string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Message  string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// unix seconds
+	Timestamp     int64 `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Chat) Reset() {
+	*x = Chat{}
+	mi := &file_game_v1_game_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Chat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chat) ProtoMessage() {}
+
+func (x *Chat) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chat.ProtoReflect.Descriptor instead.
+func (*Chat) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Chat) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *Chat) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Chat) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Chat) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type ReplayInfo struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	MatchId uint32                 `protobuf:"varint,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Mode    string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	// RFC 3339
+	StartedAt     string `protobuf:"bytes,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	DurationMs    uint32 `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayInfo) Reset() {
+	*x = ReplayInfo{}
+	mi := &file_game_v1_game_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayInfo) ProtoMessage() {}
+
+func (x *ReplayInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayInfo.ProtoReflect.Descriptor instead.
+func (*ReplayInfo) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ReplayInfo) GetMatchId() uint32 {
+	if x != nil {
+		return x.MatchId
+	}
+	return 0
+}
+
+func (x *ReplayInfo) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *ReplayInfo) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *ReplayInfo) GetDurationMs() uint32 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+type ReplayStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AtMs          uint32                 `protobuf:"varint,1,opt,name=at_ms,json=atMs,proto3" json:"at_ms,omitempty"`
+	DurationMs    uint32                 `protobuf:"varint,2,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Paused        bool                   `protobuf:"varint,3,opt,name=paused,proto3" json:"paused,omitempty"`
+	Speed         float64                `protobuf:"fixed64,4,opt,name=speed,proto3" json:"speed,omitempty"`
+	Ended         bool                   `protobuf:"varint,5,opt,name=ended,proto3" json:"ended,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayStatus) Reset() {
+	*x = ReplayStatus{}
+	mi := &file_game_v1_game_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayStatus) ProtoMessage() {}
+
+func (x *ReplayStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_game_v1_game_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayStatus.ProtoReflect.Descriptor instead.
+func (*ReplayStatus) Descriptor() ([]byte, []int) {
+	return file_game_v1_game_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ReplayStatus) GetAtMs() uint32 {
+	if x != nil {
+		return x.AtMs
+	}
+	return 0
+}
+
+func (x *ReplayStatus) GetDurationMs() uint32 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *ReplayStatus) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *ReplayStatus) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *ReplayStatus) GetEnded() bool {
+	if x != nil {
+		return x.Ended
+	}
+	return false
+}
+
+var File_game_v1_game_proto protoreflect.FileDescriptor
+
+const file_game_v1_game_proto_rawDesc = "" +
+	"\n" +
+	"\x12game/v1/game.proto\x12\agame.v1\"\xd3\x0e\n" +
+	"\bEnvelope\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\rR\aversion\x12\x10\n" +
+	"\x03seq\x18\x02 \x01(\x04R\x03seq\x12\x0e\n" +
+	"\x02ts\x18\x03 \x01(\x03R\x02ts\x12&\n" +
+	"\x05state\x18\n" +
+	" \x01(\v2\x0e.game.v1.StateH\x00R\x05state\x12/\n" +
+	"\bsnapshot\x18\v \x01(\v2\x11.game.v1.SnapshotH\x00R\bsnapshot\x12)\n" +
+	"\x03pos\x18\f \x01(\v2\x15.game.v1.PlayerUpdateH\x00R\x03pos\x12/\n" +
+	"\x06active\x18\r \x01(\v2\x15.game.v1.PlayerUpdateH\x00R\x06active\x12)\n" +
+	"\x03dis\x18\x0e \x01(\v2\x15.game.v1.PlayerUpdateH\x00R\x03dis\x12#\n" +
+	"\x03pel\x18\x0f \x01(\v2\x0f.game.v1.PelletH\x00R\x03pel\x12$\n" +
+	"\x03pow\x18\x10 \x01(\v2\x10.game.v1.PowerUpH\x00R\x03pow\x12-\n" +
+	"\x06powend\x18\x11 \x01(\v2\x13.game.v1.PowerUpEndH\x00R\x06powend\x12#\n" +
+	"\x04kill\x18\x12 \x01(\v2\r.game.v1.KillH\x00R\x04kill\x125\n" +
+	"\n" +
+	"eliminated\x18\x13 \x01(\v2\x13.game.v1.EliminatedH\x00R\n" +
+	"eliminated\x12;\n" +
+	"\freconnecting\x18\x14 \x01(\v2\x15.game.v1.ReconnectingH\x00R\freconnecting\x12,\n" +
+	"\aresumed\x18\x15 \x01(\v2\x10.game.v1.ResumedH\x00R\aresumed\x128\n" +
+	"\vlobbystatus\x18\x16 \x01(\v2\x14.game.v1.LobbyStatusH\x00R\vlobbystatus\x122\n" +
+	"\tcountdown\x18\x17 \x01(\v2\x12.game.v1.CountdownH\x00R\tcountdown\x12G\n" +
+	"\x10countdownstarted\x18\x18 \x01(\v2\x19.game.v1.CountdownStartedH\x00R\x10countdownstarted\x122\n" +
+	"\tgamestart\x18\x19 \x01(\v2\x12.game.v1.GameStartH\x00R\tgamestart\x12/\n" +
+	"\bgameover\x18\x1a \x01(\v2\x11.game.v1.GameOverH\x00R\bgameover\x12-\n" +
+	"\x05error\x18\x1b \x01(\v2\x15.game.v1.ErrorMessageH\x00R\x05error\x129\n" +
+	"\fphase_update\x18\x1c \x01(\v2\x14.game.v1.PhaseUpdateH\x00R\vphaseUpdate\x129\n" +
+	"\fphase_change\x18\x1d \x01(\v2\x14.game.v1.PhaseChangeH\x00R\vphaseChange\x126\n" +
+	"\vmaze_update\x18\x1e \x01(\v2\x13.game.v1.MazeUpdateH\x00R\n" +
+	"mazeUpdate\x12B\n" +
+	"\x0fentities_update\x18\x1f \x01(\v2\x17.game.v1.EntitiesUpdateH\x00R\x0eentitiesUpdate\x126\n" +
+	"\ventity_near\x18  \x01(\v2\x13.game.v1.EntityNearH\x00R\n" +
+	"entityNear\x12E\n" +
+	"\x10entity_collision\x18! \x01(\v2\x18.game.v1.EntityCollisionH\x00R\x0fentityCollision\x123\n" +
+	"\n" +
+	"zone_query\x18\" \x01(\v2\x12.game.v1.ZoneQueryH\x00R\tzoneQuery\x12<\n" +
+	"\rdynamic_state\x18# \x01(\v2\x15.game.v1.DynamicStateH\x00R\fdynamicState\x12#\n" +
+	"\x04chat\x18$ \x01(\v2\r.game.v1.ChatH\x00R\x04chat\x125\n" +
+	"\n" +
+	"replayinfo\x18% \x01(\v2\x13.game.v1.ReplayInfoH\x00R\n" +
+	"replayinfo\x12;\n" +
+	"\freplaystatus\x18& \x01(\v2\x15.game.v1.ReplayStatusH\x00R\freplaystatus\x12&\n" +
+	"\x05timer\x18' \x01(\v2\x0e.game.v1.TimerH\x00R\x05timer\x128\n" +
+	"\vsuddendeath\x18( \x01(\v2\x14.game.v1.SuddenDeathH\x00R\vsuddendeath\x12.\n" +
+	"\x06chaser\x18) \x01(\v2\x14.game.v1.ChaserStateH\x00R\x06chaser\x12/\n" +
+	"\blifelost\x18* \x01(\v2\x11.game.v1.LifeLostH\x00R\blifelost\x12,\n" +
+	"\arespawn\x18+ \x01(\v2\x10.game.v1.RespawnH\x00R\arespawn\x12&\n" +
+	"\x05level\x18, \x01(\v2\x0e.game.v1.LevelH\x00R\x05levelB\t\n" +
+	"\apayload\"M\n" +
+	"\bSnapshot\x12\x12\n" +
+	"\x04tick\x18\x01 \x01(\x04R\x04tick\x12-\n" +
+	"\bmessages\x18\x02 \x03(\v2\x11.game.v1.EnvelopeR\bmessages\"#\n" +
+	"\x05Point\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x01R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x01R\x01y\"%\n" +
+	"\aTilePos\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x05R\x01y\"\x8b\x03\n" +
+	"\fPlayerUpdate\x12\x1a\n" +
+	"\bplayerid\x18\x01 \x01(\tR\bplayerid\x12\x12\n" +
+	"\x04user\x18\x02 \x01(\tR\x04user\x12\x1f\n" +
+	"\vsprite_type\x18\x03 \x01(\tR\n" +
+	"spriteType\x12\f\n" +
+	"\x01x\x18\x04 \x01(\x01R\x01x\x12\f\n" +
+	"\x01y\x18\x05 \x01(\x01R\x01y\x12\x10\n" +
+	"\x03dir\x18\x06 \x01(\tR\x03dir\x12\x19\n" +
+	"\bis_ready\x18\a \x01(\bR\aisReady\x12\x17\n" +
+	"\ais_host\x18\b \x01(\bR\x06isHost\x12!\n" +
+	"\fis_spectator\x18\t \x01(\bR\visSpectator\x12(\n" +
+	"\x06pellet\x18\n" +
+	" \x01(\v2\x10.game.v1.TilePosR\x06pellet\x12+\n" +
+	"\bpower_up\x18\v \x01(\v2\x10.game.v1.TilePosR\apowerUp\x12\x1d\n" +
+	"\apowered\x18\f \x01(\bH\x00R\apowered\x88\x01\x01\x12\x19\n" +
+	"\x05score\x18\r \x01(\x05H\x01R\x05score\x88\x01\x01B\n" +
+	"\n" +
+	"\b_poweredB\b\n" +
+	"\x06_score\"W\n" +
+	"\x06Pellet\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x05R\x01y\x12\x1b\n" +
+	"\tplayer_id\x18\x03 \x01(\tR\bplayerId\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x05R\x05score\"^\n" +
+	"\aPowerUp\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\f\n" +
+	"\x01x\x18\x02 \x01(\x01R\x01x\x12\f\n" +
+	"\x01y\x18\x03 \x01(\x01R\x01y\x12\x1a\n" +
+	"\bduration\x18\x04 \x01(\x05R\bduration\")\n" +
+	"\n" +
+	"PowerUpEnd\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\"@\n" +
+	"\x04Kill\x12\x1b\n" +
+	"\tsprite_id\x18\x01 \x01(\tR\bspriteId\x12\x1b\n" +
+	"\tchaser_id\x18\x02 \x01(\tR\bchaserId\"O\n" +
+	"\n" +
+	"Eliminated\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x0e\n" +
+	"\x02by\x18\x02 \x01(\tR\x02by\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x05R\x05score\"i\n" +
+	"\fReconnecting\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1f\n" +
+	"\vsprite_type\x18\x02 \x01(\tR\n" +
+	"spriteType\x12\x1b\n" +
+	"\tgrace_sec\x18\x03 \x01(\x05R\bgraceSec\"G\n" +
+	"\aResumed\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1f\n" +
+	"\vsprite_type\x18\x02 \x01(\tR\n" +
+	"spriteType\"\x9b\x01\n" +
+	"\vLobbyPlayer\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1f\n" +
+	"\vsprite_type\x18\x03 \x01(\tR\n" +
+	"spriteType\x12\x19\n" +
+	"\bis_ready\x18\x04 \x01(\bR\aisReady\x12\x17\n" +
+	"\ais_host\x18\x05 \x01(\bR\x06isHost\"\xa5\x03\n" +
+	"\vLobbyStatus\x12.\n" +
+	"\aplayers\x18\x01 \x03(\v2\x14.game.v1.LobbyPlayerR\aplayers\x124\n" +
+	"\n" +
+	"spectators\x18\x02 \x03(\v2\x14.game.v1.LobbyPlayerR\n" +
+	"spectators\x12'\n" +
+	"\x0fspectator_count\x18\x03 \x01(\x05R\x0espectatorCount\x12!\n" +
+	"\fplayer_count\x18\x04 \x01(\x05R\vplayerCount\x12\x1f\n" +
+	"\vready_count\x18\x05 \x01(\x05R\n" +
+	"readyCount\x12#\n" +
+	"\rmatch_started\x18\x06 \x01(\bR\fmatchStarted\x12\x17\n" +
+	"\ahost_id\x18\a \x01(\tR\x06hostId\x12%\n" +
+	"\x0ebot_difficulty\x18\b \x01(\tR\rbotDifficulty\x12%\n" +
+	"\x0eround_duration\x18\t \x01(\x05R\rroundDuration\x12!\n" +
+	"\fsudden_death\x18\n" +
+	" \x01(\bR\vsuddenDeath\x12\x14\n" +
+	"\x05lives\x18\v \x01(\x05R\x05lives\"!\n" +
+	"\tCountdown\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\"\x12\n" +
+	"\x10CountdownStarted\"\x9a\x01\n" +
+	"\tGameStart\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12:\n" +
+	"\rdynamic_state\x18\x02 \x01(\v2\x15.game.v1.DynamicStateR\fdynamicState\x12*\n" +
+	"\x0eround_duration\x18\x03 \x01(\x05H\x00R\rroundDuration\x88\x01\x01B\x11\n" +
+	"\x0f_round_duration\"n\n" +
+	"\x05Timer\x12!\n" +
+	"\fremaining_ms\x18\x01 \x01(\rR\vremainingMs\x12\x1f\n" +
+	"\vduration_ms\x18\x02 \x01(\rR\n" +
+	"durationMs\x12!\n" +
+	"\fsudden_death\x18\x03 \x01(\bR\vsuddenDeath\"]\n" +
+	"\vSuddenDeath\x12+\n" +
+	"\tpower_ups\x18\x01 \x03(\v2\x0e.game.v1.PointR\bpowerUps\x12!\n" +
+	"\fspeed_factor\x18\x02 \x01(\x01R\vspeedFactor\"P\n" +
+	"\vChaserState\x12\x1b\n" +
+	"\tsprite_id\x18\x01 \x01(\tR\bspriteId\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x0e\n" +
+	"\x02ms\x18\x03 \x01(\rR\x02ms\"=\n" +
+	"\bLifeLost\x12\x14\n" +
+	"\x05lives\x18\x01 \x01(\x05R\x05lives\x12\x1b\n" +
+	"\tfreeze_ms\x18\x02 \x01(\rR\bfreezeMs\"\x96\x01\n" +
+	"\aRespawn\x12=\n" +
+	"\tpositions\x18\x01 \x03(\v2\x1f.game.v1.Respawn.PositionsEntryR\tpositions\x1aL\n" +
+	"\x0ePositionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12$\n" +
+	"\x05value\x18\x02 \x01(\v2\x0e.game.v1.PointR\x05value:\x028\x01\"\x96\x01\n" +
+	"\x05Level\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\x05R\x05level\x12.\n" +
+	"\x13chaser_speed_factor\x18\x02 \x01(\x01R\x11chaserSpeedFactor\x12*\n" +
+	"\x11power_up_duration\x18\x03 \x01(\x05R\x0fpowerUpDuration\x12\x1b\n" +
+	"\tfreeze_ms\x18\x04 \x01(\rR\bfreezeMs\"\xc6\x02\n" +
+	"\bGameOver\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06winner\x18\x02 \x01(\tR\x06winner\x125\n" +
+	"\x06scores\x18\x03 \x03(\v2\x1d.game.v1.GameOver.ScoresEntryR\x06scores\x12\x14\n" +
+	"\x05level\x18\x04 \x01(\x05R\x05level\x12\x14\n" +
+	"\x05lives\x18\x05 \x01(\x05R\x05lives\x122\n" +
+	"\x05names\x18\x06 \x03(\v2\x1c.game.v1.GameOver.NamesEntryR\x05names\x1a9\n" +
+	"\vScoresEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a8\n" +
+	"\n" +
+	"NamesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"$\n" +
+	"\fErrorMessage\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"F\n" +
+	"\fActivePlayer\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\f\n" +
+	"\x01x\x18\x02 \x01(\x01R\x01x\x12\f\n" +
+	"\x01y\x18\x03 \x01(\x01R\x01y\"H\n" +
+	"\x06Tunnel\x12\x1e\n" +
+	"\x01a\x18\x01 \x01(\v2\x10.game.v1.TilePosR\x01a\x12\x1e\n" +
+	"\x01b\x18\x02 \x01(\v2\x10.game.v1.TilePosR\x01b\"\xc5\x01\n" +
+	"\aMapInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x05R\x06height\x12\x14\n" +
+	"\x05tiles\x18\x04 \x03(\tR\x05tiles\x12)\n" +
+	"\atunnels\x18\x05 \x03(\v2\x0f.game.v1.TunnelR\atunnels\x12#\n" +
+	"\rtotal_pellets\x18\x06 \x01(\x05R\ftotalPellets\x12\x12\n" +
+	"\x04seed\x18\a \x01(\x03R\x04seed\"\xaa\v\n" +
+	"\x05State\x12)\n" +
+	"\x10protocol_version\x18\x01 \x01(\rR\x0fprotocolVersion\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12#\n" +
+	"\rchasers_eaten\x18\x03 \x03(\tR\fchasersEaten\x12\x1e\n" +
+	"\n" +
+	"eliminated\x18\x04 \x03(\tR\n" +
+	"eliminated\x12H\n" +
+	"\x0eactive_players\x18\x05 \x03(\v2!.game.v1.State.ActivePlayersEntryR\ractivePlayers\x127\n" +
+	"\fplayers_list\x18\x06 \x03(\v2\x14.game.v1.LobbyPlayerR\vplayersList\x123\n" +
+	"\rpellets_eaten\x18\a \x03(\v2\x0e.game.v1.PointR\fpelletsEaten\x126\n" +
+	"\x0fpower_ups_eaten\x18\b \x03(\v2\x0e.game.v1.PointR\rpowerUpsEaten\x12!\n" +
+	"\fsecret_token\x18\t \x01(\tR\vsecretToken\x12\x1b\n" +
+	"\tsprite_id\x18\n" +
+	" \x01(\tR\bspriteId\x12\x1f\n" +
+	"\vsprite_type\x18\v \x01(\tR\n" +
+	"spriteType\x12\x1a\n" +
+	"\busername\x18\f \x01(\tR\busername\x12\x1b\n" +
+	"\tplayer_id\x18\r \x01(\tR\bplayerId\x12#\n" +
+	"\rmatch_started\x18\x0e \x01(\bR\fmatchStarted\x12\x17\n" +
+	"\ahost_id\x18\x0f \x01(\tR\x06hostId\x12\x17\n" +
+	"\ais_host\x18\x10 \x01(\bR\x06isHost\x12!\n" +
+	"\fplayer_count\x18\x11 \x01(\x05R\vplayerCount\x12\x1f\n" +
+	"\vready_count\x18\x12 \x01(\x05R\n" +
+	"readyCount\x122\n" +
+	"\x06scores\x18\x13 \x03(\v2\x1a.game.v1.State.ScoresEntryR\x06scores\x12K\n" +
+	"\x0fspawn_positions\x18\x14 \x03(\v2\".game.v1.State.SpawnPositionsEntryR\x0espawnPositions\x12\"\n" +
+	"\x03map\x18\x15 \x01(\v2\x10.game.v1.MapInfoR\x03map\x12!\n" +
+	"\fis_spectator\x18\x16 \x01(\bR\visSpectator\x12'\n" +
+	"\x0fspectator_count\x18\x17 \x01(\x05R\x0espectatorCount\x12\x16\n" +
+	"\x06replay\x18\x18 \x01(\bR\x06replay\x12&\n" +
+	"\fresume_token\x18\x19 \x01(\tH\x00R\vresumeToken\x88\x01\x01\x12\x11\n" +
+	"\x01x\x18\x1a \x01(\x01H\x01R\x01x\x88\x01\x01\x12\x11\n" +
+	"\x01y\x18\x1b \x01(\x01H\x02R\x01y\x88\x01\x01\x125\n" +
+	"\achasers\x18\x1c \x03(\v2\x1b.game.v1.State.ChasersEntryR\achasers\x12\x14\n" +
+	"\x05level\x18\x1d \x01(\x05R\x05level\x12\x14\n" +
+	"\x05lives\x18\x1e \x01(\x05R\x05lives\x1aW\n" +
+	"\x12ActivePlayersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12+\n" +
+	"\x05value\x18\x02 \x01(\v2\x15.game.v1.ActivePlayerR\x05value:\x028\x01\x1a9\n" +
+	"\vScoresEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1aQ\n" +
+	"\x13SpawnPositionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12$\n" +
+	"\x05value\x18\x02 \x01(\v2\x0e.game.v1.PointR\x05value:\x028\x01\x1a:\n" +
+	"\fChasersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0f\n" +
+	"\r_resume_tokenB\x04\n" +
+	"\x02_xB\x04\n" +
+	"\x02_y\"\x91\x01\n" +
+	"\x04Zone\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\f\n" +
+	"\x01x\x18\x03 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x04 \x01(\x05R\x01y\x12\x14\n" +
+	"\x05width\x18\x05 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x06 \x01(\x05R\x06height\x12\x1b\n" +
+	"\tis_active\x18\a \x01(\bR\bisActive\"?\n" +
+	"\vPhaseUpdate\x12\x14\n" +
+	"\x05phase\x18\x01 \x01(\tR\x05phase\x12\x1a\n" +
+	"\bprogress\x18\x02 \x01(\x01R\bprogress\"O\n" +
+	"\vPhaseChange\x12\x1b\n" +
+	"\tnew_phase\x18\x01 \x01(\tR\bnewPhase\x12#\n" +
+	"\x05zones\x18\x02 \x03(\v2\r.game.v1.ZoneR\x05zones\"\xe2\x01\n" +
+	"\n" +
+	"MazeUpdate\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\f\n" +
+	"\x01x\x18\x02 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x03 \x01(\x05R\x01y\x12\x1e\n" +
+	"\btarget_x\x18\x04 \x01(\x05H\x00R\atargetX\x88\x01\x01\x12\x1e\n" +
+	"\btarget_y\x18\x05 \x01(\x05H\x01R\atargetY\x88\x01\x01\x12\x1a\n" +
+	"\bduration\x18\x06 \x01(\x05R\bduration\x12 \n" +
+	"\trevert_in\x18\a \x01(\x05H\x02R\brevertIn\x88\x01\x01B\v\n" +
+	"\t_target_xB\v\n" +
+	"\t_target_yB\f\n" +
+	"\n" +
+	"_revert_in\"\xa8\x02\n" +
+	"\x06Entity\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\f\n" +
+	"\x01x\x18\x04 \x01(\x01R\x01x\x12\f\n" +
+	"\x01y\x18\x05 \x01(\x01R\x01y\x12\x10\n" +
+	"\x03dir\x18\x06 \x01(\tR\x03dir\x12\x12\n" +
+	"\x04glow\x18\a \x01(\x01R\x04glow\x12\x1d\n" +
+	"\n" +
+	"glow_color\x18\b \x01(\tR\tglowColor\x12\x14\n" +
+	"\x05alert\x18\t \x01(\x01R\x05alert\x12%\n" +
+	"\x0escan_direction\x18\n" +
+	" \x01(\x01R\rscanDirection\x12\x1d\n" +
+	"\n" +
+	"scan_angle\x18\v \x01(\x01R\tscanAngle\x12'\n" +
+	"\x0fdetection_range\x18\f \x01(\x01R\x0edetectionRange\"s\n" +
+	"\x0eEntitiesUpdate\x12+\n" +
+	"\bentities\x18\x01 \x03(\v2\x0f.game.v1.EntityR\bentities\x12\x1a\n" +
+	"\bbaseline\x18\x02 \x01(\x04R\bbaseline\x12\x18\n" +
+	"\aremoved\x18\x03 \x03(\tR\aremoved\"`\n" +
+	"\n" +
+	"EntityNear\x12\x1b\n" +
+	"\tentity_id\x18\x01 \x01(\tR\bentityId\x12\x18\n" +
+	"\awarning\x18\x02 \x01(\bR\awarning\x12\x1b\n" +
+	"\tplayer_id\x18\x03 \x01(\tR\bplayerId\"\xb7\x01\n" +
+	"\x0fEntityCollision\x12\x1b\n" +
+	"\tentity_id\x18\x01 \x01(\tR\bentityId\x12\x1f\n" +
+	"\ventity_type\x18\x02 \x01(\tR\n" +
+	"entityType\x12\x16\n" +
+	"\x06caught\x18\x03 \x01(\bR\x06caught\x12\x1b\n" +
+	"\tplayer_id\x18\x04 \x01(\tR\bplayerId\x12\x18\n" +
+	"\aoutcome\x18\x05 \x01(\tR\aoutcome\x12\x17\n" +
+	"\astun_ms\x18\x06 \x01(\rR\x06stunMs\".\n" +
+	"\tZoneQuery\x12!\n" +
+	"\x04zone\x18\x01 \x01(\v2\r.game.v1.ZoneR\x04zone\"c\n" +
+	"\n" +
+	"ZonesState\x12#\n" +
+	"\x05zones\x18\x01 \x03(\v2\r.game.v1.ZoneR\x05zones\x12\x14\n" +
+	"\x05phase\x18\x02 \x01(\tR\x05phase\x12\x1a\n" +
+	"\bprogress\x18\x03 \x01(\x01R\bprogress\"\x9e\x01\n" +
+	"\fDynamicState\x12)\n" +
+	"\x05zones\x18\x01 \x01(\v2\x13.game.v1.ZonesStateR\x05zones\x12+\n" +
+	"\bentities\x18\x02 \x03(\v2\x0f.game.v1.EntityR\bentities\x126\n" +
+	"\fmaze_updates\x18\x03 \x03(\v2\x13.game.v1.MazeUpdateR\vmazeUpdates\"w\n" +
+	"\x04Chat\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\"{\n" +
+	"\n" +
+	"ReplayInfo\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\rR\amatchId\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x03 \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\rR\n" +
+	"durationMs\"\x88\x01\n" +
+	"\fReplayStatus\x12\x13\n" +
+	"\x05at_ms\x18\x01 \x01(\rR\x04atMs\x12\x1f\n" +
+	"\vduration_ms\x18\x02 \x01(\rR\n" +
+	"durationMs\x12\x16\n" +
+	"\x06paused\x18\x03 \x01(\bR\x06paused\x12\x14\n" +
+	"\x05speed\x18\x04 \x01(\x01R\x05speed\x12\x14\n" +
+	"\x05ended\x18\x05 \x01(\bR\x05endedB\x87\x01\n" +
+	"\vcom.game.v1B\tGameProtoP\x01Z0github.com/frank2889/mazechase/generated/game/v1\xa2\x02\x03GXX\xaa\x02\aGame.V1\xca\x02\aGame\\V1\xe2\x02\x13Game\\V1\\GPBMetadata\xea\x02\bGame::V1b\x06proto3"
+
+var (
+	file_game_v1_game_proto_rawDescOnce sync.Once
+	file_game_v1_game_proto_rawDescData []byte
+)
+
+func file_game_v1_game_proto_rawDescGZIP() []byte {
+	file_game_v1_game_proto_rawDescOnce.Do(func() {
+		file_game_v1_game_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_game_v1_game_proto_rawDesc), len(file_game_v1_game_proto_rawDesc)))
+	})
+	return file_game_v1_game_proto_rawDescData
+}
+
+var file_game_v1_game_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
+var file_game_v1_game_proto_goTypes = []any{
+	(*Envelope)(nil),         // 0: game.v1.Envelope
+	(*Snapshot)(nil),         // 1: game.v1.Snapshot
+	(*Point)(nil),            // 2: game.v1.Point
+	(*TilePos)(nil),          // 3: game.v1.TilePos
+	(*PlayerUpdate)(nil),     // 4: game.v1.PlayerUpdate
+	(*Pellet)(nil),           // 5: game.v1.Pellet
+	(*PowerUp)(nil),          // 6: game.v1.PowerUp
+	(*PowerUpEnd)(nil),       // 7: game.v1.PowerUpEnd
+	(*Kill)(nil),             // 8: game.v1.Kill
+	(*Eliminated)(nil),       // 9: game.v1.Eliminated
+	(*Reconnecting)(nil),     // 10: game.v1.Reconnecting
+	(*Resumed)(nil),          // 11: game.v1.Resumed
+	(*LobbyPlayer)(nil),      // 12: game.v1.LobbyPlayer
+	(*LobbyStatus)(nil),      // 13: game.v1.LobbyStatus
+	(*Countdown)(nil),        // 14: game.v1.Countdown
+	(*CountdownStarted)(nil), // 15: game.v1.CountdownStarted
+	(*GameStart)(nil),        // 16: game.v1.GameStart
+	(*Timer)(nil),            // 17: game.v1.Timer
+	(*SuddenDeath)(nil),      // 18: game.v1.SuddenDeath
+	(*ChaserState)(nil),      // 19: game.v1.ChaserState
+	(*LifeLost)(nil),         // 20: game.v1.LifeLost
+	(*Respawn)(nil),          // 21: game.v1.Respawn
+	(*Level)(nil),            // 22: game.v1.Level
+	(*GameOver)(nil),         // 23: game.v1.GameOver
+	(*ErrorMessage)(nil),     // 24: game.v1.ErrorMessage
+	(*ActivePlayer)(nil),     // 25: game.v1.ActivePlayer
+	(*Tunnel)(nil),           // 26: game.v1.Tunnel
+	(*MapInfo)(nil),          // 27: game.v1.MapInfo
+	(*State)(nil),            // 28: game.v1.State
+	(*Zone)(nil),             // 29: game.v1.Zone
+	(*PhaseUpdate)(nil),      // 30: game.v1.PhaseUpdate
+	(*PhaseChange)(nil),      // 31: game.v1.PhaseChange
+	(*MazeUpdate)(nil),       // 32: game.v1.MazeUpdate
+	(*Entity)(nil),           // 33: game.v1.Entity
+	(*EntitiesUpdate)(nil),   // 34: game.v1.EntitiesUpdate
+	(*EntityNear)(nil),       // 35: game.v1.EntityNear
+	(*EntityCollision)(nil),  // 36: game.v1.EntityCollision
+	(*ZoneQuery)(nil),        // 37: game.v1.ZoneQuery
+	(*ZonesState)(nil),       // 38: game.v1.ZonesState
+	(*DynamicState)(nil),     // 39: game.v1.DynamicState
+	(*Chat)(nil),             // 40: game.v1.Chat
+	(*ReplayInfo)(nil),       // 41: game.v1.ReplayInfo
+	(*ReplayStatus)(nil),     // 42: game.v1.ReplayStatus
+	nil,                      // 43: game.v1.Respawn.PositionsEntry
+	nil,                      // 44: game.v1.GameOver.ScoresEntry
+	nil,                      // 45: game.v1.GameOver.NamesEntry
+	nil,                      // 46: game.v1.State.ActivePlayersEntry
+	nil,                      // 47: game.v1.State.ScoresEntry
+	nil,                      // 48: game.v1.State.SpawnPositionsEntry
+	nil,                      // 49: game.v1.State.ChasersEntry
+}
+var file_game_v1_game_proto_depIdxs = []int32{
+	28, // 0: game.v1.Envelope.state:type_name -> game.v1.State
+	1,  // 1: game.v1.Envelope.snapshot:type_name -> game.v1.Snapshot
+	4,  // 2: game.v1.Envelope.pos:type_name -> game.v1.PlayerUpdate
+	4,  // 3: game.v1.Envelope.active:type_name -> game.v1.PlayerUpdate
+	4,  // 4: game.v1.Envelope.dis:type_name -> game.v1.PlayerUpdate
+	5,  // 5: game.v1.Envelope.pel:type_name -> game.v1.Pellet
+	6,  // 6: game.v1.Envelope.pow:type_name -> game.v1.PowerUp
+	7,  // 7: game.v1.Envelope.powend:type_name -> game.v1.PowerUpEnd
+	8,  // 8: game.v1.Envelope.kill:type_name -> game.v1.Kill
+	9,  // 9: game.v1.Envelope.eliminated:type_name -> game.v1.Eliminated
+	10, // 10: game.v1.Envelope.reconnecting:type_name -> game.v1.Reconnecting
+	11, // 11: game.v1.Envelope.resumed:type_name -> game.v1.Resumed
+	13, // 12: game.v1.Envelope.lobbystatus:type_name -> game.v1.LobbyStatus
+	14, // 13: game.v1.Envelope.countdown:type_name -> game.v1.Countdown
+	15, // 14: game.v1.Envelope.countdownstarted:type_name -> game.v1.CountdownStarted
+	16, // 15: game.v1.Envelope.gamestart:type_name -> game.v1.GameStart
+	23, // 16: game.v1.Envelope.gameover:type_name -> game.v1.GameOver
+	24, // 17: game.v1.Envelope.error:type_name -> game.v1.ErrorMessage
+	30, // 18: game.v1.Envelope.phase_update:type_name -> game.v1.PhaseUpdate
+	31, // 19: game.v1.Envelope.phase_change:type_name -> game.v1.PhaseChange
+	32, // 20: game.v1.Envelope.maze_update:type_name -> game.v1.MazeUpdate
+	34, // 21: game.v1.Envelope.entities_update:type_name -> game.v1.EntitiesUpdate
+	35, // 22: game.v1.Envelope.entity_near:type_name -> game.v1.EntityNear
+	36, // 23: game.v1.Envelope.entity_collision:type_name -> game.v1.EntityCollision
+	37, // 24: game.v1.Envelope.zone_query:type_name -> game.v1.ZoneQuery
+	39, // 25: game.v1.Envelope.dynamic_state:type_name -> game.v1.DynamicState
+	40, // 26: game.v1.Envelope.chat:type_name -> game.v1.Chat
+	41, // 27: game.v1.Envelope.replayinfo:type_name -> game.v1.ReplayInfo
+	42, // 28: game.v1.Envelope.replaystatus:type_name -> game.v1.ReplayStatus
+	17, // 29: game.v1.Envelope.timer:type_name -> game.v1.Timer
+	18, // 30: game.v1.Envelope.suddendeath:type_name -> game.v1.SuddenDeath
+	19, // 31: game.v1.Envelope.chaser:type_name -> game.v1.ChaserState
+	20, // 32: game.v1.Envelope.lifelost:type_name -> game.v1.LifeLost
+	21, // 33: game.v1.Envelope.respawn:type_name -> game.v1.Respawn
+	22, // 34: game.v1.Envelope.level:type_name -> game.v1.Level
+	0,  // 35: game.v1.Snapshot.messages:type_name -> game.v1.Envelope
+	3,  // 36: game.v1.PlayerUpdate.pellet:type_name -> game.v1.TilePos
+	3,  // 37: game.v1.PlayerUpdate.power_up:type_name -> game.v1.TilePos
+	12, // 38: game.v1.LobbyStatus.players:type_name -> game.v1.LobbyPlayer
+	12, // 39: game.v1.LobbyStatus.spectators:type_name -> game.v1.LobbyPlayer
+	39, // 40: game.v1.GameStart.dynamic_state:type_name -> game.v1.DynamicState
+	2,  // 41: game.v1.SuddenDeath.power_ups:type_name -> game.v1.Point
+	43, // 42: game.v1.Respawn.positions:type_name -> game.v1.Respawn.PositionsEntry
+	44, // 43: game.v1.GameOver.scores:type_name -> game.v1.GameOver.ScoresEntry
+	45, // 44: game.v1.GameOver.names:type_name -> game.v1.GameOver.NamesEntry
+	3,  // 45: game.v1.Tunnel.a:type_name -> game.v1.TilePos
+	3,  // 46: game.v1.Tunnel.b:type_name -> game.v1.TilePos
+	26, // 47: game.v1.MapInfo.tunnels:type_name -> game.v1.Tunnel
+	46, // 48: game.v1.State.active_players:type_name -> game.v1.State.ActivePlayersEntry
+	12, // 49: game.v1.State.players_list:type_name -> game.v1.LobbyPlayer
+	2,  // 50: game.v1.State.pellets_eaten:type_name -> game.v1.Point
+	2,  // 51: game.v1.State.power_ups_eaten:type_name -> game.v1.Point
+	47, // 52: game.v1.State.scores:type_name -> game.v1.State.ScoresEntry
+	48, // 53: game.v1.State.spawn_positions:type_name -> game.v1.State.SpawnPositionsEntry
+	27, // 54: game.v1.State.map:type_name -> game.v1.MapInfo
+	49, // 55: game.v1.State.chasers:type_name -> game.v1.State.ChasersEntry
+	29, // 56: game.v1.PhaseChange.zones:type_name -> game.v1.Zone
+	33, // 57: game.v1.EntitiesUpdate.entities:type_name -> game.v1.Entity
+	29, // 58: game.v1.ZoneQuery.zone:type_name -> game.v1.Zone
+	29, // 59: game.v1.ZonesState.zones:type_name -> game.v1.Zone
+	38, // 60: game.v1.DynamicState.zones:type_name -> game.v1.ZonesState
+	33, // 61: game.v1.DynamicState.entities:type_name -> game.v1.Entity
+	32, // 62: game.v1.DynamicState.maze_updates:type_name -> game.v1.MazeUpdate
+	2,  // 63: game.v1.Respawn.PositionsEntry.value:type_name -> game.v1.Point
+	25, // 64: game.v1.State.ActivePlayersEntry.value:type_name -> game.v1.ActivePlayer
+	2,  // 65: game.v1.State.SpawnPositionsEntry.value:type_name -> game.v1.Point
+	66, // [66:66] is the sub-list for method output_type
+	66, // [66:66] is the sub-list for method input_type
+	66, // [66:66] is the sub-list for extension type_name
+	66, // [66:66] is the sub-list for extension extendee
+	0,  // [0:66] is the sub-list for field type_name
+}
+
+func init() { file_game_v1_game_proto_init() }
+func file_game_v1_game_proto_init() {
+	if File_game_v1_game_proto != nil {
+		return
+	}
+	file_game_v1_game_proto_msgTypes[0].OneofWrappers = []any{
+		(*Envelope_State)(nil),
+		(*Envelope_Snapshot)(nil),
+		(*Envelope_Pos)(nil),
+		(*Envelope_Active)(nil),
+		(*Envelope_Dis)(nil),
+		(*Envelope_Pel)(nil),
+		(*Envelope_Pow)(nil),
+		(*Envelope_Powend)(nil),
+		(*Envelope_Kill)(nil),
+		(*Envelope_Eliminated)(nil),
+		(*Envelope_Reconnecting)(nil),
+		(*Envelope_Resumed)(nil),
+		(*Envelope_Lobbystatus)(nil),
+		(*Envelope_Countdown)(nil),
+		(*Envelope_Countdownstarted)(nil),
+		(*Envelope_Gamestart)(nil),
+		(*Envelope_Gameover)(nil),
+		(*Envelope_Error)(nil),
+		(*Envelope_PhaseUpdate)(nil),
+		(*Envelope_PhaseChange)(nil),
+		(*Envelope_MazeUpdate)(nil),
+		(*Envelope_EntitiesUpdate)(nil),
+		(*Envelope_EntityNear)(nil),
+		(*Envelope_EntityCollision)(nil),
+		(*Envelope_ZoneQuery)(nil),
+		(*Envelope_DynamicState)(nil),
+		(*Envelope_Chat)(nil),
+		(*Envelope_Replayinfo)(nil),
+		(*Envelope_Replaystatus)(nil),
+		(*Envelope_Timer)(nil),
+		(*Envelope_Suddendeath)(nil),
+		(*Envelope_Chaser)(nil),
+		(*Envelope_Lifelost)(nil),
+		(*Envelope_Respawn)(nil),
+		(*Envelope_Level)(nil),
+	}
+	file_game_v1_game_proto_msgTypes[4].OneofWrappers = []any{}
+	file_game_v1_game_proto_msgTypes[16].OneofWrappers = []any{}
+	file_game_v1_game_proto_msgTypes[28].OneofWrappers = []any{}
+	file_game_v1_game_proto_msgTypes[32].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_game_v1_game_proto_rawDesc), len(file_game_v1_game_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   50,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_game_v1_game_proto_goTypes,
+		DependencyIndexes: file_game_v1_game_proto_depIdxs,
+		MessageInfos:      file_game_v1_game_proto_msgTypes,
+	}.Build()
+	File_game_v1_game_proto = out.File
+	file_game_v1_game_proto_goTypes = nil
+	file_game_v1_game_proto_depIdxs = nil
+}
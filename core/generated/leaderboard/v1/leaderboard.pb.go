@@ -0,0 +1,573 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: leaderboard/v1/leaderboard.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TopPlayersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// classic, race, battle; empty means all modes
+	GameMode string `protobuf:"bytes,1,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	// day, week, month; empty means all time
+	TimeWindow    string `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
+	Limit         uint32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopPlayersRequest) Reset() {
+	*x = TopPlayersRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopPlayersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopPlayersRequest) ProtoMessage() {}
+
+func (x *TopPlayersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopPlayersRequest.ProtoReflect.Descriptor instead.
+func (*TopPlayersRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TopPlayersRequest) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *TopPlayersRequest) GetTimeWindow() string {
+	if x != nil {
+		return x.TimeWindow
+	}
+	return ""
+}
+
+func (x *TopPlayersRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TopPlayersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LeaderboardEntry    `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopPlayersResponse) Reset() {
+	*x = TopPlayersResponse{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopPlayersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopPlayersResponse) ProtoMessage() {}
+
+func (x *TopPlayersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopPlayersResponse.ProtoReflect.Descriptor instead.
+func (*TopPlayersResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TopPlayersResponse) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type PlayerStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 0 means the calling user
+	UserId   uint64 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	GameMode string `protobuf:"bytes,2,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	// day, week, month; empty means all time
+	TimeWindow    string `protobuf:"bytes,3,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerStatsRequest) Reset() {
+	*x = PlayerStatsRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerStatsRequest) ProtoMessage() {}
+
+func (x *PlayerStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerStatsRequest.ProtoReflect.Descriptor instead.
+func (*PlayerStatsRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PlayerStatsRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PlayerStatsRequest) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *PlayerStatsRequest) GetTimeWindow() string {
+	if x != nil {
+		return x.TimeWindow
+	}
+	return ""
+}
+
+type PlayerStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         *LeaderboardEntry      `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerStatsResponse) Reset() {
+	*x = PlayerStatsResponse{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerStatsResponse) ProtoMessage() {}
+
+func (x *PlayerStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerStatsResponse.ProtoReflect.Descriptor instead.
+func (*PlayerStatsResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PlayerStatsResponse) GetStats() *LeaderboardEntry {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type MyRankRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameMode      string                 `protobuf:"bytes,1,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	TimeWindow    string                 `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MyRankRequest) Reset() {
+	*x = MyRankRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MyRankRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MyRankRequest) ProtoMessage() {}
+
+func (x *MyRankRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MyRankRequest.ProtoReflect.Descriptor instead.
+func (*MyRankRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MyRankRequest) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *MyRankRequest) GetTimeWindow() string {
+	if x != nil {
+		return x.TimeWindow
+	}
+	return ""
+}
+
+type MyRankResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 0 when the user has not played in this selection yet
+	Rank          uint64            `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	TotalPlayers  uint64            `protobuf:"varint,2,opt,name=totalPlayers,proto3" json:"totalPlayers,omitempty"`
+	Entry         *LeaderboardEntry `protobuf:"bytes,3,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MyRankResponse) Reset() {
+	*x = MyRankResponse{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MyRankResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MyRankResponse) ProtoMessage() {}
+
+func (x *MyRankResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MyRankResponse.ProtoReflect.Descriptor instead.
+func (*MyRankResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MyRankResponse) GetRank() uint64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *MyRankResponse) GetTotalPlayers() uint64 {
+	if x != nil {
+		return x.TotalPlayers
+	}
+	return 0
+}
+
+func (x *MyRankResponse) GetEntry() *LeaderboardEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type LeaderboardEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rank          uint64                 `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	UserId        uint64                 `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	GamesPlayed   uint64                 `protobuf:"varint,4,opt,name=gamesPlayed,proto3" json:"gamesPlayed,omitempty"`
+	Wins          uint64                 `protobuf:"varint,5,opt,name=wins,proto3" json:"wins,omitempty"`
+	Losses        uint64                 `protobuf:"varint,6,opt,name=losses,proto3" json:"losses,omitempty"`
+	HighScore     int64                  `protobuf:"varint,7,opt,name=highScore,proto3" json:"highScore,omitempty"`
+	TotalScore    int64                  `protobuf:"varint,8,opt,name=totalScore,proto3" json:"totalScore,omitempty"`
+	PelletsEaten  uint64                 `protobuf:"varint,9,opt,name=pelletsEaten,proto3" json:"pelletsEaten,omitempty"`
+	ChasersEaten  uint64                 `protobuf:"varint,10,opt,name=chasersEaten,proto3" json:"chasersEaten,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEntry) ProtoMessage() {}
+
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LeaderboardEntry) GetRank() uint64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetGamesPlayed() uint64 {
+	if x != nil {
+		return x.GamesPlayed
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetWins() uint64 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetLosses() uint64 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetHighScore() int64 {
+	if x != nil {
+		return x.HighScore
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetTotalScore() int64 {
+	if x != nil {
+		return x.TotalScore
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetPelletsEaten() uint64 {
+	if x != nil {
+		return x.PelletsEaten
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetChasersEaten() uint64 {
+	if x != nil {
+		return x.ChasersEaten
+	}
+	return 0
+}
+
+var File_leaderboard_v1_leaderboard_proto protoreflect.FileDescriptor
+
+const file_leaderboard_v1_leaderboard_proto_rawDesc = "" +
+	"\n" +
+	" leaderboard/v1/leaderboard.proto\x12\x0eleaderboard.v1\"g\n" +
+	"\x11TopPlayersRequest\x12\x1b\n" +
+	"\tgame_mode\x18\x01 \x01(\tR\bgameMode\x12\x1f\n" +
+	"\vtime_window\x18\x02 \x01(\tR\n" +
+	"timeWindow\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\rR\x05limit\"P\n" +
+	"\x12TopPlayersResponse\x12:\n" +
+	"\aentries\x18\x01 \x03(\v2 .leaderboard.v1.LeaderboardEntryR\aentries\"k\n" +
+	"\x12PlayerStatsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12\x1b\n" +
+	"\tgame_mode\x18\x02 \x01(\tR\bgameMode\x12\x1f\n" +
+	"\vtime_window\x18\x03 \x01(\tR\n" +
+	"timeWindow\"M\n" +
+	"\x13PlayerStatsResponse\x126\n" +
+	"\x05stats\x18\x01 \x01(\v2 .leaderboard.v1.LeaderboardEntryR\x05stats\"M\n" +
+	"\rMyRankRequest\x12\x1b\n" +
+	"\tgame_mode\x18\x01 \x01(\tR\bgameMode\x12\x1f\n" +
+	"\vtime_window\x18\x02 \x01(\tR\n" +
+	"timeWindow\"\x80\x01\n" +
+	"\x0eMyRankResponse\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x04R\x04rank\x12\"\n" +
+	"\ftotalPlayers\x18\x02 \x01(\x04R\ftotalPlayers\x126\n" +
+	"\x05entry\x18\x03 \x01(\v2 .leaderboard.v1.LeaderboardEntryR\x05entry\"\xae\x02\n" +
+	"\x10LeaderboardEntry\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x04R\x04rank\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\x04R\x06userId\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12 \n" +
+	"\vgamesPlayed\x18\x04 \x01(\x04R\vgamesPlayed\x12\x12\n" +
+	"\x04wins\x18\x05 \x01(\x04R\x04wins\x12\x16\n" +
+	"\x06losses\x18\x06 \x01(\x04R\x06losses\x12\x1c\n" +
+	"\thighScore\x18\a \x01(\x03R\thighScore\x12\x1e\n" +
+	"\n" +
+	"totalScore\x18\b \x01(\x03R\n" +
+	"totalScore\x12\"\n" +
+	"\fpelletsEaten\x18\t \x01(\x04R\fpelletsEaten\x12\"\n" +
+	"\fchasersEaten\x18\n" +
+	" \x01(\x04R\fchasersEaten2\x99\x02\n" +
+	"\x12LeaderboardService\x12X\n" +
+	"\rGetTopPlayers\x12!.leaderboard.v1.TopPlayersRequest\x1a\".leaderboard.v1.TopPlayersResponse\"\x00\x12[\n" +
+	"\x0eGetPlayerStats\x12\".leaderboard.v1.PlayerStatsRequest\x1a#.leaderboard.v1.PlayerStatsResponse\"\x00\x12L\n" +
+	"\tGetMyRank\x12\x1d.leaderboard.v1.MyRankRequest\x1a\x1e.leaderboard.v1.MyRankResponse\"\x00B\xb8\x01\n" +
+	"\x12com.leaderboard.v1B\x10LeaderboardProtoP\x01Z7github.com/frank2889/mazechase/generated/leaderboard/v1\xa2\x02\x03LXX\xaa\x02\x0eLeaderboard.V1\xca\x02\x0eLeaderboard\\V1\xe2\x02\x1aLeaderboard\\V1\\GPBMetadata\xea\x02\x0fLeaderboard::V1b\x06proto3"
+
+var (
+	file_leaderboard_v1_leaderboard_proto_rawDescOnce sync.Once
+	file_leaderboard_v1_leaderboard_proto_rawDescData []byte
+)
+
+func file_leaderboard_v1_leaderboard_proto_rawDescGZIP() []byte {
+	file_leaderboard_v1_leaderboard_proto_rawDescOnce.Do(func() {
+		file_leaderboard_v1_leaderboard_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_leaderboard_v1_leaderboard_proto_rawDesc), len(file_leaderboard_v1_leaderboard_proto_rawDesc)))
+	})
+	return file_leaderboard_v1_leaderboard_proto_rawDescData
+}
+
+var file_leaderboard_v1_leaderboard_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_leaderboard_v1_leaderboard_proto_goTypes = []any{
+	(*TopPlayersRequest)(nil),   // 0: leaderboard.v1.TopPlayersRequest
+	(*TopPlayersResponse)(nil),  // 1: leaderboard.v1.TopPlayersResponse
+	(*PlayerStatsRequest)(nil),  // 2: leaderboard.v1.PlayerStatsRequest
+	(*PlayerStatsResponse)(nil), // 3: leaderboard.v1.PlayerStatsResponse
+	(*MyRankRequest)(nil),       // 4: leaderboard.v1.MyRankRequest
+	(*MyRankResponse)(nil),      // 5: leaderboard.v1.MyRankResponse
+	(*LeaderboardEntry)(nil),    // 6: leaderboard.v1.LeaderboardEntry
+}
+var file_leaderboard_v1_leaderboard_proto_depIdxs = []int32{
+	6, // 0: leaderboard.v1.TopPlayersResponse.entries:type_name -> leaderboard.v1.LeaderboardEntry
+	6, // 1: leaderboard.v1.PlayerStatsResponse.stats:type_name -> leaderboard.v1.LeaderboardEntry
+	6, // 2: leaderboard.v1.MyRankResponse.entry:type_name -> leaderboard.v1.LeaderboardEntry
+	0, // 3: leaderboard.v1.LeaderboardService.GetTopPlayers:input_type -> leaderboard.v1.TopPlayersRequest
+	2, // 4: leaderboard.v1.LeaderboardService.GetPlayerStats:input_type -> leaderboard.v1.PlayerStatsRequest
+	4, // 5: leaderboard.v1.LeaderboardService.GetMyRank:input_type -> leaderboard.v1.MyRankRequest
+	1, // 6: leaderboard.v1.LeaderboardService.GetTopPlayers:output_type -> leaderboard.v1.TopPlayersResponse
+	3, // 7: leaderboard.v1.LeaderboardService.GetPlayerStats:output_type -> leaderboard.v1.PlayerStatsResponse
+	5, // 8: leaderboard.v1.LeaderboardService.GetMyRank:output_type -> leaderboard.v1.MyRankResponse
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_leaderboard_v1_leaderboard_proto_init() }
+func file_leaderboard_v1_leaderboard_proto_init() {
+	if File_leaderboard_v1_leaderboard_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_leaderboard_v1_leaderboard_proto_rawDesc), len(file_leaderboard_v1_leaderboard_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_leaderboard_v1_leaderboard_proto_goTypes,
+		DependencyIndexes: file_leaderboard_v1_leaderboard_proto_depIdxs,
+		MessageInfos:      file_leaderboard_v1_leaderboard_proto_msgTypes,
+	}.Build()
+	File_leaderboard_v1_leaderboard_proto = out.File
+	file_leaderboard_v1_leaderboard_proto_goTypes = nil
+	file_leaderboard_v1_leaderboard_proto_depIdxs = nil
+}
@@ -21,6 +21,64 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type LobbyEventType int32
+
+const (
+	LobbyEventType_LOBBY_EVENT_TYPE_UNSPECIFIED   LobbyEventType = 0
+	LobbyEventType_LOBBY_EVENT_TYPE_CREATED       LobbyEventType = 1
+	LobbyEventType_LOBBY_EVENT_TYPE_DELETED       LobbyEventType = 2
+	LobbyEventType_LOBBY_EVENT_TYPE_PLAYER_COUNT  LobbyEventType = 3
+	LobbyEventType_LOBBY_EVENT_TYPE_MATCH_STARTED LobbyEventType = 4
+	LobbyEventType_LOBBY_EVENT_TYPE_MATCH_ENDED   LobbyEventType = 5
+)
+
+// Enum value maps for LobbyEventType.
+var (
+	LobbyEventType_name = map[int32]string{
+		0: "LOBBY_EVENT_TYPE_UNSPECIFIED",
+		1: "LOBBY_EVENT_TYPE_CREATED",
+		2: "LOBBY_EVENT_TYPE_DELETED",
+		3: "LOBBY_EVENT_TYPE_PLAYER_COUNT",
+		4: "LOBBY_EVENT_TYPE_MATCH_STARTED",
+		5: "LOBBY_EVENT_TYPE_MATCH_ENDED",
+	}
+	LobbyEventType_value = map[string]int32{
+		"LOBBY_EVENT_TYPE_UNSPECIFIED":   0,
+		"LOBBY_EVENT_TYPE_CREATED":       1,
+		"LOBBY_EVENT_TYPE_DELETED":       2,
+		"LOBBY_EVENT_TYPE_PLAYER_COUNT":  3,
+		"LOBBY_EVENT_TYPE_MATCH_STARTED": 4,
+		"LOBBY_EVENT_TYPE_MATCH_ENDED":   5,
+	}
+)
+
+func (x LobbyEventType) Enum() *LobbyEventType {
+	p := new(LobbyEventType)
+	*p = x
+	return p
+}
+
+func (x LobbyEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LobbyEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_lobby_v1_lobby_proto_enumTypes[0].Descriptor()
+}
+
+func (LobbyEventType) Type() protoreflect.EnumType {
+	return &file_lobby_v1_lobby_proto_enumTypes[0]
+}
+
+func (x LobbyEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LobbyEventType.Descriptor instead.
+func (LobbyEventType) EnumDescriptor() ([]byte, []int) {
+	return file_lobby_v1_lobby_proto_rawDescGZIP(), []int{0}
+}
+
 type ListLobbiesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -102,8 +160,14 @@ func (x *ListLobbiesResponse) GetLobbies() []*Lobby {
 }
 
 type AddLobbiesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	LobbyName     string                 `protobuf:"bytes,1,opt,name=lobby_name,json=lobbyName,proto3" json:"lobby_name,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	LobbyName string                 `protobuf:"bytes,1,opt,name=lobby_name,json=lobbyName,proto3" json:"lobby_name,omitempty"`
+	// classic, race, battle; empty means classic
+	GameMode string `protobuf:"bytes,2,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	// name of a map from ListMaps; empty means the default map
+	MapName string `protobuf:"bytes,3,opt,name=map_name,json=mapName,proto3" json:"map_name,omitempty"`
+	// seed of the generated map; 0 lets the server pick one
+	MapSeed       int64 `protobuf:"varint,4,opt,name=map_seed,json=mapSeed,proto3" json:"map_seed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -145,6 +209,27 @@ func (x *AddLobbiesRequest) GetLobbyName() string {
 	return ""
 }
 
+func (x *AddLobbiesRequest) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *AddLobbiesRequest) GetMapName() string {
+	if x != nil {
+		return x.MapName
+	}
+	return ""
+}
+
+func (x *AddLobbiesRequest) GetMapSeed() int64 {
+	if x != nil {
+		return x.MapSeed
+	}
+	return 0
+}
+
 type AddLobbiesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	LobbyId       uint64                 `protobuf:"varint,1,opt,name=lobby_id,json=lobbyId,proto3" json:"lobby_id,omitempty"`
@@ -269,21 +354,196 @@ func (*DelLobbiesResponse) Descriptor() ([]byte, []int) {
 	return file_lobby_v1_lobby_proto_rawDescGZIP(), []int{5}
 }
 
-type Lobby struct {
+type ListMapsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMapsRequest) Reset() {
+	*x = ListMapsRequest{}
+	mi := &file_lobby_v1_lobby_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMapsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMapsRequest) ProtoMessage() {}
+
+func (x *ListMapsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lobby_v1_lobby_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMapsRequest.ProtoReflect.Descriptor instead.
+func (*ListMapsRequest) Descriptor() ([]byte, []int) {
+	return file_lobby_v1_lobby_proto_rawDescGZIP(), []int{6}
+}
+
+type ListMapsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the first map is the default
+	MapNames      []string `protobuf:"bytes,1,rep,name=map_names,json=mapNames,proto3" json:"map_names,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMapsResponse) Reset() {
+	*x = ListMapsResponse{}
+	mi := &file_lobby_v1_lobby_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMapsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMapsResponse) ProtoMessage() {}
+
+func (x *ListMapsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lobby_v1_lobby_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMapsResponse.ProtoReflect.Descriptor instead.
+func (*ListMapsResponse) Descriptor() ([]byte, []int) {
+	return file_lobby_v1_lobby_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListMapsResponse) GetMapNames() []string {
+	if x != nil {
+		return x.MapNames
+	}
+	return nil
+}
+
+type WatchLobbiesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ID            uint64                 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	LobbyName     string                 `protobuf:"bytes,2,opt,name=lobby_name,json=lobbyName,proto3" json:"lobby_name,omitempty"`
-	OwnerName     string                 `protobuf:"bytes,4,opt,name=ownerName,proto3" json:"ownerName,omitempty"`
-	OwnerId       uint64                 `protobuf:"varint,5,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	PlayerCount   uint64                 `protobuf:"varint,6,opt,name=playerCount,proto3" json:"playerCount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchLobbiesRequest) Reset() {
+	*x = WatchLobbiesRequest{}
+	mi := &file_lobby_v1_lobby_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchLobbiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchLobbiesRequest) ProtoMessage() {}
+
+func (x *WatchLobbiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lobby_v1_lobby_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchLobbiesRequest.ProtoReflect.Descriptor instead.
+func (*WatchLobbiesRequest) Descriptor() ([]byte, []int) {
+	return file_lobby_v1_lobby_proto_rawDescGZIP(), []int{8}
+}
+
+type LobbyEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  LobbyEventType         `protobuf:"varint,1,opt,name=type,proto3,enum=lobby.v1.LobbyEventType" json:"type,omitempty"`
+	// deleted events only carry the ID, player count events the ID and playerCount
+	Lobby         *Lobby `protobuf:"bytes,2,opt,name=lobby,proto3" json:"lobby,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LobbyEvent) Reset() {
+	*x = LobbyEvent{}
+	mi := &file_lobby_v1_lobby_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LobbyEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LobbyEvent) ProtoMessage() {}
+
+func (x *LobbyEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_lobby_v1_lobby_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LobbyEvent.ProtoReflect.Descriptor instead.
+func (*LobbyEvent) Descriptor() ([]byte, []int) {
+	return file_lobby_v1_lobby_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LobbyEvent) GetType() LobbyEventType {
+	if x != nil {
+		return x.Type
+	}
+	return LobbyEventType_LOBBY_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *LobbyEvent) GetLobby() *Lobby {
+	if x != nil {
+		return x.Lobby
+	}
+	return nil
+}
+
+type Lobby struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ID           uint64                 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	LobbyName    string                 `protobuf:"bytes,2,opt,name=lobby_name,json=lobbyName,proto3" json:"lobby_name,omitempty"`
+	OwnerName    string                 `protobuf:"bytes,4,opt,name=ownerName,proto3" json:"ownerName,omitempty"`
+	OwnerId      uint64                 `protobuf:"varint,5,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+	CreatedAt    string                 `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	PlayerCount  uint64                 `protobuf:"varint,6,opt,name=playerCount,proto3" json:"playerCount,omitempty"`
+	GameMode     string                 `protobuf:"bytes,7,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	MatchStarted bool                   `protobuf:"varint,8,opt,name=match_started,json=matchStarted,proto3" json:"match_started,omitempty"`
+	MapName      string                 `protobuf:"bytes,9,opt,name=map_name,json=mapName,proto3" json:"map_name,omitempty"`
+	// replays the same layout when the map is generated
+	MapSeed       int64 `protobuf:"varint,10,opt,name=map_seed,json=mapSeed,proto3" json:"map_seed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Lobby) Reset() {
 	*x = Lobby{}
-	mi := &file_lobby_v1_lobby_proto_msgTypes[6]
+	mi := &file_lobby_v1_lobby_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -295,7 +555,7 @@ func (x *Lobby) String() string {
 func (*Lobby) ProtoMessage() {}
 
 func (x *Lobby) ProtoReflect() protoreflect.Message {
-	mi := &file_lobby_v1_lobby_proto_msgTypes[6]
+	mi := &file_lobby_v1_lobby_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -308,7 +568,7 @@ func (x *Lobby) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Lobby.ProtoReflect.Descriptor instead.
 func (*Lobby) Descriptor() ([]byte, []int) {
-	return file_lobby_v1_lobby_proto_rawDescGZIP(), []int{6}
+	return file_lobby_v1_lobby_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Lobby) GetID() uint64 {
@@ -353,6 +613,34 @@ func (x *Lobby) GetPlayerCount() uint64 {
 	return 0
 }
 
+func (x *Lobby) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *Lobby) GetMatchStarted() bool {
+	if x != nil {
+		return x.MatchStarted
+	}
+	return false
+}
+
+func (x *Lobby) GetMapName() string {
+	if x != nil {
+		return x.MapName
+	}
+	return ""
+}
+
+func (x *Lobby) GetMapSeed() int64 {
+	if x != nil {
+		return x.MapSeed
+	}
+	return 0
+}
+
 var File_lobby_v1_lobby_proto protoreflect.FileDescriptor
 
 const file_lobby_v1_lobby_proto_rawDesc = "" +
@@ -360,15 +648,26 @@ const file_lobby_v1_lobby_proto_rawDesc = "" +
 	"\x14lobby/v1/lobby.proto\x12\blobby.v1\"\x14\n" +
 	"\x12ListLobbiesRequest\"@\n" +
 	"\x13ListLobbiesResponse\x12)\n" +
-	"\alobbies\x18\x01 \x03(\v2\x0f.lobby.v1.LobbyR\alobbies\"2\n" +
+	"\alobbies\x18\x01 \x03(\v2\x0f.lobby.v1.LobbyR\alobbies\"\x85\x01\n" +
 	"\x11AddLobbiesRequest\x12\x1d\n" +
 	"\n" +
-	"lobby_name\x18\x01 \x01(\tR\tlobbyName\"/\n" +
+	"lobby_name\x18\x01 \x01(\tR\tlobbyName\x12\x1b\n" +
+	"\tgame_mode\x18\x02 \x01(\tR\bgameMode\x12\x19\n" +
+	"\bmap_name\x18\x03 \x01(\tR\amapName\x12\x19\n" +
+	"\bmap_seed\x18\x04 \x01(\x03R\amapSeed\"/\n" +
 	"\x12AddLobbiesResponse\x12\x19\n" +
 	"\blobby_id\x18\x01 \x01(\x04R\alobbyId\":\n" +
 	"\x11DelLobbiesRequest\x12%\n" +
 	"\x05lobby\x18\x01 \x01(\v2\x0f.lobby.v1.LobbyR\x05lobby\"\x14\n" +
-	"\x12DelLobbiesResponse\"\xaf\x01\n" +
+	"\x12DelLobbiesResponse\"\x11\n" +
+	"\x0fListMapsRequest\"/\n" +
+	"\x10ListMapsResponse\x12\x1b\n" +
+	"\tmap_names\x18\x01 \x03(\tR\bmapNames\"\x15\n" +
+	"\x13WatchLobbiesRequest\"a\n" +
+	"\n" +
+	"LobbyEvent\x12,\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x18.lobby.v1.LobbyEventTypeR\x04type\x12%\n" +
+	"\x05lobby\x18\x02 \x01(\v2\x0f.lobby.v1.LobbyR\x05lobby\"\xa7\x02\n" +
 	"\x05Lobby\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\x04R\x02ID\x12\x1d\n" +
 	"\n" +
@@ -377,11 +676,25 @@ const file_lobby_v1_lobby_proto_rawDesc = "" +
 	"\aownerId\x18\x05 \x01(\x04R\aownerId\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\x03 \x01(\tR\tcreatedAt\x12 \n" +
-	"\vplayerCount\x18\x06 \x01(\x04R\vplayerCount2\xf1\x01\n" +
+	"\vplayerCount\x18\x06 \x01(\x04R\vplayerCount\x12\x1b\n" +
+	"\tgame_mode\x18\a \x01(\tR\bgameMode\x12#\n" +
+	"\rmatch_started\x18\b \x01(\bR\fmatchStarted\x12\x19\n" +
+	"\bmap_name\x18\t \x01(\tR\amapName\x12\x19\n" +
+	"\bmap_seed\x18\n" +
+	" \x01(\x03R\amapSeed*\xd7\x01\n" +
+	"\x0eLobbyEventType\x12 \n" +
+	"\x1cLOBBY_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18LOBBY_EVENT_TYPE_CREATED\x10\x01\x12\x1c\n" +
+	"\x18LOBBY_EVENT_TYPE_DELETED\x10\x02\x12!\n" +
+	"\x1dLOBBY_EVENT_TYPE_PLAYER_COUNT\x10\x03\x12\"\n" +
+	"\x1eLOBBY_EVENT_TYPE_MATCH_STARTED\x10\x04\x12 \n" +
+	"\x1cLOBBY_EVENT_TYPE_MATCH_ENDED\x10\x052\xff\x02\n" +
 	"\fLobbyService\x12L\n" +
 	"\vListLobbies\x12\x1c.lobby.v1.ListLobbiesRequest\x1a\x1d.lobby.v1.ListLobbiesResponse\"\x00\x12G\n" +
+	"\fWatchLobbies\x12\x1d.lobby.v1.WatchLobbiesRequest\x1a\x14.lobby.v1.LobbyEvent\"\x000\x01\x12G\n" +
 	"\bAddLobby\x12\x1b.lobby.v1.AddLobbiesRequest\x1a\x1c.lobby.v1.AddLobbiesResponse\"\x00\x12J\n" +
-	"\vDeleteLobby\x12\x1b.lobby.v1.DelLobbiesRequest\x1a\x1c.lobby.v1.DelLobbiesResponse\"\x00B\x8e\x01\n" +
+	"\vDeleteLobby\x12\x1b.lobby.v1.DelLobbiesRequest\x1a\x1c.lobby.v1.DelLobbiesResponse\"\x00\x12C\n" +
+	"\bListMaps\x12\x19.lobby.v1.ListMapsRequest\x1a\x1a.lobby.v1.ListMapsResponse\"\x00B\x8e\x01\n" +
 	"\fcom.lobby.v1B\n" +
 	"LobbyProtoP\x01Z1github.com/frank2889/mazechase/generated/lobby/v1\xa2\x02\x03LXX\xaa\x02\bLobby.V1\xca\x02\bLobby\\V1\xe2\x02\x14Lobby\\V1\\GPBMetadata\xea\x02\tLobby::V1b\x06proto3"
 
@@ -397,30 +710,42 @@ func file_lobby_v1_lobby_proto_rawDescGZIP() []byte {
 	return file_lobby_v1_lobby_proto_rawDescData
 }
 
-var file_lobby_v1_lobby_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_lobby_v1_lobby_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_lobby_v1_lobby_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_lobby_v1_lobby_proto_goTypes = []any{
-	(*ListLobbiesRequest)(nil),  // 0: lobby.v1.ListLobbiesRequest
-	(*ListLobbiesResponse)(nil), // 1: lobby.v1.ListLobbiesResponse
-	(*AddLobbiesRequest)(nil),   // 2: lobby.v1.AddLobbiesRequest
-	(*AddLobbiesResponse)(nil),  // 3: lobby.v1.AddLobbiesResponse
-	(*DelLobbiesRequest)(nil),   // 4: lobby.v1.DelLobbiesRequest
-	(*DelLobbiesResponse)(nil),  // 5: lobby.v1.DelLobbiesResponse
-	(*Lobby)(nil),               // 6: lobby.v1.Lobby
+	(LobbyEventType)(0),         // 0: lobby.v1.LobbyEventType
+	(*ListLobbiesRequest)(nil),  // 1: lobby.v1.ListLobbiesRequest
+	(*ListLobbiesResponse)(nil), // 2: lobby.v1.ListLobbiesResponse
+	(*AddLobbiesRequest)(nil),   // 3: lobby.v1.AddLobbiesRequest
+	(*AddLobbiesResponse)(nil),  // 4: lobby.v1.AddLobbiesResponse
+	(*DelLobbiesRequest)(nil),   // 5: lobby.v1.DelLobbiesRequest
+	(*DelLobbiesResponse)(nil),  // 6: lobby.v1.DelLobbiesResponse
+	(*ListMapsRequest)(nil),     // 7: lobby.v1.ListMapsRequest
+	(*ListMapsResponse)(nil),    // 8: lobby.v1.ListMapsResponse
+	(*WatchLobbiesRequest)(nil), // 9: lobby.v1.WatchLobbiesRequest
+	(*LobbyEvent)(nil),          // 10: lobby.v1.LobbyEvent
+	(*Lobby)(nil),               // 11: lobby.v1.Lobby
 }
 var file_lobby_v1_lobby_proto_depIdxs = []int32{
-	6, // 0: lobby.v1.ListLobbiesResponse.lobbies:type_name -> lobby.v1.Lobby
-	6, // 1: lobby.v1.DelLobbiesRequest.lobby:type_name -> lobby.v1.Lobby
-	0, // 2: lobby.v1.LobbyService.ListLobbies:input_type -> lobby.v1.ListLobbiesRequest
-	2, // 3: lobby.v1.LobbyService.AddLobby:input_type -> lobby.v1.AddLobbiesRequest
-	4, // 4: lobby.v1.LobbyService.DeleteLobby:input_type -> lobby.v1.DelLobbiesRequest
-	1, // 5: lobby.v1.LobbyService.ListLobbies:output_type -> lobby.v1.ListLobbiesResponse
-	3, // 6: lobby.v1.LobbyService.AddLobby:output_type -> lobby.v1.AddLobbiesResponse
-	5, // 7: lobby.v1.LobbyService.DeleteLobby:output_type -> lobby.v1.DelLobbiesResponse
-	5, // [5:8] is the sub-list for method output_type
-	2, // [2:5] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	11, // 0: lobby.v1.ListLobbiesResponse.lobbies:type_name -> lobby.v1.Lobby
+	11, // 1: lobby.v1.DelLobbiesRequest.lobby:type_name -> lobby.v1.Lobby
+	0,  // 2: lobby.v1.LobbyEvent.type:type_name -> lobby.v1.LobbyEventType
+	11, // 3: lobby.v1.LobbyEvent.lobby:type_name -> lobby.v1.Lobby
+	1,  // 4: lobby.v1.LobbyService.ListLobbies:input_type -> lobby.v1.ListLobbiesRequest
+	9,  // 5: lobby.v1.LobbyService.WatchLobbies:input_type -> lobby.v1.WatchLobbiesRequest
+	3,  // 6: lobby.v1.LobbyService.AddLobby:input_type -> lobby.v1.AddLobbiesRequest
+	5,  // 7: lobby.v1.LobbyService.DeleteLobby:input_type -> lobby.v1.DelLobbiesRequest
+	7,  // 8: lobby.v1.LobbyService.ListMaps:input_type -> lobby.v1.ListMapsRequest
+	2,  // 9: lobby.v1.LobbyService.ListLobbies:output_type -> lobby.v1.ListLobbiesResponse
+	10, // 10: lobby.v1.LobbyService.WatchLobbies:output_type -> lobby.v1.LobbyEvent
+	4,  // 11: lobby.v1.LobbyService.AddLobby:output_type -> lobby.v1.AddLobbiesResponse
+	6,  // 12: lobby.v1.LobbyService.DeleteLobby:output_type -> lobby.v1.DelLobbiesResponse
+	8,  // 13: lobby.v1.LobbyService.ListMaps:output_type -> lobby.v1.ListMapsResponse
+	9,  // [9:14] is the sub-list for method output_type
+	4,  // [4:9] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_lobby_v1_lobby_proto_init() }
@@ -433,13 +758,14 @@ func file_lobby_v1_lobby_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_lobby_v1_lobby_proto_rawDesc), len(file_lobby_v1_lobby_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      1,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_lobby_v1_lobby_proto_goTypes,
 		DependencyIndexes: file_lobby_v1_lobby_proto_depIdxs,
+		EnumInfos:         file_lobby_v1_lobby_proto_enumTypes,
 		MessageInfos:      file_lobby_v1_lobby_proto_msgTypes,
 	}.Build()
 	File_lobby_v1_lobby_proto = out.File
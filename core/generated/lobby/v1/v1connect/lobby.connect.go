@@ -36,19 +36,27 @@ const (
 	// LobbyServiceListLobbiesProcedure is the fully-qualified name of the LobbyService's ListLobbies
 	// RPC.
 	LobbyServiceListLobbiesProcedure = "/lobby.v1.LobbyService/ListLobbies"
+	// LobbyServiceWatchLobbiesProcedure is the fully-qualified name of the LobbyService's WatchLobbies
+	// RPC.
+	LobbyServiceWatchLobbiesProcedure = "/lobby.v1.LobbyService/WatchLobbies"
 	// LobbyServiceAddLobbyProcedure is the fully-qualified name of the LobbyService's AddLobby RPC.
 	LobbyServiceAddLobbyProcedure = "/lobby.v1.LobbyService/AddLobby"
 	// LobbyServiceDeleteLobbyProcedure is the fully-qualified name of the LobbyService's DeleteLobby
 	// RPC.
 	LobbyServiceDeleteLobbyProcedure = "/lobby.v1.LobbyService/DeleteLobby"
+	// LobbyServiceListMapsProcedure is the fully-qualified name of the LobbyService's ListMaps RPC.
+	LobbyServiceListMapsProcedure = "/lobby.v1.LobbyService/ListMaps"
 )
 
 // LobbyServiceClient is a client for the lobby.v1.LobbyService service.
 type LobbyServiceClient interface {
-	// todo figure out lobby streaming
 	ListLobbies(context.Context, *connect.Request[v1.ListLobbiesRequest]) (*connect.Response[v1.ListLobbiesResponse], error)
+	// WatchLobbies first sends every existing lobby as a created event, then
+	// pushes lobby changes as they happen
+	WatchLobbies(context.Context, *connect.Request[v1.WatchLobbiesRequest]) (*connect.ServerStreamForClient[v1.LobbyEvent], error)
 	AddLobby(context.Context, *connect.Request[v1.AddLobbiesRequest]) (*connect.Response[v1.AddLobbiesResponse], error)
 	DeleteLobby(context.Context, *connect.Request[v1.DelLobbiesRequest]) (*connect.Response[v1.DelLobbiesResponse], error)
+	ListMaps(context.Context, *connect.Request[v1.ListMapsRequest]) (*connect.Response[v1.ListMapsResponse], error)
 }
 
 // NewLobbyServiceClient constructs a client for the lobby.v1.LobbyService service. By default, it
@@ -68,6 +76,12 @@ func NewLobbyServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(lobbyServiceMethods.ByName("ListLobbies")),
 			connect.WithClientOptions(opts...),
 		),
+		watchLobbies: connect.NewClient[v1.WatchLobbiesRequest, v1.LobbyEvent](
+			httpClient,
+			baseURL+LobbyServiceWatchLobbiesProcedure,
+			connect.WithSchema(lobbyServiceMethods.ByName("WatchLobbies")),
+			connect.WithClientOptions(opts...),
+		),
 		addLobby: connect.NewClient[v1.AddLobbiesRequest, v1.AddLobbiesResponse](
 			httpClient,
 			baseURL+LobbyServiceAddLobbyProcedure,
@@ -80,14 +94,22 @@ func NewLobbyServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(lobbyServiceMethods.ByName("DeleteLobby")),
 			connect.WithClientOptions(opts...),
 		),
+		listMaps: connect.NewClient[v1.ListMapsRequest, v1.ListMapsResponse](
+			httpClient,
+			baseURL+LobbyServiceListMapsProcedure,
+			connect.WithSchema(lobbyServiceMethods.ByName("ListMaps")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // lobbyServiceClient implements LobbyServiceClient.
 type lobbyServiceClient struct {
-	listLobbies *connect.Client[v1.ListLobbiesRequest, v1.ListLobbiesResponse]
-	addLobby    *connect.Client[v1.AddLobbiesRequest, v1.AddLobbiesResponse]
-	deleteLobby *connect.Client[v1.DelLobbiesRequest, v1.DelLobbiesResponse]
+	listLobbies  *connect.Client[v1.ListLobbiesRequest, v1.ListLobbiesResponse]
+	watchLobbies *connect.Client[v1.WatchLobbiesRequest, v1.LobbyEvent]
+	addLobby     *connect.Client[v1.AddLobbiesRequest, v1.AddLobbiesResponse]
+	deleteLobby  *connect.Client[v1.DelLobbiesRequest, v1.DelLobbiesResponse]
+	listMaps     *connect.Client[v1.ListMapsRequest, v1.ListMapsResponse]
 }
 
 // ListLobbies calls lobby.v1.LobbyService.ListLobbies.
@@ -95,6 +117,11 @@ func (c *lobbyServiceClient) ListLobbies(ctx context.Context, req *connect.Reque
 	return c.listLobbies.CallUnary(ctx, req)
 }
 
+// WatchLobbies calls lobby.v1.LobbyService.WatchLobbies.
+func (c *lobbyServiceClient) WatchLobbies(ctx context.Context, req *connect.Request[v1.WatchLobbiesRequest]) (*connect.ServerStreamForClient[v1.LobbyEvent], error) {
+	return c.watchLobbies.CallServerStream(ctx, req)
+}
+
 // AddLobby calls lobby.v1.LobbyService.AddLobby.
 func (c *lobbyServiceClient) AddLobby(ctx context.Context, req *connect.Request[v1.AddLobbiesRequest]) (*connect.Response[v1.AddLobbiesResponse], error) {
 	return c.addLobby.CallUnary(ctx, req)
@@ -105,12 +132,20 @@ func (c *lobbyServiceClient) DeleteLobby(ctx context.Context, req *connect.Reque
 	return c.deleteLobby.CallUnary(ctx, req)
 }
 
+// ListMaps calls lobby.v1.LobbyService.ListMaps.
+func (c *lobbyServiceClient) ListMaps(ctx context.Context, req *connect.Request[v1.ListMapsRequest]) (*connect.Response[v1.ListMapsResponse], error) {
+	return c.listMaps.CallUnary(ctx, req)
+}
+
 // LobbyServiceHandler is an implementation of the lobby.v1.LobbyService service.
 type LobbyServiceHandler interface {
-	// todo figure out lobby streaming
 	ListLobbies(context.Context, *connect.Request[v1.ListLobbiesRequest]) (*connect.Response[v1.ListLobbiesResponse], error)
+	// WatchLobbies first sends every existing lobby as a created event, then
+	// pushes lobby changes as they happen
+	WatchLobbies(context.Context, *connect.Request[v1.WatchLobbiesRequest], *connect.ServerStream[v1.LobbyEvent]) error
 	AddLobby(context.Context, *connect.Request[v1.AddLobbiesRequest]) (*connect.Response[v1.AddLobbiesResponse], error)
 	DeleteLobby(context.Context, *connect.Request[v1.DelLobbiesRequest]) (*connect.Response[v1.DelLobbiesResponse], error)
+	ListMaps(context.Context, *connect.Request[v1.ListMapsRequest]) (*connect.Response[v1.ListMapsResponse], error)
 }
 
 // NewLobbyServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -126,6 +161,12 @@ func NewLobbyServiceHandler(svc LobbyServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(lobbyServiceMethods.ByName("ListLobbies")),
 		connect.WithHandlerOptions(opts...),
 	)
+	lobbyServiceWatchLobbiesHandler := connect.NewServerStreamHandler(
+		LobbyServiceWatchLobbiesProcedure,
+		svc.WatchLobbies,
+		connect.WithSchema(lobbyServiceMethods.ByName("WatchLobbies")),
+		connect.WithHandlerOptions(opts...),
+	)
 	lobbyServiceAddLobbyHandler := connect.NewUnaryHandler(
 		LobbyServiceAddLobbyProcedure,
 		svc.AddLobby,
@@ -138,14 +179,24 @@ func NewLobbyServiceHandler(svc LobbyServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(lobbyServiceMethods.ByName("DeleteLobby")),
 		connect.WithHandlerOptions(opts...),
 	)
+	lobbyServiceListMapsHandler := connect.NewUnaryHandler(
+		LobbyServiceListMapsProcedure,
+		svc.ListMaps,
+		connect.WithSchema(lobbyServiceMethods.ByName("ListMaps")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/lobby.v1.LobbyService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case LobbyServiceListLobbiesProcedure:
 			lobbyServiceListLobbiesHandler.ServeHTTP(w, r)
+		case LobbyServiceWatchLobbiesProcedure:
+			lobbyServiceWatchLobbiesHandler.ServeHTTP(w, r)
 		case LobbyServiceAddLobbyProcedure:
 			lobbyServiceAddLobbyHandler.ServeHTTP(w, r)
 		case LobbyServiceDeleteLobbyProcedure:
 			lobbyServiceDeleteLobbyHandler.ServeHTTP(w, r)
+		case LobbyServiceListMapsProcedure:
+			lobbyServiceListMapsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -159,6 +210,10 @@ func (UnimplementedLobbyServiceHandler) ListLobbies(context.Context, *connect.Re
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("lobby.v1.LobbyService.ListLobbies is not implemented"))
 }
 
+func (UnimplementedLobbyServiceHandler) WatchLobbies(context.Context, *connect.Request[v1.WatchLobbiesRequest], *connect.ServerStream[v1.LobbyEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("lobby.v1.LobbyService.WatchLobbies is not implemented"))
+}
+
 func (UnimplementedLobbyServiceHandler) AddLobby(context.Context, *connect.Request[v1.AddLobbiesRequest]) (*connect.Response[v1.AddLobbiesResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("lobby.v1.LobbyService.AddLobby is not implemented"))
 }
@@ -166,3 +221,7 @@ func (UnimplementedLobbyServiceHandler) AddLobby(context.Context, *connect.Reque
 func (UnimplementedLobbyServiceHandler) DeleteLobby(context.Context, *connect.Request[v1.DelLobbiesRequest]) (*connect.Response[v1.DelLobbiesResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("lobby.v1.LobbyService.DeleteLobby is not implemented"))
 }
+
+func (UnimplementedLobbyServiceHandler) ListMaps(context.Context, *connect.Request[v1.ListMapsRequest]) (*connect.Response[v1.ListMapsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("lobby.v1.LobbyService.ListMaps is not implemented"))
+}
@@ -14,6 +14,7 @@ type Options struct {
 	LobbyLimit  int
 	DbPath      string
 	LogFilePath string
+	ReplayDir   string
 }
 
 var (
@@ -33,6 +34,7 @@ func Load() {
 
 	opts.DbPath = fmt.Sprintf("%s/multipacman.db", configDir)
 	opts.LogFilePath = fmt.Sprintf("%s/multipacman.log", configDir)
+	opts.ReplayDir = getReplayDir(configDir)
 
 	Opts = opts
 
@@ -48,6 +50,15 @@ func getConfigDir(baseDir string) string {
 	return configDir
 }
 
+func getReplayDir(configDir string) string {
+	replayDir := fmt.Sprintf("%s/%s", configDir, "replays")
+	err := os.MkdirAll(replayDir, DefaultFilePerm)
+	if err != nil {
+		log.Fatal().Err(err).Str("Replay dir", replayDir).Msgf("could not create replay directory")
+	}
+	return replayDir
+}
+
 func loadLobbyLimit() int {
 	const defaultLobbyLimit = 100 // Increased for more lobbies
 
@@ -3,6 +3,7 @@ package database
 import (
 	"github.com/frank2889/mazechase/internal/config"
 	"github.com/frank2889/mazechase/internal/lobby"
+	"github.com/frank2889/mazechase/internal/match"
 	"github.com/frank2889/mazechase/internal/user"
 	"github.com/rs/zerolog/log"
 	"gorm.io/driver/sqlite"
@@ -23,7 +24,7 @@ func InitDB() *gorm.DB {
 	}
 
 	// Migrate the schema
-	err = db.AutoMigrate(user.User{}, user.Score{}, lobby.Lobby{})
+	err = db.AutoMigrate(user.User{}, user.Score{}, lobby.Lobby{}, match.Match{}, match.Replay{})
 	if err != nil {
 		log.Fatal().Err(err).Msgf("failed to migrate database")
 	}
@@ -0,0 +1,208 @@
+package game
+
+import (
+	"expvar"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
+	"github.com/rs/zerolog/log"
+)
+
+// Kinds of anti-cheat violations
+const (
+	ViolationRateLimit    = "rate_limit"
+	ViolationInvalidInput = "invalid_input"
+	ViolationTeleport     = "teleport"
+	ViolationPelletClaim  = "pellet_claim"
+	ViolationPowerUpClaim = "powerup_claim"
+	ViolationKillClaim    = "kill_claim"
+)
+
+// Metrics of the anti-cheat, served on /api/metrics
+var (
+	cheatViolations = expvar.NewMap("game_cheat_violations")
+	flaggedPlayers  = expvar.NewInt("game_flagged_players")
+)
+
+// antiCheatMetrics writes the anti-cheat counters as JSON, without the
+// command line and memory stats the default expvar handler publishes
+func antiCheatMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	fmt.Fprintf(w, "{\"game_cheat_violations\": %s, \"game_flagged_players\": %s}\n", cheatViolations, flaggedPlayers)
+}
+
+// AntiCheat counts the violations of the players in a match and flags the
+// ones that keep breaking the rules
+type AntiCheat struct {
+	mu         sync.Mutex
+	violations map[string]int
+	flagged    map[string]bool
+	lastMove   map[string]time.Time
+}
+
+func NewAntiCheat() *AntiCheat {
+	return &AntiCheat{
+		violations: make(map[string]int),
+		flagged:    make(map[string]bool),
+		lastMove:   make(map[string]time.Time),
+	}
+}
+
+// Report records a violation of a player, it returns true once the player is
+// flagged as suspicious
+func (ac *AntiCheat) Report(playerId, violation string, err error) bool {
+	cheatViolations.Add(violation, 1)
+
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	ac.violations[playerId]++
+	count := ac.violations[playerId]
+	log.Warn().Err(err).Str("player", playerId).Str("violation", violation).Int("count", count).Msg("Rejected player input")
+
+	if count >= SuspicionThreshold && !ac.flagged[playerId] {
+		ac.flagged[playerId] = true
+		flaggedPlayers.Add(1)
+		log.Error().Str("player", playerId).Int("violations", count).Msg("Player flagged as suspicious")
+	}
+	return ac.flagged[playerId]
+}
+
+// Flagged reports whether a player is flagged as suspicious
+func (ac *AntiCheat) Flagged(playerId string) bool {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return ac.flagged[playerId]
+}
+
+// Violations returns the number of violations of a player
+func (ac *AntiCheat) Violations(playerId string) int {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return ac.violations[playerId]
+}
+
+// moved stores the time of a client-reported move and returns how much
+// movement the player may catch up on, at least a tick and at most MoveMaxGapMs
+func (ac *AntiCheat) moved(playerId string, now time.Time) time.Duration {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	gap := time.Duration(MoveMaxGapMs) * time.Millisecond
+	if last, ok := ac.lastMove[playerId]; ok {
+		gap = min(gap, max(now.Sub(last), TickRateMs*time.Millisecond))
+	}
+	ac.lastMove[playerId] = now
+	return gap
+}
+
+// checkMoveLocked tells whether a client-reported position is on the map and
+// reachable from where the player stands, it returns the violation otherwise
+// (caller must hold worldLock)
+func (w *World) checkMoveLocked(player *PlayerEntity, x, y float64, now time.Time) (string, error) {
+	if err := w.Validator.ValidatePosition(x, y); err != nil {
+		return ViolationInvalidInput, err
+	}
+	if w.MazeData != nil && !w.MazeData.IsWalkable(x, y) {
+		return ViolationTeleport, fmt.Errorf("position %.0f,%.0f is inside a wall", x, y)
+	}
+
+	maxStep := PlayerSpeed * w.playerSpeedFactorLocked(player) * w.AntiCheat.moved(player.PlayerId, now).Seconds() * MoveSpeedSlack
+	if Distance(player.X, player.Y, x, y) > maxStep*maxStep {
+		return ViolationTeleport, fmt.Errorf("moved from %.0f,%.0f to %.0f,%.0f, at most %.0fpx allowed", player.X, player.Y, x, y, maxStep)
+	}
+	return "", nil
+}
+
+// nearTileLocked reports whether a player stands within ClaimRadiusTiles of a
+// tile (caller must hold worldLock)
+func (w *World) nearTileLocked(player *PlayerEntity, tileX, tileY int) bool {
+	playerX, playerY := PixelToTile(player.X, player.Y)
+	return abs(playerX-tileX) <= ClaimRadiusTiles && abs(playerY-tileY) <= ClaimRadiusTiles
+}
+
+// ClaimPellet checks a client claim that the player ate a pellet. A pellet
+// next to the player is eaten by the server, one the server already gave away
+// is ignored, anything else is a violation.
+func (w *World) ClaimPellet(player *PlayerEntity, x, y float64) error {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+
+	tileX, tileY := int(x), int(y)
+	if !w.nearTileLocked(player, tileX, tileY) {
+		return fmt.Errorf("pellet %d,%d is out of reach", tileX, tileY)
+	}
+	if w.PelletsCoordEaten.Contains(float64(tileX), float64(tileY)) {
+		return nil
+	}
+	if !w.eatPelletLocked(player, tileX, tileY) {
+		return fmt.Errorf("no pellet at %d,%d", tileX, tileY)
+	}
+
+	w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Pel{Pel: &gamev1.Pellet{
+		X:        int32(tileX),
+		Y:        int32(tileY),
+		PlayerId: player.PlayerId,
+		Score:    int32(w.Scores[player.PlayerId]),
+	}}})
+	return nil
+}
+
+// ClaimPowerUp checks a client claim that the player ate a power-up, like
+// ClaimPellet
+func (w *World) ClaimPowerUp(player *PlayerEntity, x, y float64) error {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+
+	tileX, tileY := int(x), int(y)
+	if !w.nearTileLocked(player, tileX, tileY) {
+		return fmt.Errorf("power-up %d,%d is out of reach", tileX, tileY)
+	}
+	if w.PowerUpsCoordsEaten.Contains(float64(tileX), float64(tileY)) {
+		return nil
+	}
+	if !w.eatPowerUpTileLocked(player, tileX, tileY, w.clock.Now()) {
+		return fmt.Errorf("no power-up at %d,%d", tileX, tileY)
+	}
+	return nil
+}
+
+// ClaimKill checks a client claim that the runner and a chaser collided, only
+// one of the two may claim it and CollisionCheck must see them touch. The world
+// resolves the collision and broadcasts the kill in the next snapshot. Claims
+// during a respawn freeze or after the game over are refused.
+func (w *World) ClaimKill(player *PlayerEntity, chaser SpriteType) error {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+
+	now := w.clock.Now()
+	if w.gameEnded {
+		return fmt.Errorf("the game is over")
+	}
+	if w.isFrozenLocked(now) {
+		return fmt.Errorf("players are frozen for a respawn")
+	}
+	if player.SpriteType != Runner && player.SpriteType != chaser {
+		return fmt.Errorf("%s cannot claim a kill between runner and %s", player.SpriteType, chaser)
+	}
+	if w.isChaserEatenLocked(chaser) {
+		return fmt.Errorf("chaser %s is already eaten", chaser)
+	}
+
+	runnerId := w.playerIdBySpriteLocked(Runner)
+	runnerPos := w.PlayerPositions[runnerId]
+	chaserPos := w.PlayerPositions[w.playerIdBySpriteLocked(chaser)]
+	if runnerPos == nil || chaserPos == nil {
+		return fmt.Errorf("runner or %s is not in the match", chaser)
+	}
+
+	if !CollisionCheck(runnerPos.X, runnerPos.Y, chaserPos.X, chaserPos.Y) {
+		return fmt.Errorf("runner and %s are too far apart", chaser)
+	}
+
+	w.resolveCatchLocked(runnerId, chaser, now)
+	return nil
+}
@@ -1,13 +1,13 @@
 package game
 
 import (
-	"encoding/json"
 	"fmt"
 	"math"
-	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
 	"github.com/rs/zerolog/log"
 )
 
@@ -25,27 +25,75 @@ const (
 	StrategyPatrol
 )
 
-// Bot represents an AI-controlled player
+// strategyNames are the names the simulator uses for strategies
+var strategyNames = map[BotStrategy]string{
+	StrategyChaser:  "chaser",
+	StrategyAmbush:  "ambush",
+	StrategyBlocker: "blocker",
+	StrategyPatrol:  "patrol",
+}
+
+// ParseBotStrategy turns a strategy name into a strategy
+func ParseBotStrategy(name string) (BotStrategy, error) {
+	for strategy, strategyName := range strategyNames {
+		if strategyName == name {
+			return strategy, nil
+		}
+	}
+	return StrategyChaser, fmt.Errorf("onbekende strategie: %s", name)
+}
+
+func (s BotStrategy) String() string {
+	return strategyNames[s]
+}
+
+// BotProfile sets the strategy and aggression of a bot instead of the
+// default line-up
+type BotProfile struct {
+	Strategy   BotStrategy
+	Aggression float64
+}
+
+// defaultBotProfiles gives every bot a different strategy, Alpha is the
+// most aggressive
+var defaultBotProfiles = []BotProfile{
+	{Strategy: StrategyChaser, Aggression: 0.9},
+	{Strategy: StrategyAmbush, Aggression: 0.7},
+	{Strategy: StrategyBlocker, Aggression: 0.5},
+	{Strategy: StrategyPatrol, Aggression: 0.3},
+}
+
+// Bot represents an AI-controlled player, moved by the world loop
 type Bot struct {
 	PlayerEntity *PlayerEntity
 	World        *World
-	stopChan     chan struct{}
-	isRunning    bool
-	mutex        sync.Mutex
 	Strategy     BotStrategy  // AI behavior pattern
 	TargetX      float64      // Target position for patrol/ambush
 	TargetY      float64
 	LastRunnerX  float64      // Track runner movement for prediction
 	LastRunnerY  float64
 	AggressionLevel float64   // 0.0 to 1.0 - how aggressively to chase
+	currentDir             string
+	directionChangeCounter int
+	stuckCounter           int
+	// Chasers steer with the ChaserAI towards a target tile and runners with
+	// the RunnerAI around the chasers they saw, both refreshed once per
+	// reaction time of the difficulty
+	ai           *ChaserAI
+	runnerAI     *RunnerAI
+	target       TilePoint
+	threats      []TilePoint
+	nextReaction time.Time
 }
 
 // BotManager manages all bots in a world
 type BotManager struct {
 	bots      []*Bot
+	standIns  map[string]*Bot // drive dropped players until they resume
 	world     *World
 	mutex     sync.Mutex
-	broadcast func([]byte) error
+	broadcast func(*gamev1.Envelope) error
+	profiles  []BotProfile // bots take them in order of creation
 }
 
 // GetBots returns a copy of the bots slice (thread-safe)
@@ -57,21 +105,117 @@ func (bm *BotManager) GetBots() []*Bot {
 	return result
 }
 
+// addStandIn lets a bot move a dropped player's sprite, it does not count as
+// a bot and keeps the player's slot (caller must hold world lock)
+func (bm *BotManager) addStandIn(player *PlayerEntity) {
+	bm.mutex.Lock()
+	defer bm.mutex.Unlock()
+	bm.standIns[player.PlayerId] = &Bot{
+		PlayerEntity:    player,
+		World:           bm.world,
+		Strategy:        StrategyPatrol,
+		AggressionLevel: 0.5,
+		currentDir:      directions[bm.world.rng.Intn(len(directions))],
+		ai:              bm.newChaserAI(player.SpriteType),
+		runnerAI:        bm.newRunnerAI(),
+	}
+}
+
+// newChaserAI creates the steering AI of a bot at the world's bot difficulty,
+// each chaser scatters to its own corner
+func (bm *BotManager) newChaserAI(sprite SpriteType) *ChaserAI {
+	ai := NewChaserAI(bm.world.PathGrid, bm.world.BotDifficulty)
+	ai.SetRand(bm.world.rng)
+
+	width, height := bm.world.MazeData.Width, bm.world.MazeData.Height
+	switch sprite {
+	case Chaser1:
+		ai.SetScatterTarget(width-2, 1)
+	case Chaser2:
+		ai.SetScatterTarget(1, 1)
+	case Chaser3:
+		ai.SetScatterTarget(width-2, height-2)
+	default:
+		ai.SetScatterTarget(1, height-2)
+	}
+	return ai
+}
+
+// newRunnerAI creates the steering AI of a runner bot at the world's bot
+// difficulty
+func (bm *BotManager) newRunnerAI() *RunnerAI {
+	ai := NewRunnerAI(bm.world.PathGrid, bm.world.BotDifficulty)
+	ai.SetRand(bm.world.rng)
+	return ai
+}
+
+// setDifficulty changes the difficulty of every bot and stand-in (caller
+// must hold world lock)
+func (bm *BotManager) setDifficulty(difficulty DifficultyLevel) {
+	bm.mutex.Lock()
+	defer bm.mutex.Unlock()
+	for _, bot := range bm.getBotsAndStandInsUnlocked() {
+		bot.ai.SetDifficulty(difficulty)
+		bot.runnerAI.SetDifficulty(difficulty)
+	}
+}
+
+// removeStandIn hands a sprite back to its player (caller must hold world lock)
+func (bm *BotManager) removeStandIn(playerId string) {
+	bm.mutex.Lock()
+	defer bm.mutex.Unlock()
+	delete(bm.standIns, playerId)
+}
+
+// getBotsAndStandInsUnlocked returns every bot the manager moves (caller must
+// hold mutex)
+func (bm *BotManager) getBotsAndStandInsUnlocked() []*Bot {
+	result := append([]*Bot{}, bm.bots...)
+	for _, bot := range bm.standIns {
+		result = append(result, bot)
+	}
+	return result
+}
+
+// getStandIns returns the stand-in bots ordered by player id
+func (bm *BotManager) getStandIns() []*Bot {
+	bm.mutex.Lock()
+	defer bm.mutex.Unlock()
+	result := make([]*Bot, 0, len(bm.standIns))
+	for _, bot := range bm.standIns {
+		result = append(result, bot)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PlayerEntity.PlayerId < result[j].PlayerEntity.PlayerId
+	})
+	return result
+}
+
 var botNames = []string{"Bot Alpha", "Bot Beta", "Bot Gamma", "Bot Delta"}
 var directions = []string{"up", "down", "left", "right"}
 
 // NewBotManager creates a new bot manager for a world
-func NewBotManager(world *World, broadcastFunc func([]byte) error) *BotManager {
+func NewBotManager(world *World, broadcastFunc func(*gamev1.Envelope) error) *BotManager {
 	return &BotManager{
 		bots:      make([]*Bot, 0),
+		standIns:  make(map[string]*Bot),
 		world:     world,
 		broadcast: broadcastFunc,
 	}
 }
 
+// SetProfiles replaces the strategy and aggression of the bots created from
+// now on, bots past the end of the list cycle through it
+func (bm *BotManager) SetProfiles(profiles []BotProfile) {
+	bm.mutex.Lock()
+	defer bm.mutex.Unlock()
+	bm.profiles = profiles
+}
+
 // FillWithBots adds bots to fill remaining slots (up to 4 players total)
-// Note: This should be called while holding the world lock
 func (bm *BotManager) FillWithBots() {
+	bm.world.worldLock.Lock()
+	defer bm.world.worldLock.Unlock()
 	bm.mutex.Lock()
 	defer bm.mutex.Unlock()
 
@@ -87,12 +231,11 @@ func (bm *BotManager) FillWithBots() {
 		bot := bm.createBotUnlocked(i)
 		if bot != nil {
 			bm.bots = append(bm.bots, bot)
-			go bot.Start(bm.broadcast)
 		}
 	}
 }
 
-// createBotUnlocked creates a single bot (caller must hold mutex)
+// createBotUnlocked creates a single bot (caller must hold mutex and world lock)
 func (bm *BotManager) createBotUnlocked(index int) *Bot {
 	if len(bm.world.CharactersList) == 0 {
 		return nil
@@ -102,36 +245,42 @@ func (bm *BotManager) createBotUnlocked(index int) *Bot {
 	spriteId := bm.world.CharactersList[len(bm.world.CharactersList)-1]
 	bm.world.CharactersList = bm.world.CharactersList[:len(bm.world.CharactersList)-1]
 
-	// Create bot player entity
+	// Create bot player entity at the sprite's spawn
 	botName := botNames[index%len(botNames)]
+	spawn := bm.world.Map.Spawn(spriteId)
+	startX, startY := TileToPixel(spawn.X, spawn.Y)
 	player := &PlayerEntity{
 		PlayerId:    fmt.Sprintf("bot_%d", index),
 		Username:    botName,
 		SpriteType:  spriteId,
-		X:           getStartX(spriteId),
-		Y:           getStartY(spriteId),
+		X:           startX,
+		Y:           startY,
 		secretToken: fmt.Sprintf("bot_token_%d", index),
 		IsBot:       true,
 	}
 
 	// Store in connected players (nil session for bots)
+	bm.world.Players[player.PlayerId] = player
+	bm.world.Participants[player.PlayerId] = player
+	bm.world.PlayerPositions[player.PlayerId] = &PointF{X: player.X, Y: player.Y}
 	bm.world.ConnectedPlayers.Store(player.PlayerId, nil)
 
 	// Assign different strategies to different bots for variety
-	strategies := []BotStrategy{StrategyChaser, StrategyAmbush, StrategyBlocker, StrategyPatrol}
-	strategy := strategies[index%len(strategies)]
-	
-	// Vary aggression level based on bot type
-	aggressionLevels := []float64{0.9, 0.7, 0.5, 0.3} // Alpha is aggressive, others vary
-	aggression := aggressionLevels[index%len(aggressionLevels)]
+	profiles := bm.profiles
+	if len(profiles) == 0 {
+		profiles = defaultBotProfiles
+	}
+	strategy := profiles[index%len(profiles)].Strategy
+	aggression := profiles[index%len(profiles)].Aggression
 
 	bot := &Bot{
 		PlayerEntity:    player,
 		World:           bm.world,
-		stopChan:        make(chan struct{}),
-		isRunning:       false,
 		Strategy:        strategy,
 		AggressionLevel: aggression,
+		currentDir:      directions[bm.world.rng.Intn(len(directions))],
+		ai:              bm.newChaserAI(spriteId),
+		runnerAI:        bm.newRunnerAI(),
 	}
 
 	log.Info().
@@ -142,117 +291,158 @@ func (bm *BotManager) createBotUnlocked(index int) *Bot {
 		Msg("Bot created with strategy")
 
 	// Broadcast bot join to other players
-	joinMsg := map[string]interface{}{
-		"type":       "active",
-		"user":       botName,
-		"spriteType": string(spriteId),
-	}
-	if msgBytes, err := json.Marshal(joinMsg); err == nil {
-		bm.broadcast(msgBytes)
-	}
+	bm.broadcast(&gamev1.Envelope{Payload: &gamev1.Envelope_Active{Active: &gamev1.PlayerUpdate{
+		User:       botName,
+		SpriteType: string(spriteId),
+	}}})
 
 	return bot
 }
 
-// getStartX returns the starting X position for a sprite
-func getStartX(sprite SpriteType) float64 {
-	spawn, ok := SpawnPositions[sprite]
-	if !ok {
-		return 700
+// botSpeed is how far a bot moves per BotMoveIntervalMs
+const botSpeed = PlayerSpeed * 0.2 * 0.001 * 200
+
+// speed is how far the bot moves this step, faster in sudden death and for
+// chasers in later levels (called with the world lock held)
+func (b *Bot) speed() float64 {
+	return botSpeed * b.World.playerSpeedFactorLocked(b.PlayerEntity)
+}
+
+// Step advances the bot by one move and returns its pos event, or nil when it
+// is blocked. Called by the world loop every BotMoveIntervalMs with the world
+// lock held.
+func (b *Bot) Step(now time.Time) *gamev1.Envelope {
+	if b.ai == nil || b.runnerAI == nil {
+		return b.wanderStep(now)
 	}
-	x, _ := TileToPixel(spawn.X, spawn.Y)
-	return x
+	return b.steerStep(now)
 }
 
-// getStartY returns the starting Y position for a sprite
-func getStartY(sprite SpriteType) float64 {
-	spawn, ok := SpawnPositions[sprite]
-	if !ok {
-		return 575
+// steerStep moves the bot along the path of its AI, it picks a new tile
+// whenever it passes a tile center
+func (b *Bot) steerStep(now time.Time) *gamev1.Envelope {
+	player := b.PlayerEntity
+	tileX, tileY := PixelToTile(player.X, player.Y)
+	centerX, centerY := TileToPixel(tileX, tileY)
+	speed := b.speed()
+	atCenter := math.Abs(player.X-centerX) <= speed/2 && math.Abs(player.Y-centerY) <= speed/2
+
+	dir := b.currentDir
+	if atCenter || dir == "" {
+		nextX, nextY := b.nextTile(tileX, tileY, now)
+		if next := directionBetween(tileX, tileY, nextX, nextY); next != "" {
+			dir = next
+		}
+	}
+	if dir == "" {
+		return nil
+	}
+
+	// Turning snaps onto the tile center so the bot stays in its corridor
+	fromX, fromY := player.X, player.Y
+	if dir != b.currentDir {
+		player.X, player.Y = centerX, centerY
 	}
-	_, y := TileToPixel(spawn.X, spawn.Y)
-	return y
+	event, moved := b.World.stepPlayerLocked(player, dir, speed, now)
+	if !moved {
+		player.X, player.Y = fromX, fromY
+		b.currentDir = ""
+		return nil
+	}
+
+	b.currentDir = dir
+	return event
 }
 
-// Start begins the bot's movement loop
-func (b *Bot) Start(broadcast func([]byte) error) {
-	b.mutex.Lock()
-	if b.isRunning {
-		b.mutex.Unlock()
-		return
+// nextTile asks the AI of the bot's role where to go from a tile
+func (b *Bot) nextTile(tileX, tileY int, now time.Time) (int, int) {
+	react := !now.Before(b.nextReaction)
+	if react {
+		b.nextReaction = now.Add(b.ai.Difficulty().ReactionTime())
 	}
-	b.isRunning = true
-	b.mutex.Unlock()
-
-	ticker := time.NewTicker(200 * time.Millisecond) // Move every 200ms
-	defer ticker.Stop()
-
-	currentDir := directions[rand.Intn(len(directions))]
-	directionChangeCounter := 0
-	stuckCounter := 0
-
-	for {
-		select {
-		case <-b.stopChan:
-			return
-		case <-ticker.C:
-			// Change direction occasionally or randomly
-			directionChangeCounter++
-			if directionChangeCounter > 10+rand.Intn(20) || rand.Float32() < 0.1 {
-				currentDir = b.chooseNewDirection(currentDir)
-				directionChangeCounter = 0
-			}
 
-			// Calculate new position based on direction
-			speed := PlayerSpeed * 0.2 * 0.001 * 200 // Adjust for tick rate
-			newX, newY := b.PlayerEntity.X, b.PlayerEntity.Y
-
-			switch currentDir {
-			case "up":
-				newY -= speed
-			case "down":
-				newY += speed
-			case "left":
-				newX -= speed
-			case "right":
-				newX += speed
-			}
+	if b.World.Rules.IsRunner(b.PlayerEntity.SpriteType) {
+		if react {
+			b.threats = b.getThreatTiles()
+		}
+		return b.runnerAI.GetNextMove(tileX, tileY, b.threats, b.World.isPoweredLocked(b.PlayerEntity.PlayerId, now), b.World.MazeData)
+	}
+
+	if react {
+		b.target = b.chaseTarget()
+	}
+	return b.ai.GetNextMove(tileX, tileY, b.target.X, b.target.Y, b.World.chaserBehaviorLocked(b.PlayerEntity.SpriteType, now, b.AggressionLevel))
+}
 
-			// Check wall collision using maze data
-			if b.World.MazeData != nil && !b.World.MazeData.CanMoveTo(b.PlayerEntity.X, b.PlayerEntity.Y, newX, newY) {
-				// Hit a wall, choose new direction
-				stuckCounter++
-				if stuckCounter > 3 {
-					currentDir = b.chooseNewDirection(currentDir)
-					stuckCounter = 0
-				}
+// getThreatTiles returns the tiles of the players a runner bot has to watch:
+// the chasers not on their way back to the house, or in modes where everyone
+// runs, the powered up opponents
+func (b *Bot) getThreatTiles() []TilePoint {
+	tiles := []TilePoint{}
+	for _, id := range b.World.sortedPlayerIdsLocked() {
+		player := b.World.Players[id]
+		if id == b.PlayerEntity.PlayerId || b.World.isChaserEatenLocked(player.SpriteType) {
+			continue
+		}
+		if b.World.Rules.IsRunner(player.SpriteType) {
+			if _, powered := b.World.PoweredUntil[id]; !powered {
 				continue
 			}
-			
-			stuckCounter = 0
-			b.PlayerEntity.X = newX
-			b.PlayerEntity.Y = newY
-			b.PlayerEntity.Dir = currentDir
-			
-			// Update world position tracking
-			b.World.worldLock.Lock()
-			b.World.PlayerPositions[b.PlayerEntity.PlayerId] = &PointF{X: newX, Y: newY}
-			b.World.worldLock.Unlock()
-
-			// Broadcast position to all players
-			posMsg := map[string]interface{}{
-				"type":       "pos",
-				"spriteType": string(b.PlayerEntity.SpriteType),
-				"x":          newX,
-				"y":          newY,
-				"dir":        currentDir,
-			}
+		}
+		x, y := PixelToTile(player.X, player.Y)
+		tiles = append(tiles, TilePoint{X: x, Y: y})
+	}
+	return tiles
+}
 
-			if msgBytes, err := json.Marshal(posMsg); err == nil {
-				broadcast(msgBytes)
-			}
+// chaseTarget is the tile the bot's strategy aims for
+func (b *Bot) chaseTarget() TilePoint {
+	runnerX, runnerY := b.getRunnerPosition()
+	targetX, targetY := b.calculateTargetPosition(runnerX, runnerY, true)
+	b.LastRunnerX, b.LastRunnerY = runnerX, runnerY
+
+	x, y := PixelToTile(targetX, targetY)
+	return TilePoint{X: x, Y: y}
+}
+
+// directionBetween returns the direction from one tile to a neighbouring tile
+func directionBetween(fromX, fromY, toX, toY int) string {
+	switch {
+	case toY < fromY:
+		return "up"
+	case toY > fromY:
+		return "down"
+	case toX < fromX:
+		return "left"
+	case toX > fromX:
+		return "right"
+	}
+	return ""
+}
+
+// wanderStep moves the bot in its current direction, changing direction now
+// and then or when it hits a wall
+func (b *Bot) wanderStep(now time.Time) *gamev1.Envelope {
+	// Change direction occasionally or randomly
+	b.directionChangeCounter++
+	if b.directionChangeCounter > 10+b.World.rng.Intn(20) || b.World.rng.Float32() < 0.1 {
+		b.currentDir = b.chooseNewDirection(b.currentDir)
+		b.directionChangeCounter = 0
+	}
+
+	event, moved := b.World.stepPlayerLocked(b.PlayerEntity, b.currentDir, b.speed(), now)
+	if !moved {
+		// Hit a wall, choose new direction
+		b.stuckCounter++
+		if b.stuckCounter > 3 {
+			b.currentDir = b.chooseNewDirection(b.currentDir)
+			b.stuckCounter = 0
 		}
+		return nil
 	}
+
+	b.stuckCounter = 0
+	return event
 }
 
 // chooseNewDirection picks a valid direction to move in based on bot strategy
@@ -261,7 +451,7 @@ func (b *Bot) chooseNewDirection(currentDir string) string {
 	runnerX, runnerY := b.getRunnerPosition()
 	
 	// Determine if this bot should chase or flee
-	isChaser := b.PlayerEntity.SpriteType != Runner
+	isChaser := !b.World.Rules.IsRunner(b.PlayerEntity.SpriteType)
 	
 	// Calculate target position based on strategy
 	targetX, targetY := b.calculateTargetPosition(runnerX, runnerY, isChaser)
@@ -273,7 +463,7 @@ func (b *Bot) chooseNewDirection(currentDir string) string {
 	}
 	validDirs := make([]dirScore, 0, 4)
 	
-	speed := PlayerSpeed * 0.2 * 0.001 * 200
+	speed := b.speed()
 	for _, dir := range directions {
 		testX, testY := b.PlayerEntity.X, b.PlayerEntity.Y
 		switch dir {
@@ -298,7 +488,7 @@ func (b *Bot) chooseNewDirection(currentDir string) string {
 			
 			// Apply aggression - higher aggression means less randomness
 			randomFactor := 1.0 - b.AggressionLevel
-			score += (rand.Float64() - 0.5) * 100 * randomFactor
+			score += (b.World.rng.Float64() - 0.5) * 100 * randomFactor
 			
 			// Penalize reversing direction (avoid back and forth)
 			if isOppositeDirection(dir, currentDir) {
@@ -311,7 +501,7 @@ func (b *Bot) chooseNewDirection(currentDir string) string {
 	
 	if len(validDirs) == 0 {
 		// Fallback to random
-		return directions[rand.Intn(len(directions))]
+		return directions[b.World.rng.Intn(len(directions))]
 	}
 	
 	// Pick direction with best score
@@ -455,7 +645,7 @@ func (b *Bot) calculateFleePosition() (float64, float64) {
 	
 	if len(chaserPositions) == 0 {
 		// No chasers, just wander
-		return b.PlayerEntity.X + float64(rand.Intn(200)-100), b.PlayerEntity.Y + float64(rand.Intn(200)-100)
+		return b.PlayerEntity.X + float64(b.World.rng.Intn(200)-100), b.PlayerEntity.Y + float64(b.World.rng.Intn(200)-100)
 	}
 	
 	// Calculate average chaser position
@@ -480,34 +670,14 @@ func (b *Bot) calculateFleePosition() (float64, float64) {
 
 // getChaserPositions returns positions of all chaser bots/players
 func (b *Bot) getChaserPositions() []PointF {
-	b.World.worldLock.Lock()
-	defer b.World.worldLock.Unlock()
-	
 	positions := make([]PointF, 0)
 	
-	// Check all player positions
-	for playerId, pos := range b.World.PlayerPositions {
-		if pos == nil || playerId == b.PlayerEntity.PlayerId {
+	for _, id := range b.World.sortedPlayerIdsLocked() {
+		player := b.World.Players[id]
+		if id == b.PlayerEntity.PlayerId || b.World.Rules.IsRunner(player.SpriteType) {
 			continue
 		}
-		
-		// Check if this is a chaser
-		session, exists := b.World.ConnectedPlayers.Load(playerId)
-		if exists && session != nil {
-			player, err := getPlayerEntityFromSession(session)
-			if err == nil && player.SpriteType != Runner {
-				positions = append(positions, *pos)
-			}
-		}
-	}
-	
-	// Also check bot chasers
-	if b.World.BotManager != nil {
-		for _, bot := range b.World.BotManager.GetBots() {
-			if bot.PlayerEntity.PlayerId != b.PlayerEntity.PlayerId && bot.PlayerEntity.SpriteType != Runner {
-				positions = append(positions, PointF{X: bot.PlayerEntity.X, Y: bot.PlayerEntity.Y})
-			}
-		}
+		positions = append(positions, PointF{X: player.X, Y: player.Y})
 	}
 	
 	return positions
@@ -515,31 +685,9 @@ func (b *Bot) getChaserPositions() []PointF {
 
 // getRunnerPosition returns the runner's current position
 func (b *Bot) getRunnerPosition() (float64, float64) {
-	b.World.worldLock.Lock()
-	defer b.World.worldLock.Unlock()
-	
-	// Look through PlayerPositions to find the runner
-	for playerId, pos := range b.World.PlayerPositions {
-		if pos == nil {
-			continue
-		}
-		
-		// Check connected players for runner
-		session, exists := b.World.ConnectedPlayers.Load(playerId)
-		if exists && session != nil {
-			player, err := getPlayerEntityFromSession(session)
-			if err == nil && player.SpriteType == Runner {
-				return pos.X, pos.Y
-			}
-		}
-	}
-	
-	// Also check bots directly (they store position in PlayerEntity)
-	if b.World.BotManager != nil {
-		for _, bot := range b.World.BotManager.GetBots() {
-			if bot.PlayerEntity.SpriteType == Runner {
-				return bot.PlayerEntity.X, bot.PlayerEntity.Y
-			}
+	for _, player := range b.World.Players {
+		if player.SpriteType == Runner {
+			return player.X, player.Y
 		}
 	}
 	
@@ -547,26 +695,16 @@ func (b *Bot) getRunnerPosition() (float64, float64) {
 	return 700, 575
 }
 
-// Stop stops the bot
-func (b *Bot) Stop() {
-	b.mutex.Lock()
-	defer b.mutex.Unlock()
-
-	if b.isRunning {
-		close(b.stopChan)
-		b.isRunning = false
-	}
-}
-
 // StopAllBots stops all bots managed by this manager
 func (bm *BotManager) StopAllBots() {
+	bm.world.worldLock.Lock()
+	defer bm.world.worldLock.Unlock()
 	bm.mutex.Lock()
 	defer bm.mutex.Unlock()
 
 	for _, bot := range bm.bots {
-		bot.Stop()
 		// Remove from connected players
-		bm.world.ConnectedPlayers.Delete(bot.PlayerEntity.PlayerId)
+		bm.world.removeBotLocked(bot.PlayerEntity.PlayerId)
 		// Return sprite to available list
 		bm.world.CharactersList = append(bm.world.CharactersList, bot.PlayerEntity.SpriteType)
 	}
@@ -576,6 +714,8 @@ func (bm *BotManager) StopAllBots() {
 
 // RemoveOneBot removes one bot (when a real player joins)
 func (bm *BotManager) RemoveOneBot() {
+	bm.world.worldLock.Lock()
+	defer bm.world.worldLock.Unlock()
 	bm.mutex.Lock()
 	defer bm.mutex.Unlock()
 
@@ -587,23 +727,17 @@ func (bm *BotManager) RemoveOneBot() {
 	bot := bm.bots[len(bm.bots)-1]
 	bm.bots = bm.bots[:len(bm.bots)-1]
 
-	bot.Stop()
-
 	// Remove from connected players
-	bm.world.ConnectedPlayers.Delete(bot.PlayerEntity.PlayerId)
+	bm.world.removeBotLocked(bot.PlayerEntity.PlayerId)
 
 	// Return sprite to available list
 	bm.world.CharactersList = append(bm.world.CharactersList, bot.PlayerEntity.SpriteType)
 
 	// Broadcast bot leave
-	leaveMsg := map[string]interface{}{
-		"type":       "dis",
-		"user":       bot.PlayerEntity.Username,
-		"spriteType": string(bot.PlayerEntity.SpriteType),
-	}
-	if msgBytes, err := json.Marshal(leaveMsg); err == nil {
-		bm.broadcast(msgBytes)
-	}
+	bm.broadcast(&gamev1.Envelope{Payload: &gamev1.Envelope_Dis{Dis: &gamev1.PlayerUpdate{
+		User:       bot.PlayerEntity.Username,
+		SpriteType: string(bot.PlayerEntity.SpriteType),
+	}}})
 
 	log.Info().Str("name", bot.PlayerEntity.Username).Msg("Bot removed to make room for player")
 }
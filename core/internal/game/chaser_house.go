@@ -0,0 +1,210 @@
+package game
+
+import (
+	"math"
+	"time"
+
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
+	"github.com/rs/zerolog/log"
+)
+
+// ChaserPhase is where a chaser is in its cycle through the chaser house
+type ChaserPhase string
+
+const (
+	ChaserActive     ChaserPhase = "active"
+	ChaserFrightened ChaserPhase = "frightened" // can be eaten by the runner
+	ChaserEyes       ChaserPhase = "eyes"       // eaten, on its way back to the house
+	ChaserHouse      ChaserPhase = "house"      // waiting in the house to be released
+)
+
+// ChaserStatus is the house cycle of one chaser, chasers without a status
+// are active
+type ChaserStatus struct {
+	Phase           ChaserPhase
+	FrightenedUntil time.Time
+	ReleaseAt       time.Time
+	route           []Point // tiles left to travel as eyes
+}
+
+// chaserStatusLocked returns the status of a chaser, creating an active one
+// (caller must hold worldLock)
+func (w *World) chaserStatusLocked(sprite SpriteType) *ChaserStatus {
+	status, ok := w.Chasers[sprite]
+	if !ok {
+		status = &ChaserStatus{Phase: ChaserActive}
+		w.Chasers[sprite] = status
+	}
+	return status
+}
+
+// chaserPhaseLocked returns the phase of a chaser (caller must hold worldLock)
+func (w *World) chaserPhaseLocked(sprite SpriteType) ChaserPhase {
+	if status, ok := w.Chasers[sprite]; ok {
+		return status.Phase
+	}
+	return ChaserActive
+}
+
+// isFrightenedLocked reports whether the runner can eat a chaser (caller must
+// hold worldLock)
+func (w *World) isFrightenedLocked(sprite SpriteType) bool {
+	return w.chaserPhaseLocked(sprite) == ChaserFrightened
+}
+
+// frightenChasersLocked frightens the chasers in play for the length of a
+// power-up, eaten ones stay eyes or in the house (caller must hold worldLock)
+func (w *World) frightenChasersLocked(now time.Time) {
+	for _, sprite := range []SpriteType{Chaser1, Chaser2, Chaser3} {
+		if w.playerIdBySpriteLocked(sprite) == "" || w.isChaserEatenLocked(sprite) {
+			continue
+		}
+		status := w.chaserStatusLocked(sprite)
+		status.Phase = ChaserFrightened
+		status.FrightenedUntil = now.Add(w.powerUpDurationLocked())
+		w.emitChaserLocked(sprite, w.powerUpDurationLocked())
+	}
+}
+
+// calmChasersLocked ends the fright of every chaser, for power-ups that end
+// early (caller must hold worldLock)
+func (w *World) calmChasersLocked() {
+	for _, sprite := range []SpriteType{Chaser1, Chaser2, Chaser3} {
+		if w.isFrightenedLocked(sprite) {
+			w.chaserStatusLocked(sprite).Phase = ChaserActive
+			w.emitChaserLocked(sprite, 0)
+		}
+	}
+}
+
+// eatChaserLocked turns an eaten chaser into eyes that travel back to the
+// house (caller must hold worldLock)
+func (w *World) eatChaserLocked(sprite SpriteType) {
+	status := w.chaserStatusLocked(sprite)
+	status.Phase = ChaserEyes
+	status.FrightenedUntil = time.Time{}
+	status.route = nil
+	w.ChasersIdsEaten = append(w.ChasersIdsEaten, sprite)
+
+	if player, ok := w.Players[w.playerIdBySpriteLocked(sprite)]; ok {
+		house := w.Map.House(sprite)
+		tileX, tileY := PixelToTile(player.X, player.Y)
+		status.route = NewAStarPathfinder(w.PathGrid).FindPath(tileX, tileY, house.X, house.Y)
+	}
+	w.emitChaserLocked(sprite, 0)
+}
+
+// tickChasersLocked ends frights, moves eyes back to the house and releases
+// chasers from the house one at a time (called from the loop with worldLock
+// held)
+func (w *World) tickChasersLocked(now time.Time) {
+	for _, sprite := range []SpriteType{Chaser1, Chaser2, Chaser3} {
+		status, ok := w.Chasers[sprite]
+		if !ok {
+			continue
+		}
+
+		switch status.Phase {
+		case ChaserFrightened:
+			if !now.Before(status.FrightenedUntil) {
+				status.Phase = ChaserActive
+				w.emitChaserLocked(sprite, 0)
+			}
+		case ChaserEyes:
+			if w.moveEyesLocked(sprite, status) {
+				status.Phase = ChaserHouse
+				status.ReleaseAt = now.Add(ChaserRespawnMs * time.Millisecond)
+				w.emitChaserLocked(sprite, ChaserRespawnMs*time.Millisecond)
+			}
+		case ChaserHouse:
+			if now.Before(status.ReleaseAt) || now.Before(w.nextReleaseAt) {
+				continue
+			}
+			status.Phase = ChaserActive
+			w.nextReleaseAt = now.Add(ChaserReleaseIntervalMs * time.Millisecond)
+			w.removeEatenChaserLocked(sprite)
+			w.emitChaserLocked(sprite, 0)
+			log.Info().Str("chaser", string(sprite)).Msg("Chaser released from the house")
+		}
+	}
+}
+
+// moveEyesLocked moves eyes along their route at ChaserEyesSpeed and reports
+// whether they reached the house, eyes of a chaser that left go straight home
+// (caller must hold worldLock)
+func (w *World) moveEyesLocked(sprite SpriteType, status *ChaserStatus) bool {
+	player, ok := w.Players[w.playerIdBySpriteLocked(sprite)]
+	if !ok {
+		return true
+	}
+	if len(status.route) == 0 {
+		house := w.Map.House(sprite)
+		x, y := TileToPixel(house.X, house.Y)
+		w.movePlayerLocked(player, x, y)
+		w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Pos{Pos: player.ToProto()}})
+		return true
+	}
+
+	budget := ChaserEyesSpeed * TickRateSec
+	for budget > 0 && len(status.route) > 0 {
+		x, y := TileToPixel(int(status.route[0].X), int(status.route[0].Y))
+		dx, dy := x-player.X, y-player.Y
+		player.Dir = directionOf(dx, dy, player.Dir)
+		dist := math.Hypot(dx, dy)
+		if dist > budget {
+			w.movePlayerLocked(player, player.X+dx/dist*budget, player.Y+dy/dist*budget)
+			break
+		}
+		w.movePlayerLocked(player, x, y)
+		budget -= dist
+		status.route = status.route[1:]
+	}
+	w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Pos{Pos: player.ToProto()}})
+	return len(status.route) == 0
+}
+
+// removeEatenChaserLocked drops a released chaser from ChasersIdsEaten
+// (caller must hold worldLock)
+func (w *World) removeEatenChaserLocked(sprite SpriteType) {
+	for i, eaten := range w.ChasersIdsEaten {
+		if eaten == sprite {
+			w.ChasersIdsEaten = append(w.ChasersIdsEaten[:i], w.ChasersIdsEaten[i+1:]...)
+			return
+		}
+	}
+}
+
+// resetChasersLocked makes every chaser active again for a respawn, the
+// house lets them all out (caller must hold worldLock)
+func (w *World) resetChasersLocked() {
+	for _, sprite := range []SpriteType{Chaser1, Chaser2, Chaser3} {
+		if w.chaserPhaseLocked(sprite) != ChaserActive {
+			w.Chasers[sprite] = &ChaserStatus{Phase: ChaserActive}
+			w.emitChaserLocked(sprite, 0)
+		}
+	}
+	w.ChasersIdsEaten = []SpriteType{}
+	w.nextReleaseAt = time.Time{}
+}
+
+// chaserPhasesLocked returns the chasers that are not active by sprite, for
+// the state report (caller must hold worldLock)
+func (w *World) chaserPhasesLocked() map[string]string {
+	phases := make(map[string]string)
+	for sprite, status := range w.Chasers {
+		if status.Phase != ChaserActive {
+			phases[string(sprite)] = string(status.Phase)
+		}
+	}
+	return phases
+}
+
+// emitChaserLocked sends the phase of a chaser, with how long a fright or a
+// wait in the house lasts (caller must hold worldLock)
+func (w *World) emitChaserLocked(sprite SpriteType, d time.Duration) {
+	w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Chaser{Chaser: &gamev1.ChaserState{
+		SpriteId: string(sprite),
+		State:    string(w.chaserPhaseLocked(sprite)),
+		Ms:       uint32(d.Milliseconds()),
+	}}})
+}
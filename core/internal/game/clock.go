@@ -0,0 +1,145 @@
+package game
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is the time source of a World. The server runs on the wall clock,
+// simulations and tests move a ManualClock forward themselves.
+type Clock interface {
+	Now() time.Time
+	// After sends the time once the duration has passed
+	After(d time.Duration) <-chan time.Time
+	// NewTicker sends the time every interval until it is stopped
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker is a stoppable ticker of a Clock
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// WallClock is the real time clock
+type WallClock struct{}
+
+func (WallClock) Now() time.Time { return time.Now() }
+
+func (WallClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func (WallClock) NewTicker(d time.Duration) Ticker {
+	return wallTicker{ticker: time.NewTicker(d)}
+}
+
+type wallTicker struct {
+	ticker *time.Ticker
+}
+
+func (t wallTicker) C() <-chan time.Time { return t.ticker.C }
+
+func (t wallTicker) Stop() { t.ticker.Stop() }
+
+// ManualClock is a virtual clock that only moves on Advance, timers and
+// tickers fire as the virtual time passes them
+type ManualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*manualTimer
+}
+
+type manualTimer struct {
+	at       time.Time
+	interval time.Duration // zero for a one-shot timer
+	ch       chan time.Time
+}
+
+// NewManualClock creates a virtual clock standing at start
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *ManualClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.addTimerLocked(d, 0).ch
+}
+
+func (c *ManualClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for ManualClock.NewTicker")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &manualTicker{clock: c, timer: c.addTimerLocked(d, d)}
+}
+
+// Advance moves the clock forward, every timer passed on the way fires in
+// order, a ticker that is not read drops ticks like a time.Ticker
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	target := c.now.Add(d)
+	for len(c.timers) > 0 && !c.timers[0].at.After(target) {
+		timer := c.timers[0]
+		c.timers = c.timers[1:]
+		c.now = timer.at
+
+		select {
+		case timer.ch <- timer.at:
+		default:
+		}
+		if timer.interval > 0 {
+			timer.at = timer.at.Add(timer.interval)
+			c.insertLocked(timer)
+		}
+	}
+	c.now = target
+}
+
+func (c *ManualClock) addTimerLocked(d, interval time.Duration) *manualTimer {
+	timer := &manualTimer{at: c.now.Add(d), interval: interval, ch: make(chan time.Time, 1)}
+	if d <= 0 && interval == 0 {
+		timer.ch <- c.now
+		return timer
+	}
+	c.insertLocked(timer)
+	return timer
+}
+
+func (c *ManualClock) insertLocked(timer *manualTimer) {
+	i := sort.Search(len(c.timers), func(i int) bool { return c.timers[i].at.After(timer.at) })
+	c.timers = append(c.timers, nil)
+	copy(c.timers[i+1:], c.timers[i:])
+	c.timers[i] = timer
+}
+
+func (c *ManualClock) removeLocked(timer *manualTimer) {
+	for i, t := range c.timers {
+		if t == timer {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return
+		}
+	}
+}
+
+type manualTicker struct {
+	clock *ManualClock
+	timer *manualTimer
+}
+
+func (t *manualTicker) C() <-chan time.Time { return t.timer.ch }
+
+func (t *manualTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.clock.removeLocked(t.timer)
+}
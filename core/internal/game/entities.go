@@ -3,8 +3,10 @@ package game
 import (
 	"math"
 	"math/rand"
+	"sort"
 	"sync"
-	"time"
+
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
 )
 
 // EntityType represents the type of dangerous entity
@@ -47,6 +49,10 @@ type DangerEntity struct {
 	AlertLevel    float64     `json:"alertLevel"`    // 0-1, how alert the entity is
 	GlowIntensity float64     `json:"glowIntensity"` // For visual effects
 	GlowColor     string      `json:"glowColor"`
+
+	route    []Point   // Tiles still to walk, the first is the one the entity is heading to
+	goal     TilePoint // Tile the route leads to
+	lastTile TilePoint // Tile the entity came from, wandering does not turn back
 }
 
 // Note: Point struct is defined in utils.go
@@ -55,17 +61,19 @@ type DangerEntity struct {
 type EntityManager struct {
 	mu            sync.RWMutex
 	Entities      map[string]*DangerEntity
-	ticker        *time.Ticker
-	stopChan      chan struct{}
+	rng           *rand.Rand
 	mazeWidth     int
 	mazeHeight    int
 	mazeData      [][]int // 0 = walkable, 1 = wall
+	pathfinder    *AStarPathfinder
 	dynamicWorld  *DynamicWorld
-	broadcastFunc func(msgType string, data interface{})
+	speedFactor   float64 // Factor on every entity's speed, raised in sudden death
+	broadcastFunc func(*gamev1.Envelope)
 	getPlayers    func() []PlayerPosition
 }
 
-// PlayerPosition for tracking player locations
+// PlayerPosition for tracking player locations, in tile units like the
+// entities
 type PlayerPosition struct {
 	ID string
 	X  float64
@@ -76,24 +84,76 @@ type PlayerPosition struct {
 func NewEntityManager(mazeWidth, mazeHeight int, dynamicWorld *DynamicWorld) *EntityManager {
 	em := &EntityManager{
 		Entities:     make(map[string]*DangerEntity),
-		stopChan:     make(chan struct{}),
 		mazeWidth:    mazeWidth,
 		mazeHeight:   mazeHeight,
 		dynamicWorld: dynamicWorld,
+		rng:          newUnseededRand(),
+		pathfinder:   NewAStarPathfinder(NewPathGrid(mazeWidth, mazeHeight)),
+		speedFactor:  1,
 	}
 	
 	return em
 }
 
-// SetMazeData provides the current maze layout
+// SetRand makes spawns and movement draw from a world's generator
+func (em *EntityManager) SetRand(rng *rand.Rand) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	em.rng = rng
+}
+
+// SetMazeData provides the current maze layout, entities walk its corridors
 func (em *EntityManager) SetMazeData(maze [][]int) {
 	em.mu.Lock()
 	defer em.mu.Unlock()
 	em.mazeData = maze
+	em.pathfinder = NewAStarPathfinder(NewPathGridFromWalkGrid(maze))
+}
+
+// SetWall updates one tile of the maze layout. Entities whose route or patrol
+// loop crosses a new wall plan a new one.
+func (em *EntityManager) SetWall(x, y int, wall bool) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	if y < 0 || y >= len(em.mazeData) || x < 0 || x >= len(em.mazeData[y]) {
+		return
+	}
+	em.mazeData[y][x] = 0
+	if wall {
+		em.mazeData[y][x] = 1
+	}
+	em.pathfinder.grid.SetWalkable(x, y, !wall)
+	if !wall {
+		return
+	}
+
+	tile := TilePoint{X: x, Y: y}
+	for _, entity := range em.sortedEntitiesLocked() {
+		if entityTile(entity) == tile {
+			em.placeOnTile(entity, em.nearestWalkable(x, y))
+		}
+		if routeCrosses(entity.route, tile) {
+			entity.route = nil
+		}
+		if routeCrosses(entity.PatrolPath, tile) {
+			if zone, ok := em.zone(entity.HomeZone); ok {
+				entity.PatrolPath = em.generatePatrolPath(zone)
+			}
+			entity.PatrolIndex = 0
+			entity.route = nil
+		}
+	}
+}
+
+// SetSpeedFactor makes every entity move faster or slower
+func (em *EntityManager) SetSpeedFactor(factor float64) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	em.speedFactor = factor
 }
 
 // SetBroadcastFunc sets the function to broadcast entity updates
-func (em *EntityManager) SetBroadcastFunc(fn func(msgType string, data interface{})) {
+func (em *EntityManager) SetBroadcastFunc(fn func(*gamev1.Envelope)) {
 	em.mu.Lock()
 	defer em.mu.Unlock()
 	em.broadcastFunc = fn
@@ -131,21 +191,16 @@ func (em *EntityManager) SpawnInitialEntities() {
 	}
 }
 
-// spawnEntity creates a new entity in a zone
+// spawnEntity creates a new entity on a walkable tile of a zone
 func (em *EntityManager) spawnEntity(id int, entityType EntityType, zone Zone) {
-	// Random position within zone
-	x := float64(zone.X) + rand.Float64()*float64(zone.Width)
-	y := float64(zone.Y) + rand.Float64()*float64(zone.Height)
-	
 	entity := &DangerEntity{
-		ID:       generateEntityID(id),
+		ID:       em.generateEntityID(id),
 		Type:     entityType,
 		State:    StatePatrol,
-		X:        x,
-		Y:        y,
 		Dir:      "right",
 		HomeZone: zone.ID,
 	}
+	em.placeOnTile(entity, em.randomZoneTile(zone))
 	
 	// Set type-specific properties
 	switch entityType {
@@ -169,48 +224,57 @@ func (em *EntityManager) spawnEntity(id int, entityType EntityType, zone Zone) {
 		entity.GlowColor = "#aa33ff" // Purple glow
 		entity.GlowIntensity = 0.5
 		entity.PatrolPath = em.generatePatrolPath(zone)
+		if len(entity.PatrolPath) > 0 {
+			em.placeOnTile(entity, pointTile(entity.PatrolPath[0]))
+		}
 	}
 	
 	em.Entities[entity.ID] = entity
 }
 
-// generatePatrolPath creates a patrol route for sweepers
-func (em *EntityManager) generatePatrolPath(zone Zone) []Point {
-	path := make([]Point, 0)
-	
-	// Create a rectangular patrol within the zone
-	margin := 2.0
-	path = append(path, Point{X: float64(zone.X) + margin, Y: float64(zone.Y) + margin})
-	path = append(path, Point{X: float64(zone.X+zone.Width) - margin, Y: float64(zone.Y) + margin})
-	path = append(path, Point{X: float64(zone.X+zone.Width) - margin, Y: float64(zone.Y+zone.Height) - margin})
-	path = append(path, Point{X: float64(zone.X) + margin, Y: float64(zone.Y+zone.Height) - margin})
-	
-	return path
-}
-
-// Start begins the entity update loop
-func (em *EntityManager) Start() {
-	em.ticker = time.NewTicker(50 * time.Millisecond) // 20 updates per second
-	
-	go func() {
-		for {
-			select {
-			case <-em.ticker.C:
-				em.update()
-			case <-em.stopChan:
-				em.ticker.Stop()
-				return
-			}
+// randomZoneTile draws a walkable tile inside a zone, the walkable tile
+// nearest to its center when the draws only hit walls
+func (em *EntityManager) randomZoneTile(zone Zone) TilePoint {
+	for attempt := 0; attempt < 20; attempt++ {
+		x := zone.X + em.rng.Intn(max(1, zone.Width))
+		y := zone.Y + em.rng.Intn(max(1, zone.Height))
+		if em.walkable(x, y) {
+			return TilePoint{X: x, Y: y}
 		}
-	}()
+	}
+	return em.nearestWalkable(zone.X+zone.Width/2, zone.Y+zone.Height/2)
 }
 
-// Stop halts entity updates
-func (em *EntityManager) Stop() {
-	close(em.stopChan)
+// generatePatrolPath creates a patrol loop for sweepers: the corridors
+// connecting the walkable tiles nearest to the corners of the zone, as a
+// list of adjacent tiles the last of which neighbours the first
+func (em *EntityManager) generatePatrolPath(zone Zone) []Point {
+	margin := 2
+	corners := []TilePoint{
+		em.nearestWalkable(zone.X+margin, zone.Y+margin),
+		em.nearestWalkable(zone.X+zone.Width-1-margin, zone.Y+margin),
+		em.nearestWalkable(zone.X+zone.Width-1-margin, zone.Y+zone.Height-1-margin),
+		em.nearestWalkable(zone.X+margin, zone.Y+zone.Height-1-margin),
+	}
+
+	path := make([]Point, 0)
+	for i, from := range corners {
+		to := corners[(i+1)%len(corners)]
+		leg := em.pathfinder.FindPath(from.X, from.Y, to.X, to.Y)
+		if leg == nil {
+			// The corners are not connected, the sweeper wanders instead
+			return nil
+		}
+		// Each leg starts where the previous one ended
+		path = append(path, leg[:len(leg)-1]...)
+	}
+	if len(path) < 2 {
+		return nil
+	}
+	return path
 }
 
-// update processes entity AI each tick
+// update processes entity AI each tick, driven by the world loop every EntityTickMs
 func (em *EntityManager) update() {
 	em.mu.Lock()
 	defer em.mu.Unlock()
@@ -224,9 +288,9 @@ func (em *EntityManager) update() {
 	// Get current phase for behavior modification
 	currentPhase := em.dynamicWorld.CurrentPhase
 	
-	updates := make([]map[string]interface{}, 0)
+	updates := make([]*gamev1.Entity, 0)
 	
-	for _, entity := range em.Entities {
+	for _, entity := range em.sortedEntitiesLocked() {
 		// Entities are more aggressive at night
 		aggressionMultiplier := 1.0
 		if currentPhase == PhaseNight {
@@ -248,22 +312,12 @@ func (em *EntityManager) update() {
 		// Update glow based on alert level
 		entity.GlowIntensity = 0.5 + (entity.AlertLevel * 0.5)
 		
-		updates = append(updates, map[string]interface{}{
-			"id":        entity.ID,
-			"type":      entity.Type,
-			"state":     entity.State,
-			"x":         entity.X,
-			"y":         entity.Y,
-			"dir":       entity.Dir,
-			"glow":      entity.GlowIntensity,
-			"glowColor": entity.GlowColor,
-			"alert":     entity.AlertLevel,
-		})
+		updates = append(updates, entity.toProto())
 	}
 	
 	// Broadcast entity updates
 	if em.broadcastFunc != nil && len(updates) > 0 {
-		em.broadcastFunc("entities_update", updates)
+		em.broadcastFunc(&gamev1.Envelope{Payload: &gamev1.Envelope_EntitiesUpdate{EntitiesUpdate: &gamev1.EntitiesUpdate{Entities: updates}}})
 	}
 }
 
@@ -277,7 +331,7 @@ func (em *EntityManager) updateHunter(entity *DangerEntity, players []PlayerPosi
 	switch entity.State {
 	case StatePatrol:
 		// Random wandering
-		em.randomMovement(entity, 0.05)
+		em.wander(entity, entity.Speed*WanderSpeedFactor)
 		
 		// Check for players
 		if distance < detectionRange && nearestPlayer != nil {
@@ -303,7 +357,12 @@ func (em *EntityManager) updateHunter(entity *DangerEntity, players []PlayerPosi
 		
 	case StateReturn:
 		// Return to home zone
-		em.randomMovement(entity, 0.05)
+		if zone, ok := em.zone(entity.HomeZone); ok {
+			home := em.nearestWalkable(zone.X+zone.Width/2, zone.Y+zone.Height/2)
+			em.moveToward(entity, float64(home.X)+0.5, float64(home.Y)+0.5, entity.Speed)
+		} else {
+			em.wander(entity, entity.Speed*WanderSpeedFactor)
+		}
 		entity.AlertLevel = math.Max(0, entity.AlertLevel-0.01)
 		
 		if entity.AlertLevel <= 0 {
@@ -333,7 +392,7 @@ func (em *EntityManager) updateScanner(entity *DangerEntity, players []PlayerPos
 	}
 	
 	// Slow movement while scanning
-	em.randomMovement(entity, 0.02)
+	em.wander(entity, entity.Speed*ScanSpeedFactor)
 	
 	// Decay alert level
 	if entity.State == StateAlert {
@@ -347,18 +406,11 @@ func (em *EntityManager) updateScanner(entity *DangerEntity, players []PlayerPos
 
 // updateSweeper processes sweeper AI
 func (em *EntityManager) updateSweeper(entity *DangerEntity, players []PlayerPosition, aggression float64) {
-	// Follow patrol path
+	// Follow patrol loop
 	if len(entity.PatrolPath) > 0 {
-		target := entity.PatrolPath[entity.PatrolIndex]
-		
-		dist := math.Sqrt(math.Pow(target.X-entity.X, 2) + math.Pow(target.Y-entity.Y, 2))
-		
-		if dist < 0.5 {
-			// Move to next patrol point
-			entity.PatrolIndex = (entity.PatrolIndex + 1) % len(entity.PatrolPath)
-		} else {
-			em.moveToward(entity, target.X, target.Y, entity.Speed*aggression)
-		}
+		em.patrol(entity, entity.Speed*aggression)
+	} else {
+		em.wander(entity, entity.Speed*WanderSpeedFactor)
 	}
 	
 	// Check for players in detection range
@@ -427,84 +479,221 @@ func (em *EntityManager) findNearestPlayer(entity *DangerEntity, players []Playe
 	return nearest, minDist
 }
 
-// moveToward moves an entity toward a target position
+// moveToward walks an entity along the shortest corridor path to the tile
+// of a target position, the path is planned again when the target changes tile
 func (em *EntityManager) moveToward(entity *DangerEntity, targetX, targetY, speed float64) {
-	dx := targetX - entity.X
-	dy := targetY - entity.Y
-	dist := math.Sqrt(dx*dx + dy*dy)
-	
-	if dist < 0.1 {
+	goal := em.nearestWalkable(int(math.Floor(targetX)), int(math.Floor(targetY)))
+	if goal != entity.goal || len(entity.route) == 0 {
+		em.planRoute(entity, goal)
+	}
+	em.advance(entity, speed)
+}
+
+// planRoute plans the corridor path of an entity to a tile, starting from
+// the tile it is heading to so it never leaves the grid
+func (em *EntityManager) planRoute(entity *DangerEntity, goal TilePoint) {
+	entity.goal = goal
+
+	from := entityTile(entity)
+	var next []Point
+	if len(entity.route) > 0 {
+		from = pointTile(entity.route[0])
+		next = entity.route[:1]
+	}
+
+	path := em.pathfinder.FindPath(from.X, from.Y, goal.X, goal.Y)
+	if len(path) == 0 {
+		entity.route = next
 		return
 	}
-	
-	// Normalize and apply speed (multiply by tick rate: 0.05 seconds)
-	entity.X += (dx / dist) * speed * 0.05
-	entity.Y += (dy / dist) * speed * 0.05
-	
-	// Update direction
-	if math.Abs(dx) > math.Abs(dy) {
-		if dx > 0 {
-			entity.Dir = "right"
-		} else {
-			entity.Dir = "left"
+	entity.route = append(append([]Point{}, next...), path[1:]...)
+}
+
+// patrol walks a sweeper around its patrol loop
+func (em *EntityManager) patrol(entity *DangerEntity, speed float64) {
+	if len(entity.route) == 0 {
+		entity.PatrolIndex %= len(entity.PatrolPath)
+		target := pointTile(entity.PatrolPath[entity.PatrolIndex])
+		if target == entityTile(entity) {
+			// On the loop, head to the next tile of it
+			entity.PatrolIndex = (entity.PatrolIndex + 1) % len(entity.PatrolPath)
+			target = pointTile(entity.PatrolPath[entity.PatrolIndex])
 		}
-	} else {
-		if dy > 0 {
-			entity.Dir = "down"
-		} else {
-			entity.Dir = "up"
+		em.planRoute(entity, target)
+	}
+	em.advance(entity, speed)
+}
+
+// wander walks an entity through the corridors, at every tile it picks a
+// random way on and only turns back in dead ends
+func (em *EntityManager) wander(entity *DangerEntity, speed float64) {
+	if len(entity.route) == 0 {
+		tile := entityTile(entity)
+		options := make([]TilePoint, 0, 4)
+		for _, next := range em.walkableNeighbors(tile) {
+			if next != entity.lastTile {
+				options = append(options, next)
+			}
+		}
+		if len(options) == 0 {
+			options = em.walkableNeighbors(tile)
+		}
+		if len(options) == 0 {
+			return
 		}
+		next := options[em.rng.Intn(len(options))]
+		entity.route = []Point{{X: float64(next.X), Y: float64(next.Y)}}
+		entity.goal = next
 	}
+	em.advance(entity, speed)
 }
 
-// randomMovement makes the entity wander randomly
-func (em *EntityManager) randomMovement(entity *DangerEntity, speed float64) {
-	// Slight random direction changes
-	entity.X += (rand.Float64()*2 - 1) * speed
-	entity.Y += (rand.Float64()*2 - 1) * speed
-	
-	// Clamp to maze bounds
-	entity.X = math.Max(1, math.Min(float64(em.mazeWidth-1), entity.X))
-	entity.Y = math.Max(1, math.Min(float64(em.mazeHeight-1), entity.Y))
+// advance moves an entity along its route, from tile center to tile center,
+// at a speed in tiles per second for one entity tick
+func (em *EntityManager) advance(entity *DangerEntity, speed float64) {
+	budget := speed * em.speedFactor * EntityTickMs / 1000
+	for budget > 0 && len(entity.route) > 0 {
+		next := pointTile(entity.route[0])
+		if !em.walkable(next.X, next.Y) {
+			entity.route = nil
+			return
+		}
+
+		targetX, targetY := float64(next.X)+0.5, float64(next.Y)+0.5
+		dx := targetX - entity.X
+		dy := targetY - entity.Y
+		dist := math.Abs(dx) + math.Abs(dy)
+		entity.Dir = directionOf(dx, dy, entity.Dir)
+
+		if dist > budget {
+			entity.X += dx / dist * budget
+			entity.Y += dy / dist * budget
+			return
+		}
+
+		entity.lastTile = entityTile(entity)
+		entity.X, entity.Y = targetX, targetY
+		entity.route = entity.route[1:]
+		budget -= dist
+	}
+}
+
+// directionOf is the direction of a move, the current one when there is no move
+func directionOf(dx, dy float64, current string) string {
+	switch {
+	case dx == 0 && dy == 0:
+		return current
+	case math.Abs(dx) > math.Abs(dy) && dx > 0:
+		return "right"
+	case math.Abs(dx) > math.Abs(dy):
+		return "left"
+	case dy > 0:
+		return "down"
+	default:
+		return "up"
+	}
+}
+
+// placeOnTile puts an entity on the center of a tile and forgets its route
+func (em *EntityManager) placeOnTile(entity *DangerEntity, tile TilePoint) {
+	entity.X, entity.Y = float64(tile.X)+0.5, float64(tile.Y)+0.5
+	entity.route = nil
+	entity.lastTile = tile
+}
+
+// walkable reports whether an entity can stand on a tile
+func (em *EntityManager) walkable(x, y int) bool {
+	node := em.pathfinder.grid.GetNode(x, y)
+	return node != nil && node.Walkable
+}
+
+func (em *EntityManager) walkableNeighbors(tile TilePoint) []TilePoint {
+	node := em.pathfinder.grid.GetNode(tile.X, tile.Y)
+	if node == nil {
+		return nil
+	}
+	result := make([]TilePoint, 0, 4)
+	for _, neighbor := range em.pathfinder.getNeighbors(node) {
+		result = append(result, TilePoint{X: neighbor.X, Y: neighbor.Y})
+	}
+	return result
+}
+
+// nearestWalkable returns the walkable tile closest to a tile
+func (em *EntityManager) nearestWalkable(x, y int) TilePoint {
+	x, y = em.pathfinder.grid.NearestWalkable(x, y)
+	return TilePoint{X: x, Y: y}
+}
+
+// zone looks up a zone of the dynamic world by id
+func (em *EntityManager) zone(id int) (Zone, bool) {
+	for _, zone := range em.dynamicWorld.Zones {
+		if zone.ID == id {
+			return zone, true
+		}
+	}
+	return Zone{}, false
+}
+
+// entityTile is the tile an entity stands on
+func entityTile(entity *DangerEntity) TilePoint {
+	return TilePoint{X: int(math.Floor(entity.X)), Y: int(math.Floor(entity.Y))}
+}
+
+func pointTile(point Point) TilePoint {
+	return TilePoint{X: int(point.X), Y: int(point.Y)}
 }
 
-// GetEntitiesJSON returns entities for client
-func (em *EntityManager) GetEntitiesJSON() []map[string]interface{} {
+// routeCrosses reports whether a list of tiles contains a tile
+func routeCrosses(route []Point, tile TilePoint) bool {
+	for _, point := range route {
+		if pointTile(point) == tile {
+			return true
+		}
+	}
+	return false
+}
+
+// EntitiesState returns entities for client
+func (em *EntityManager) EntitiesState() []*gamev1.Entity {
 	em.mu.RLock()
 	defer em.mu.RUnlock()
 	
-	result := make([]map[string]interface{}, 0, len(em.Entities))
+	result := make([]*gamev1.Entity, 0, len(em.Entities))
 	
-	for _, entity := range em.Entities {
-		result = append(result, map[string]interface{}{
-			"id":             entity.ID,
-			"type":           entity.Type,
-			"state":          entity.State,
-			"x":              entity.X,
-			"y":              entity.Y,
-			"dir":            entity.Dir,
-			"glowIntensity":  entity.GlowIntensity,
-			"glowColor":      entity.GlowColor,
-			"alertLevel":     entity.AlertLevel,
-			"scanDirection":  entity.ScanDirection,
-			"scanAngle":      entity.ScanAngle,
-			"detectionRange": entity.DetectionRange,
-		})
+	for _, entity := range em.sortedEntitiesLocked() {
+		result = append(result, entity.toProto())
 	}
 	
 	return result
 }
 
-// CheckPlayerCollision checks if a player collides with any entity
-func (em *EntityManager) CheckPlayerCollision(playerX, playerY float64) *DangerEntity {
+// toProto is the entity as clients draw it
+func (e *DangerEntity) toProto() *gamev1.Entity {
+	return &gamev1.Entity{
+		Id:             e.ID,
+		Type:           string(e.Type),
+		State:          string(e.State),
+		X:              e.X,
+		Y:              e.Y,
+		Dir:            e.Dir,
+		Glow:           e.GlowIntensity,
+		GlowColor:      e.GlowColor,
+		Alert:          e.AlertLevel,
+		ScanDirection:  e.ScanDirection,
+		ScanAngle:      e.ScanAngle,
+		DetectionRange: e.DetectionRange,
+	}
+}
+
+// CheckPlayerCollision returns the entity within EntityCatchRadius of a
+// player position in tile units, if any
+func (em *EntityManager) CheckPlayerCollision(tileX, tileY float64) *DangerEntity {
 	em.mu.RLock()
 	defer em.mu.RUnlock()
 	
-	collisionRadius := 0.5
-	
-	for _, entity := range em.Entities {
-		dist := math.Sqrt(math.Pow(entity.X-playerX, 2) + math.Pow(entity.Y-playerY, 2))
-		if dist < collisionRadius {
+	for _, entity := range em.sortedEntitiesLocked() {
+		if math.Hypot(entity.X-tileX, entity.Y-tileY) < EntityCatchRadius {
 			return entity
 		}
 	}
@@ -512,18 +701,29 @@ func (em *EntityManager) CheckPlayerCollision(playerX, playerY float64) *DangerE
 	return nil
 }
 
+// sortedEntitiesLocked returns the entities ordered by id, so random draws
+// happen in the same order every run (caller must hold mu)
+func (em *EntityManager) sortedEntitiesLocked() []*DangerEntity {
+	result := make([]*DangerEntity, 0, len(em.Entities))
+	for _, entity := range em.Entities {
+		result = append(result, entity)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
 // generateEntityID creates a unique entity ID
-func generateEntityID(num int) string {
+func (em *EntityManager) generateEntityID(num int) string {
 	types := []string{"H", "S", "W"} // Hunter, Scanner, sWeeper
-	return types[num%3] + "-" + randomString(4)
+	return types[num%3] + "-" + em.randomString(4)
 }
 
 // randomString generates a random alphanumeric string
-func randomString(n int) string {
+func (em *EntityManager) randomString(n int) string {
 	const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
 	b := make([]byte, n)
 	for i := range b {
-		b[i] = letters[rand.Intn(len(letters))]
+		b[i] = letters[em.rng.Intn(len(letters))]
 	}
 	return string(b)
 }
@@ -0,0 +1,86 @@
+package game
+
+import (
+	"time"
+
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
+	"github.com/rs/zerolog/log"
+)
+
+// CatchOutcome is what a danger entity catching a player does to it, the
+// game mode decides
+type CatchOutcome string
+
+const (
+	CatchNone       CatchOutcome = ""           // Entities leave the player alone
+	CatchStun       CatchOutcome = "stun"       // The player cannot move for EntityStunMs
+	CatchEliminated CatchOutcome = "eliminated" // The player is out of the match
+)
+
+// resolveEntityCatchesLocked checks every player against the danger entities,
+// players and entities share tile units here. Entities only warn players in an
+// active safe zone. (called from the loop every EntityTickMs with worldLock held)
+func (w *World) resolveEntityCatchesLocked(now time.Time) {
+	for _, id := range w.sortedPlayerIdsLocked() {
+		player := w.Players[id]
+		if now.Before(w.entityGraceUntil[id]) {
+			continue
+		}
+		delete(w.entityGraceUntil, id)
+
+		entity := w.CheckEntityCollision(player.X, player.Y)
+		if entity == nil {
+			continue
+		}
+
+		if zone := w.GetCurrentZone(player.X, player.Y); zone != nil && zone.Type == ZoneSafe && zone.IsActive {
+			w.entityGraceUntil[id] = now.Add(EntityCatchGraceMs * time.Millisecond)
+			w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_EntityNear{EntityNear: &gamev1.EntityNear{
+				EntityId: entity.ID,
+				Warning:  true,
+				PlayerId: id,
+			}}})
+			continue
+		}
+
+		outcome := w.Rules.EntityCatch(player)
+		if outcome == CatchNone {
+			continue
+		}
+		w.entityGraceUntil[id] = now.Add(EntityCatchGraceMs * time.Millisecond)
+
+		collision := &gamev1.EntityCollision{
+			EntityId:   entity.ID,
+			EntityType: string(entity.Type),
+			Caught:     true,
+			PlayerId:   id,
+			Outcome:    string(outcome),
+		}
+		if outcome == CatchStun {
+			collision.StunMs = EntityStunMs
+		}
+		w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_EntityCollision{EntityCollision: collision}})
+		log.Info().Str("player", id).Str("entity", entity.ID).Str("outcome", string(outcome)).Msg("Player caught by entity")
+
+		switch outcome {
+		case CatchStun:
+			w.StunnedUntil[id] = now.Add(EntityStunMs * time.Millisecond)
+		case CatchEliminated:
+			w.eliminatePlayerLocked(id, entity.ID)
+		}
+	}
+}
+
+// isStunnedLocked reports whether a player cannot move yet, stuns that ran
+// out are forgotten (caller must hold worldLock)
+func (w *World) isStunnedLocked(playerId string, now time.Time) bool {
+	until, ok := w.StunnedUntil[playerId]
+	if !ok {
+		return false
+	}
+	if now.Before(until) {
+		return true
+	}
+	delete(w.StunnedUntil, playerId)
+	return false
+}
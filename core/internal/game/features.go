@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
 	"github.com/frank2889/mazechase/pkg"
 	"github.com/rs/zerolog/log"
 )
@@ -96,7 +97,7 @@ func ChatMessageHandler(chatManager *ChatManager, manager *Manager) MessageHandl
 	name := "chat"
 	return MessageHandler{
 		messageName: name,
-		handler: func(data MessageData) map[string]interface{} {
+		handler: func(data MessageData) *gamev1.Envelope {
 			message, ok := data.msgInfo["message"].(string)
 			if !ok {
 				log.Warn().Msg("Invalid chat message format")
@@ -113,13 +114,12 @@ func ChatMessageHandler(chatManager *ChatManager, manager *Manager) MessageHandl
 				return nil
 			}
 
-			return map[string]interface{}{
-				"type":      name,
-				"playerId":  chatMsg.PlayerID,
-				"username":  chatMsg.Username,
-				"message":   chatMsg.Message,
-				"timestamp": chatMsg.Timestamp.Unix(),
-			}
+			return &gamev1.Envelope{Payload: &gamev1.Envelope_Chat{Chat: &gamev1.Chat{
+				PlayerId:  chatMsg.PlayerID,
+				Username:  chatMsg.Username,
+				Message:   chatMsg.Message,
+				Timestamp: chatMsg.Timestamp.Unix(),
+			}}}
 		},
 	}
 }
@@ -249,6 +249,7 @@ type PlayerGameStats struct {
 	PelletsEaten int
 	ChasersEaten  int
 	PowerUpsUsed int
+	PlayersEliminated int
 }
 
 // NewWorldWithStats creates a world with stats tracking
@@ -13,48 +13,80 @@ const (
 	TickRateSec    = 0.016 // Seconds per tick
 )
 
-// Map dimensions (28x31 standard Pac-Man maze)
+// Game mechanics
 const (
-	MazeWidth  = 28
-	MazeHeight = 31
+	PowerUpDurationSec = 8                                  // Seconds
+	PowerUpDuration    = PowerUpDurationSec * time.Second   // As time.Duration
+	CollisionRadius    = 20                                 // Pixels - collision detection radius
 )
 
-// Spawn positions (tile coordinates)
-var SpawnPositions = map[SpriteType]TilePoint{
-	Runner:  {X: 14, Y: 23}, // Bottom center
-	Chaser1: {X: 12, Y: 11}, // Ghost box left
-	Chaser2: {X: 14, Y: 11}, // Ghost box center
-	Chaser3: {X: 16, Y: 11}, // Ghost box right
-}
+// Chaser house
+const (
+	ChaserEyesSpeed         = 2 * PlayerSpeed // Pixels per second an eaten chaser travels back to the house
+	ChaserRespawnMs         = 3000            // Milliseconds an eaten chaser stays in the house
+	ChaserReleaseIntervalMs = 2000            // Milliseconds between two chasers leaving the house
+)
 
-// Power-up locations (tile coordinates) - 4 corners
-var PowerUpPositions = []TilePoint{
-	{X: 1, Y: 3},   // Top-left
-	{X: 26, Y: 3},  // Top-right
-	{X: 1, Y: 23},  // Bottom-left
-	{X: 26, Y: 23}, // Bottom-right
-}
+// Lives and levels (classic)
+const (
+	RunnerLives           = 3    // Default lives of the runner
+	RunnerLivesMax        = 9    // Most lives a host can pick
+	CatchFreezeMs         = 1500 // Milliseconds everyone stands still before respawning
+	LevelCount            = 3    // Levels the runner clears to win
+	LevelChaserSpeedStep  = 0.1  // Factor added to the chasers' speed per level
+	LevelPowerUpStepSec   = 2    // Seconds a power-up gets shorter per level
+	PowerUpMinDurationSec = 2    // Shortest a power-up gets
+)
 
-// Game mechanics
+// Round timer
 const (
-	PowerUpDurationSec = 8                                  // Seconds
-	PowerUpDuration    = PowerUpDurationSec * time.Second   // As time.Duration
-	TotalPelletsMax    = 201                                // Maximum pellets on standard map
-	CollisionRadius    = 20                                 // Pixels - collision detection radius
+	RoundDurationSec       = 180                            // Default seconds per round, every mode
+	RoundDuration          = RoundDurationSec * time.Second // As time.Duration
+	RoundDurationMinSec    = 60                             // Shortest round a host can pick
+	RoundDurationMaxSec    = 600                            // Longest round a host can pick, 0 plays without a timer
+	TimerBroadcastMs       = 1000                           // Milliseconds between timer broadcasts
+	SuddenDeathSec         = 30                             // Seconds at the end of a round that are sudden death, when enabled
+	SuddenDeathSpeedFactor = 1.5                            // Factor on player, bot and entity speeds in sudden death
+)
+
+// Simulation
+const (
+	SimDefaultMaxDurationSec = 600                                    // Virtual seconds before a simulated game times out
+	SimDefaultMaxDuration    = SimDefaultMaxDurationSec * time.Second // As time.Duration
+)
+
+// Reconnect
+const (
+	ReconnectGraceSec = 30                              // Seconds a dropped player is held
+	ReconnectGrace    = ReconnectGraceSec * time.Second // As time.Duration
 )
 
 // Scoring
 const (
-	PelletScore   = 10
-	PowerUpScore  = 50
-	ChaserScore   = 100
-	WinBonusScore = 500
+	PelletScore    = 10
+	PowerUpScore   = 50
+	ChaserScore    = 100
+	EliminateScore = 200
+	WinBonusScore  = 500
 )
 
 // Bot behavior
 const (
 	BotMoveIntervalMs = 200  // Milliseconds between bot moves
 	BotFillDelayS     = 10   // Seconds before auto-filling with bots
+	BotScatterSec     = 7    // Seconds chasers scatter to their corner per cycle
+	BotChaseSec       = 20   // Seconds chasers chase per cycle
+)
+
+// Bot difficulty, reaction time is how long a bot chases a stale target and
+// accuracy the chance a chase move follows the shortest path
+const (
+	BotReactionEasyMs   = 800
+	BotReactionNormalMs = 400
+	BotReactionHardMs   = 100
+	BotAccuracyEasy     = 0.5
+	BotAccuracyNormal   = 0.8
+	BotAccuracyHard     = 1.0
 )
 
 // Entity system (dynamic world)
@@ -66,12 +98,39 @@ const (
 	ScannerConeAngle    = 60.0 // Degrees
 	ScannerRange        = 8    // Tiles
 	SweeperSpeed        = 2.0  // Tiles per second
+	WanderSpeedFactor   = 0.5  // Factor on an entity's speed while it wanders the corridors
+	ScanSpeedFactor     = 0.25 // Factor on a scanner's speed while it scans
+	EntityCatchRadius   = 0.5  // Tiles between an entity and a player it catches
+	EntityStunMs        = 2000 // Milliseconds a player caught by an entity cannot move
+	EntityCatchGraceMs  = 3000 // Milliseconds after a catch in which entities leave a player alone
+)
+
+// Maze updates (dynamic world)
+const (
+	MazeUpdateAnimationMs = 500                                   // Milliseconds a wall takes to rise or sink
+	MazeUpdateLifetimeSec = 20                                    // Seconds before a maze update is reverted
+	MazeUpdateLifetime    = MazeUpdateLifetimeSec * time.Second   // As time.Duration
+	MazeUpdateAttempts    = 10                                    // Candidate tiles tried per update
+)
+
+// Snapshots
+const (
+	InterestRadiusTiles = 10 // Tiles around a player within which it is sent danger entities
+	SnapshotHistory     = 64 // Entity updates kept per session to delta against
+)
+
+// Anti-cheat
+const (
+	MoveSpeedSlack       = 1.5  // Factor on PlayerSpeed a client-reported move may reach
+	MoveMaxGapMs         = 500  // Milliseconds of movement a client-reported move may catch up on
+	ClaimRadiusTiles     = 1    // Tiles between a player and a pellet or power-up it claims
+	SuspicionThreshold   = 10   // Violations before a player is flagged as suspicious
 )
 
 // TilePoint represents a 2D tile coordinate (integers)
 type TilePoint struct {
-	X int
-	Y int
+	X int `json:"x"`
+	Y int `json:"y"`
 }
 
 // PointF represents a 2D coordinate with floats (pixels)
@@ -102,16 +161,3 @@ func Distance(x1, y1, x2, y2 float64) float64 {
 func CollisionCheck(x1, y1, x2, y2 float64) bool {
 	return Distance(x1, y1, x2, y2) < float64(CollisionRadius*CollisionRadius)
 }
-
-// getSpawnPositionsPixels returns spawn positions in pixel coordinates
-func getSpawnPositionsPixels() map[string]map[string]float64 {
-	result := make(map[string]map[string]float64)
-	for spriteType, tilePos := range SpawnPositions {
-		x, y := TileToPixel(tilePos.X, tilePos.Y)
-		result[string(spriteType)] = map[string]float64{
-			"x": x,
-			"y": y,
-		}
-	}
-	return result
-}
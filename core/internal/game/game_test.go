@@ -1,8 +1,19 @@
 package game
 
 import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
+
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
+	"github.com/olahol/melody"
+	"google.golang.org/protobuf/proto"
 )
 
 // Rate Limiter Tests
@@ -23,17 +34,25 @@ func TestRateLimiter_Allow(t *testing.T) {
 }
 
 func TestRateLimiter_Refill(t *testing.T) {
-	rl := NewRateLimiter(5, 10) // 10 tokens per second
+	clock := NewManualClock(time.Unix(0, 0))
+	rl := NewRateLimiterWithClock(5, 10, clock) // 10 tokens per second
 
 	// Exhaust tokens
 	for i := 0; i < 5; i++ {
 		rl.Allow()
 	}
+	if rl.Allow() {
+		t.Error("Expected request to be blocked without tokens")
+	}
 
-	// Wait for refill
-	time.Sleep(200 * time.Millisecond)
+	// Still blocked before the refill
+	clock.Advance(50 * time.Millisecond)
+	if rl.Allow() {
+		t.Error("Expected request to be blocked during the block period")
+	}
 
 	// Should have refilled some tokens
+	clock.Advance(150 * time.Millisecond)
 	if !rl.Allow() {
 		t.Error("Expected tokens to refill after waiting")
 	}
@@ -208,18 +227,38 @@ func TestWorld_EatPellet(t *testing.T) {
 
 func TestWorld_EatPowerUp(t *testing.T) {
 	world := NewWorldState()
+	clock := NewManualClock(time.Unix(0, 0))
+	world.SetClock(clock)
 
 	world.EatPowerUp(100, 200)
 	if !world.IsPoweredUp {
 		t.Error("Expected world to be powered up after eating power up")
 	}
+	if !world.PowerUpEndTime.Equal(clock.Now().Add(PowerUpDuration)) {
+		t.Errorf("Expected power-up to last %v, ends after %v", PowerUpDuration, world.PowerUpEndTime.Sub(clock.Now()))
+	}
 
-	// Eating another should not change state
+	// Eating another should not change state, only extend the power-up
+	clock.Advance(time.Second)
 	world.EatPowerUp(200, 300)
 	powerUps := world.PowerUpsCoordsEaten.GetList()
 	if len(powerUps) != 1 {
 		t.Errorf("Expected 1 power up (second should be ignored), got %d", len(powerUps))
 	}
+	if !world.PowerUpEndTime.Equal(clock.Now().Add(PowerUpDuration)) {
+		t.Error("Expected second power up to extend the power-up")
+	}
+
+	clock.Advance(PowerUpDuration - time.Millisecond)
+	world.Step(clock.Now())
+	if !world.IsPoweredUp {
+		t.Error("Expected power-up to last until its end time")
+	}
+	clock.Advance(time.Millisecond)
+	world.Step(clock.Now())
+	if world.IsPoweredUp {
+		t.Error("Expected power-up to end at its end time")
+	}
 }
 
 func TestWorld_ChaserEatenAction(t *testing.T) {
@@ -250,114 +289,2191 @@ func TestWorld_IsLobbyFull(t *testing.T) {
 	}
 }
 
-// Player Entity Tests
-func TestPlayerEntity_ToJSON(t *testing.T) {
-	player := NewPlayerEntity(1, "TestPlayer")
-	player.SpriteType = Runner
-	player.X = 100
-	player.Y = 200
+// World Loop Tests
+func TestWorld_StepAppliesOneInputPerTick(t *testing.T) {
+	world := NewWorldState()
 
-	jsonBytes, err := player.ToJSON()
-	if err != nil {
-		t.Errorf("ToJSON failed: %v", err)
+	runner := NewPlayerEntity(1, "Runner")
+	world.Join(runner, nil)
+	startX := runner.X
+
+	world.QueueInput(PlayerInput{PlayerId: runner.PlayerId, Dir: "left"})
+	world.QueueInput(PlayerInput{PlayerId: runner.PlayerId, Dir: "left"})
+
+	snapshot := world.Step(time.Now())
+	if snapshot == nil || len(snapshot.Messages) != 1 {
+		t.Fatalf("Expected one pos event in snapshot, got %+v", snapshot)
+	}
+	if MessageType(snapshot.Messages[0]) != "pos" {
+		t.Errorf("Expected pos event, got %v", MessageType(snapshot.Messages[0]))
+	}
+	if runner.X != startX-PlayerSpeed*TickRateSec {
+		t.Errorf("Expected runner to move one step, moved from %v to %v", startX, runner.X)
 	}
 
-	if len(jsonBytes) == 0 {
-		t.Error("Expected non-empty JSON")
+	// Nothing queued, nothing happens
+	if snapshot := world.Step(time.Now()); snapshot != nil {
+		t.Errorf("Expected quiet tick, got %+v", snapshot)
 	}
 }
 
-func TestPlayerEntity_ToMap(t *testing.T) {
-	player := NewPlayerEntity(1, "TestPlayer")
-	player.SpriteType = Chaser1
-	player.X = 100
-	player.Y = 200
-	player.Dir = "left"
+func TestWorld_StepEndsPowerUp(t *testing.T) {
+	world := NewWorldState()
 
-	m := player.ToMap()
+	world.EatPowerUp(1, 3)
+	world.Step(time.Now())
+	if !world.IsPoweredUp {
+		t.Fatal("Expected power-up to still be active")
+	}
 
-	if m["user"] != "TestPlayer" {
-		t.Error("Expected username in map")
+	snapshot := world.Step(time.Now().Add(PowerUpDuration))
+	if world.IsPoweredUp {
+		t.Error("Expected power-up to end after its duration")
 	}
-	if m["spriteType"] != Chaser1 {
-		t.Error("Expected sprite type in map")
+	if snapshot == nil || MessageType(snapshot.Messages[len(snapshot.Messages)-1]) != "powend" {
+		t.Errorf("Expected powend event, got %+v", snapshot)
 	}
-	if m["x"] != float64(100) {
-		t.Error("Expected x coordinate in map")
+}
+
+func TestWorld_StepRunnerCaught(t *testing.T) {
+	world := NewWorldState()
+	world.SetLives(1)
+
+	runner := NewPlayerEntity(1, "Runner")
+	chaser := NewPlayerEntity(2, "Chaser")
+	world.Join(runner, nil)
+	world.Join(chaser, nil)
+	world.MovePlayer(chaser, runner.X, runner.Y)
+
+	world.Step(time.Now())
+
+	select {
+	case info := <-world.gameOverChan:
+		if info.Winner != "Chasers" {
+			t.Errorf("Expected chasers to win, got %s", info.Winner)
+		}
+	default:
+		t.Fatal("Expected game over after runner was caught")
+	}
+
+	if snapshot := world.Step(time.Now()); snapshot != nil {
+		t.Error("Expected no further ticks after game over")
 	}
 }
 
-// Coordinate List Tests
-func TestCoordList_Add(t *testing.T) {
-	cl := NewCordList()
+func TestWorld_RunnerLosesLivesAndRespawns(t *testing.T) {
+	world := NewWorldState()
+	runner := NewPlayerEntity(1, "Runner")
+	chaser := NewPlayerEntity(2, "Chaser")
+	world.Join(runner, nil)
+	world.Join(chaser, nil)
 
-	cl.Add(10, 20)
-	cl.Add(30, 40)
-	cl.Add(10, 20) // Duplicate
+	if world.SetLives(0) == nil || world.SetLives(RunnerLivesMax+1) == nil {
+		t.Error("Expected lives outside 1 to RunnerLivesMax to be refused")
+	}
+	if err := world.SetLives(2); err != nil {
+		t.Fatalf("Unable to set lives: %v", err)
+	}
+	now := time.Now()
+	world.StartMatch(now)
+	if world.SetLives(3) == nil {
+		t.Error("Expected lives to be refused once the match started")
+	}
 
-	list := cl.GetList()
-	if len(list) != 2 {
-		t.Errorf("Expected 2 unique coordinates, got %d", len(list))
+	world.MovePlayer(runner, runner.X+TileSizeFloat, runner.Y)
+	world.MovePlayer(chaser, runner.X, runner.Y)
+	snapshot := world.Step(now)
+	if len(world.gameOverChan) != 0 || world.Lives != 1 {
+		t.Fatalf("Expected the runner to lose a life, %d left", world.Lives)
+	}
+	var lifeLost *gamev1.LifeLost
+	for _, message := range snapshot.GetMessages() {
+		if message.GetLifelost() != nil {
+			lifeLost = message.GetLifelost()
+		}
+	}
+	if lifeLost == nil || lifeLost.Lives != 1 || lifeLost.FreezeMs != CatchFreezeMs {
+		t.Errorf("Expected a lifelost with one life left, got %v", lifeLost)
+	}
+
+	// Everyone stands still until the freeze ends, then respawns
+	frozenX := runner.X
+	world.QueueInput(PlayerInput{PlayerId: runner.PlayerId, Dir: "left"})
+	world.Step(now.Add(CatchFreezeMs*time.Millisecond - time.Millisecond))
+	if runner.X != frozenX {
+		t.Error("Expected a frozen runner to ignore input")
+	}
+	snapshot = world.Step(now.Add(CatchFreezeMs * time.Millisecond))
+	for _, player := range []*PlayerEntity{runner, chaser} {
+		spawn := world.Map.Spawn(player.SpriteType)
+		if x, y := TileToPixel(spawn.X, spawn.Y); player.X != x || player.Y != y {
+			t.Errorf("Expected %s to respawn at %v,%v, got %v,%v", player.SpriteType, x, y, player.X, player.Y)
+		}
+	}
+	if snapshot == nil || len(snapshot.GetMessages()) == 0 || snapshot.GetMessages()[len(snapshot.GetMessages())-1].GetRespawn() == nil {
+		t.Errorf("Expected a respawn event, got %+v", snapshot)
+	}
+
+	// The last life ends the match
+	world.MovePlayer(chaser, runner.X, runner.Y)
+	world.Step(now.Add(2 * CatchFreezeMs * time.Millisecond))
+	select {
+	case info := <-world.gameOverChan:
+		if info.Winner != "Chasers" || world.Lives != 0 {
+			t.Errorf("Expected the chasers to win with no lives left, got %s and %d lives", info.Winner, world.Lives)
+		}
+	default:
+		t.Fatal("Expected game over once the runner has no lives left")
 	}
 }
 
-func TestCoordList_Len(t *testing.T) {
-	cl := NewCordList()
+func TestLivesMessage_RejectsOnlyToSender(t *testing.T) {
+	world := NewWorldState()
+	host := NewPlayerEntity(1, "Alice")
+	guest := NewPlayerEntity(2, "Bob")
+	world.Join(host, nil)
+	world.Join(guest, nil)
+	host.IsHost = true
+
+	for _, data := range []MessageData{
+		{msgInfo: map[string]interface{}{"lives": 2.0}, world: world, playerSession: guest, session: &melody.Session{}},
+		{msgInfo: map[string]interface{}{"lives": 2.5}, world: world, playerSession: host, session: &melody.Session{}},
+		{msgInfo: map[string]interface{}{"lives": float64(RunnerLivesMax + 1)}, world: world, playerSession: host, session: &melody.Session{}},
+	} {
+		if msg := LivesMessage().handler(data); msg != nil {
+			t.Errorf("Expected rejected lives not to be broadcast, got %v", msg)
+		}
+	}
+	if world.Lives != RunnerLives {
+		t.Errorf("Expected the lives to stay at %d, got %d", RunnerLives, world.Lives)
+	}
+}
 
-	if cl.Len() != 0 {
-		t.Error("Expected empty list")
+func TestWorld_ClearedMazeAdvancesLevel(t *testing.T) {
+	world := NewWorldState()
+	runner := NewPlayerEntity(1, "Runner")
+	chaser := NewPlayerEntity(2, "Chaser")
+	world.Join(runner, nil)
+	world.Join(chaser, nil)
+
+	clearMaze := func() {
+		for y, row := range world.Map.Tiles {
+			for x, tile := range row {
+				if tile == mapPellet {
+					world.MazeData.EatPellet(x, y)
+					world.EatPellet(float64(x), float64(y))
+				}
+			}
+		}
 	}
 
-	cl.Add(10, 20)
-	if cl.Len() != 1 {
-		t.Error("Expected length 1")
+	clearMaze()
+	world.Step(time.Now())
+	if len(world.gameOverChan) != 0 || world.Level != 2 {
+		t.Fatalf("Expected a cleared maze to start level 2, got level %d", world.Level)
+	}
+	if world.PelletsCoordEaten.Len() != 0 || world.MazeData.GetPelletCount() != world.TotalPellets {
+		t.Error("Expected the pellets to come back in the next level")
+	}
+	if world.playerSpeedFactorLocked(chaser) != 1+LevelChaserSpeedStep || world.playerSpeedFactorLocked(runner) != 1 {
+		t.Error("Expected only the chasers to get faster")
+	}
+	if world.powerUpDurationLocked() != PowerUpDuration-LevelPowerUpStepSec*time.Second {
+		t.Errorf("Expected shorter power-ups, got %v", world.powerUpDurationLocked())
+	}
+	if level, lives := world.Progress(); level != 2 || lives != RunnerLives {
+		t.Errorf("Expected level 2 with %d lives in the progress, got %d and %d", RunnerLives, level, lives)
+	}
+
+	world.Level = LevelCount
+	clearMaze()
+	world.Step(time.Now())
+	select {
+	case info := <-world.gameOverChan:
+		if info.Winner != "Runner" {
+			t.Errorf("Expected the runner to win after the last level, got %s", info.Winner)
+		}
+	default:
+		t.Fatal("Expected game over after the last level")
 	}
 }
 
-// Message Batcher Tests
-func TestMessageBatcher_Add(t *testing.T) {
-	batcher := NewMessageBatcher(100*time.Millisecond, 5)
-	defer batcher.Stop()
+func TestWorld_GameStateReportShowsProgress(t *testing.T) {
+	world := NewWorldState()
+	runner := NewPlayerEntity(1, "Runner")
+	world.Join(runner, nil)
+	world.Join(NewPlayerEntity(2, "Chaser"), nil)
+	world.SetLives(RunnerLivesMax)
+	now := time.Now()
+	world.StartMatch(now)
+
+	session := &melody.Session{}
+	session.Set(userInfoKey, runner)
+
+	// Level and lives change in the loop while a player resumes
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < LevelCount-1; i++ {
+			world.worldLock.Lock()
+			world.loseLifeLocked(now)
+			world.advanceLevelLocked(now)
+			world.worldLock.Unlock()
+			world.Step(now)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		world.GetGameStateReport("", runner.Username, string(runner.SpriteType), session)
+	}
+	<-done
 
-	msg := map[string]interface{}{"type": "test"}
-	batcher.Add(msg)
+	state := world.GetGameStateReport("", runner.Username, string(runner.SpriteType), session).GetState()
+	if state.Level != LevelCount || state.Lives != int32(RunnerLivesMax-(LevelCount-1)) {
+		t.Errorf("Expected level %d with %d lives, got level %d with %d lives", LevelCount, RunnerLivesMax-(LevelCount-1), state.Level, state.Lives)
+	}
+}
 
-	// Force flush
-	batcher.Flush()
+func TestWorld_EatenChaserReturnsThroughHouse(t *testing.T) {
+	world := NewWorldState()
+	clock := NewManualClock(time.Unix(0, 0))
+	world.SetClock(clock)
+
+	runner := NewPlayerEntity(1, "Runner")
+	chaser := NewPlayerEntity(2, "Chaser")
+	world.Join(runner, nil)
+	world.Join(chaser, nil)
+	world.SetLives(1)
+	world.StartMatch(clock.Now())
+
+	world.EatPowerUp(1, 3)
+	if !world.isFrightenedLocked(chaser.SpriteType) {
+		t.Fatal("Expected the power-up to frighten the chaser")
+	}
+	world.MovePlayer(chaser, runner.X, runner.Y)
+	world.Step(clock.Now())
+	if world.chaserPhaseLocked(chaser.SpriteType) != ChaserEyes || !world.isChaserEatenLocked(chaser.SpriteType) {
+		t.Fatalf("Expected the eaten chaser to become eyes, got %v", world.chaserPhaseLocked(chaser.SpriteType))
+	}
+	for _, result := range world.MatchResults("") {
+		if result.Username == runner.Username && result.ChasersEaten != 1 {
+			t.Errorf("Expected the runner's record to count the eaten chaser, got %+v", result)
+		}
+		if result.Username == chaser.Username && result.ChasersEaten != 0 {
+			t.Errorf("Expected the chaser's record to count no chasers eaten, got %+v", result)
+		}
+	}
 
+	// Eyes ignore input and a new power-up, they head for the house
+	world.EatPowerUp(26, 3)
+	for i := 0; i < 1000 && world.chaserPhaseLocked(chaser.SpriteType) == ChaserEyes; i++ {
+		world.QueueInput(PlayerInput{PlayerId: chaser.PlayerId, Dir: "down"})
+		clock.Advance(TickRateMs * time.Millisecond)
+		world.Step(clock.Now())
+	}
+	house := world.Map.House(chaser.SpriteType)
+	if x, y := PixelToTile(chaser.X, chaser.Y); world.chaserPhaseLocked(chaser.SpriteType) != ChaserHouse || x != house.X || y != house.Y {
+		t.Fatalf("Expected the eyes to reach the house at %v, got %v at %d,%d", house, world.chaserPhaseLocked(chaser.SpriteType), x, y)
+	}
+
+	clock.Advance(ChaserRespawnMs * time.Millisecond)
+	world.Step(clock.Now())
+	if world.chaserPhaseLocked(chaser.SpriteType) != ChaserActive || world.isChaserEatenLocked(chaser.SpriteType) {
+		t.Fatalf("Expected the chaser to be released, got %v", world.chaserPhaseLocked(chaser.SpriteType))
+	}
+
+	// A released chaser is not frightened by the power-up that is still running
+	if !world.IsPoweredUp {
+		t.Fatal("Expected the power-up to outlast the trip through the house")
+	}
+	world.MovePlayer(chaser, runner.X, runner.Y)
+	world.Step(clock.Now())
 	select {
-	case data := <-batcher.FlushChan():
-		if len(data) == 0 {
-			t.Error("Expected non-empty batch data")
+	case info := <-world.gameOverChan:
+		if info.Winner != "Chasers" {
+			t.Errorf("Expected the released chaser to catch the runner, got %s", info.Winner)
 		}
-	case <-time.After(200 * time.Millisecond):
-		t.Error("Expected to receive flushed batch")
+	default:
+		t.Fatal("Expected the released chaser to catch the powered runner")
 	}
 }
 
-// Delta Encoder Tests
-func TestDeltaEncoder_ShouldUpdate(t *testing.T) {
-	de := NewDeltaEncoder(5.0) // 5 pixel threshold
+func TestWorld_HouseReleasesOneChaserAtATime(t *testing.T) {
+	world := NewWorldState()
+	for i := 1; i <= 3; i++ {
+		world.Join(NewPlayerEntity(uint(i), "Player"), nil)
+	}
 
-	// First update should always trigger
-	if !de.ShouldUpdate("player1", 100, 100, "up") {
-		t.Error("First update should always trigger")
+	now := time.Now()
+	for _, sprite := range []SpriteType{Chaser1, Chaser2} {
+		world.ChaserEatenAction(sprite)
+		status := world.chaserStatusLocked(sprite)
+		status.Phase = ChaserHouse
+		status.ReleaseAt = now
 	}
 
-	// Small movement should not trigger
-	if de.ShouldUpdate("player1", 102, 102, "up") {
-		t.Error("Small movement should not trigger update")
+	world.Step(now)
+	if world.chaserPhaseLocked(Chaser1) != ChaserActive || world.chaserPhaseLocked(Chaser2) != ChaserHouse {
+		t.Fatalf("Expected only the first chaser to leave, got %v and %v", world.chaserPhaseLocked(Chaser1), world.chaserPhaseLocked(Chaser2))
+	}
+	world.Step(now.Add(ChaserReleaseIntervalMs*time.Millisecond - time.Millisecond))
+	if world.chaserPhaseLocked(Chaser2) != ChaserHouse {
+		t.Error("Expected the second chaser to wait for the release interval")
 	}
+	world.Step(now.Add(ChaserReleaseIntervalMs * time.Millisecond))
+	if world.chaserPhaseLocked(Chaser2) != ChaserActive || len(world.ChasersIdsEaten) != 0 {
+		t.Errorf("Expected the second chaser to leave, got %v", world.chaserPhaseLocked(Chaser2))
+	}
+}
 
-	// Large movement should trigger
-	if !de.ShouldUpdate("player1", 200, 200, "up") {
-		t.Error("Large movement should trigger update")
+func TestWorld_GameStateReportDuringMatch(t *testing.T) {
+	world := NewWorldState()
+	runner := NewPlayerEntity(1, "Runner")
+	chaser := NewPlayerEntity(2, "Chaser")
+	world.Join(runner, nil)
+	world.Join(chaser, nil)
+	now := time.Now()
+	world.StartMatch(now)
+
+	session := &melody.Session{}
+	session.Set(userInfoKey, runner)
+
+	// The loop keeps frightening and resetting chasers while a player joins
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			world.worldLock.Lock()
+			world.frightenChasersLocked(now)
+			world.resetChasersLocked()
+			world.worldLock.Unlock()
+			world.Step(now)
+		}
+	}()
+	for i := 0; i < 200; i++ {
+		world.GetGameStateReport("", runner.Username, string(runner.SpriteType), session)
 	}
+	<-done
 
-	// Direction change should trigger
-	de.UpdatePosition("player1", 200, 200, "up")
-	if !de.ShouldUpdate("player1", 200, 200, "down") {
-		t.Error("Direction change should trigger update")
+	world.EatPowerUp(1, 3)
+	state := world.GetGameStateReport("", runner.Username, string(runner.SpriteType), session).GetState()
+	if state.Chasers[string(chaser.SpriteType)] != string(ChaserFrightened) {
+		t.Errorf("Expected the report to show the frightened chaser, got %v", state.Chasers)
+	}
+}
+
+// Game Mode Tests
+func TestRace_PlayersPassThroughEachOther(t *testing.T) {
+	world := NewWorldStateForMode(ModeRace)
+
+	p1 := NewPlayerEntity(1, "Alice")
+	p2 := NewPlayerEntity(2, "Bob")
+	world.Join(p1, nil)
+	world.Join(p2, nil)
+	world.MovePlayer(p2, p1.X, p1.Y)
+
+	world.Step(time.Now())
+
+	select {
+	case info := <-world.gameOverChan:
+		t.Errorf("Expected no game over in race mode, got %s", info.Reason)
+	default:
+	}
+}
+
+func TestRace_TimerHighestScoreWins(t *testing.T) {
+	world := NewWorldStateForMode(ModeRace)
+
+	p1 := NewPlayerEntity(1, "Alice")
+	p2 := NewPlayerEntity(2, "Bob")
+	world.Join(p1, nil)
+	world.Join(p2, nil)
+
+	start := time.Now()
+	world.StartMatch(start)
+	world.Scores[p1.PlayerId] = 30
+	world.Scores[p2.PlayerId] = 50
+
+	world.Step(start.Add(RoundDuration - time.Second))
+	if len(world.gameOverChan) != 0 {
+		t.Fatal("Expected race to continue before the round timer ends")
+	}
+
+	world.Step(start.Add(RoundDuration))
+
+	select {
+	case info := <-world.gameOverChan:
+		if info.Winner != p2.PlayerId {
+			t.Errorf("Expected Bob to win, got %s", info.Winner)
+		}
+	default:
+		t.Fatal("Expected game over when the round timer ends")
+	}
+}
+
+func TestRace_TopScorerWhoLeftStillWins(t *testing.T) {
+	world := NewWorldStateForMode(ModeRace)
+	p1 := NewPlayerEntity(1, "Alice")
+	p2 := NewPlayerEntity(2, "Bob")
+	world.Join(p1, nil)
+	world.Join(p2, nil)
+
+	start := time.Now()
+	world.StartMatch(start)
+	world.Scores[p1.PlayerId] = 30
+	world.Scores[p2.PlayerId] = 50
+	world.Leave(p2)
+
+	world.Step(start.Add(RoundDuration))
+	info := <-world.gameOverChan
+	for _, result := range world.MatchResults(info.Winner) {
+		if result.Won != (result.Username == "Bob") {
+			t.Errorf("Expected only Bob to win after leaving, got %+v", result)
+		}
+	}
+}
+
+func TestRace_PowerUpDoesNotPower(t *testing.T) {
+	world := NewWorldStateForMode(ModeRace)
+	player := NewPlayerEntity(1, "Alice")
+	world.Join(player, nil)
+	now := time.Now()
+	world.StartMatch(now)
+
+	// Step down onto the power-up at 1,3
+	world.MovePlayer(player, TileSizeFloat*1.5, TileSizeFloat*3-1)
+	world.worldLock.Lock()
+	event, moved := world.stepPlayerLocked(player, "down", PlayerSpeed*TickRateSec, now)
+	world.worldLock.Unlock()
+	if !moved || event.GetPos().GetPowerUp() == nil {
+		t.Fatalf("Expected the move to eat the power-up, got %v", event)
+	}
+	if event.GetPos().Powered != nil {
+		t.Error("Expected a power-up in a race not to power the player")
+	}
+}
+
+func TestWorld_RoundTimerTimeUpPerMode(t *testing.T) {
+	world := NewWorldStateForMode(ModeClassic)
+	world.Join(NewPlayerEntity(1, "Alice"), nil)
+	start := time.Now()
+	world.StartMatch(start)
+
+	world.Step(start.Add(RoundDuration - time.Second))
+	if len(world.gameOverChan) != 0 {
+		t.Fatal("Expected the match to continue before the round timer ends")
+	}
+	world.Step(start.Add(RoundDuration))
+	select {
+	case info := <-world.gameOverChan:
+		if info.Winner != "Chasers" {
+			t.Errorf("Expected the chasers to win when time is up, got %s", info.Winner)
+		}
+	default:
+		t.Fatal("Expected game over when the round timer ends")
+	}
+
+	// In a battle the best scoring player still standing wins
+	battle := NewWorldStateForMode(ModeBattle)
+	p1, p2, p3 := NewPlayerEntity(1, "Alice"), NewPlayerEntity(2, "Bob"), NewPlayerEntity(3, "Carol")
+	battle.Join(p1, nil)
+	battle.Join(p2, nil)
+	battle.Join(p3, nil)
+	battle.StartMatch(start)
+	battle.Scores[p1.PlayerId] = 10
+	battle.Scores[p2.PlayerId] = 50
+	battle.Scores[p3.PlayerId] = 20
+	battle.eliminatePlayerLocked(p2.PlayerId, p3.PlayerId)
+
+	battle.Step(start.Add(RoundDuration))
+	select {
+	case info := <-battle.gameOverChan:
+		if info.Winner != p3.PlayerId {
+			t.Errorf("Expected Carol to win when time is up, got %s", info.Winner)
+		}
+	default:
+		t.Fatal("Expected game over when the battle round timer ends")
+	}
+}
+
+func TestWorld_SetRoundSettings(t *testing.T) {
+	world := NewWorldState()
+
+	if err := world.SetRoundSettings(RoundDurationMinSec-1, false); err == nil {
+		t.Error("Expected a too short round to be refused")
+	}
+	if err := world.SetRoundSettings(0, true); err != nil || world.RoundDuration != 0 || world.SuddenDeathEnabled {
+		t.Errorf("Expected a match without timer nor sudden death, got %v %v (%v)", world.RoundDuration, world.SuddenDeathEnabled, err)
+	}
+
+	start := time.Now()
+	world.StartMatch(start)
+	world.Step(start.Add(time.Hour))
+	if len(world.gameOverChan) != 0 {
+		t.Error("Expected no time-up without a round timer")
+	}
+	if err := world.SetRoundSettings(RoundDurationMinSec, false); err == nil {
+		t.Error("Expected round settings to be refused once the match started")
+	}
+}
+
+func TestRoundSettingsMessage_RejectsOnlyToSender(t *testing.T) {
+	world := NewWorldState()
+	host := NewPlayerEntity(1, "Alice")
+	guest := NewPlayerEntity(2, "Bob")
+	world.Join(host, nil)
+	world.Join(guest, nil)
+	host.IsHost = true
+
+	for _, data := range []MessageData{
+		{msgInfo: map[string]interface{}{"durationSec": 180.0}, world: world, playerSession: guest, session: &melody.Session{}},
+		{msgInfo: map[string]interface{}{"durationSec": 1.5}, world: world, playerSession: host, session: &melody.Session{}},
+		{msgInfo: map[string]interface{}{"durationSec": 1.0}, world: world, playerSession: host, session: &melody.Session{}},
+	} {
+		if msg := RoundSettingsMessage().handler(data); msg != nil {
+			t.Errorf("Expected rejected round settings not to be broadcast, got %v", msg)
+		}
+	}
+}
+
+func TestWorld_SuddenDeath(t *testing.T) {
+	world := NewWorldState()
+	runner := NewPlayerEntity(1, "Alice")
+	world.Join(runner, nil)
+	if err := world.SetRoundSettings(RoundDurationMinSec, true); err != nil {
+		t.Fatalf("Unable to enable sudden death: %v", err)
+	}
+	powerUps := world.MazeData.GetPowerUpCount()
+
+	start := time.Now()
+	world.StartMatch(start)
+	var timers int
+	for i := 0; i < 70; i++ {
+		for _, message := range world.Step(start).GetMessages() {
+			if message.GetTimer() != nil {
+				timers++
+			}
+		}
+	}
+	if timers != 1 {
+		t.Errorf("Expected one timer broadcast per second, got %d", timers)
+	}
+
+	snapshot := world.Step(start.Add((RoundDurationMinSec - SuddenDeathSec) * time.Second))
+	var suddenDeath *gamev1.SuddenDeath
+	var timer *gamev1.Timer
+	for _, message := range snapshot.GetMessages() {
+		if message.GetSuddendeath() != nil {
+			suddenDeath = message.GetSuddendeath()
+		}
+		if message.GetTimer() != nil {
+			timer = message.GetTimer()
+		}
+	}
+	if suddenDeath == nil || len(suddenDeath.PowerUps) != powerUps {
+		t.Fatalf("Expected sudden death to clear %d power-ups, got %v", powerUps, suddenDeath)
+	}
+	if timer == nil || !timer.SuddenDeath || timer.RemainingMs != SuddenDeathSec*1000 {
+		t.Errorf("Expected a sudden death timer, got %v", timer)
+	}
+	if world.MazeData.GetPowerUpCount() != 0 || world.speedFactorLocked() != SuddenDeathSpeedFactor || world.EntityManager.speedFactor != SuddenDeathSpeedFactor {
+		t.Error("Expected no power-ups left and everyone faster")
+	}
+}
+
+func TestRace_KillClaimIgnored(t *testing.T) {
+	world := NewWorldStateForMode(ModeRace)
+
+	msg := KillPlayer().handler(MessageData{
+		msgInfo: map[string]interface{}{"id": string(Chaser1)},
+		world:   world,
+	})
+	if msg != nil {
+		t.Errorf("Expected kill claim to be ignored, got %v", msg)
+	}
+	if len(world.gameOverChan) != 0 {
+		t.Error("Expected no game over from a kill claim in race mode")
+	}
+}
+
+func TestBattle_PoweredPlayerEliminates(t *testing.T) {
+	world := NewWorldStateForMode(ModeBattle)
+
+	p1 := NewPlayerEntity(1, "Alice")
+	p2 := NewPlayerEntity(2, "Bob")
+	world.Join(p1, nil)
+	world.Join(p2, nil)
+
+	now := time.Now()
+	world.StartMatch(now)
+	world.PlayerEatPowerUp(p1, 1, 3)
+	world.MovePlayer(p2, p1.X, p1.Y)
+
+	world.Step(now)
+
+	if _, alive := world.Players[p2.PlayerId]; alive {
+		t.Error("Expected Bob to be eliminated")
+	}
+	if !p2.IsSpectator {
+		t.Error("Expected eliminated player to become a spectator")
+	}
+
+	select {
+	case info := <-world.gameOverChan:
+		if info.Winner != p1.PlayerId {
+			t.Errorf("Expected Alice to win, got %s", info.Winner)
+		}
+	default:
+		t.Fatal("Expected game over with one player left")
+	}
+}
+
+func TestBattle_TopScorerWhoLeftStillWins(t *testing.T) {
+	world := NewWorldStateForMode(ModeBattle)
+	p1 := NewPlayerEntity(1, "Alice")
+	p2 := NewPlayerEntity(2, "Bob")
+	p3 := NewPlayerEntity(3, "Carol")
+	world.Join(p1, nil)
+	world.Join(p2, nil)
+	world.Join(p3, nil)
+
+	now := time.Now()
+	world.StartMatch(now)
+	world.Scores[p1.PlayerId] = 10
+	world.Scores[p2.PlayerId] = 20
+	world.Scores[p3.PlayerId] = world.TotalPellets * PelletScore
+
+	// Carol disconnects just before the last pellet decides the match
+	world.Leave(p3)
+	for i := 0; i < world.TotalPellets; i++ {
+		world.PelletsCoordEaten.Add(float64(i), 0)
+	}
+	world.Step(now)
+
+	select {
+	case info := <-world.gameOverChan:
+		if info.Winner != p3.PlayerId {
+			t.Fatalf("Expected Carol to win with the top score, got %s", info.Winner)
+		}
+		for _, result := range world.MatchResults(info.Winner) {
+			if result.Won != (result.Username == "Carol") {
+				t.Errorf("Expected only Carol to win after disconnecting, got %+v", result)
+			}
+		}
+	default:
+		t.Fatal("Expected game over once every pellet is eaten")
+	}
+}
+
+func TestBattle_PowerUpIsPerPlayer(t *testing.T) {
+	world := NewWorldStateForMode(ModeBattle)
+
+	p1 := NewPlayerEntity(1, "Alice")
+	p2 := NewPlayerEntity(2, "Bob")
+	world.Join(p1, nil)
+	world.Join(p2, nil)
+
+	now := time.Now()
+	world.PlayerEatPowerUp(p1, 1, 3)
+	world.PlayerEatPowerUp(p2, 26, 3)
+	world.MovePlayer(p2, p1.X, p1.Y)
+
+	world.Step(now)
+	if len(world.Players) != 2 {
+		t.Error("Expected powered players to bounce off each other")
+	}
+	if world.IsPoweredUp {
+		t.Error("Expected battle power-ups to leave the global flag alone")
+	}
+
+	world.Step(time.Now().Add(PowerUpDuration))
+	if len(world.PoweredUntil) != 0 {
+		t.Error("Expected per-player power-ups to expire")
+	}
+}
+
+func TestWorld_MatchResults(t *testing.T) {
+	world := NewWorldState()
+
+	chaser := NewPlayerEntity(1, "Alice")
+	runner := NewPlayerEntity(2, "Bob")
+	world.Join(runner, nil)
+	world.Join(chaser, nil)
+	world.StartMatch(time.Now())
+
+	// Runner steps onto the pellet right of the spawn, then gets caught
+	world.MovePlayer(runner, 15*TileSizeFloat-1, runner.Y)
+	world.QueueInput(PlayerInput{PlayerId: runner.PlayerId, Dir: "right"})
+	world.Step(time.Now())
+	world.MovePlayer(chaser, runner.X, runner.Y)
+	world.Step(time.Now())
+
+	// Leaving after the start keeps the player in the record
+	world.Leave(chaser)
+
+	results := world.MatchResults("Chasers")
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	for _, result := range results {
+		switch result.Username {
+		case "Alice":
+			if !result.Won || result.PlayersEliminated != 1 {
+				t.Errorf("Expected Alice to win with one elimination, got %+v", result)
+			}
+		case "Bob":
+			if result.Won || result.PelletsCollected != 1 || result.Score != PelletScore {
+				t.Errorf("Expected Bob to lose with one pellet, got %+v", result)
+			}
+		}
+	}
+}
+
+// Reconnect Tests
+func TestWorld_DisconnectHoldsPlayer(t *testing.T) {
+	world := NewWorldState()
+
+	player := NewPlayerEntity(1, "Alice")
+	world.Join(player, nil)
+	world.MovePlayer(player, 100, 100)
+	world.addScore(player.PlayerId, 30)
+	sprites := len(world.CharactersList)
+
+	if !world.HoldDisconnected(player, nil, time.Now()) {
+		t.Fatal("Expected a dropped player to be held")
+	}
+	if len(world.CharactersList) != sprites {
+		t.Error("Expected the sprite to stay taken while held")
+	}
+	if !world.IsDisconnected(player.PlayerId) {
+		t.Error("Expected player to be marked disconnected")
+	}
+
+	if _, err := world.Resume(player.PlayerId, "wrong", nil); err == nil {
+		t.Error("Expected resume with a wrong token to fail")
+	}
+
+	resumed, err := world.Resume(player.PlayerId, player.ResumeToken(), nil)
+	if err != nil {
+		t.Fatalf("Expected resume to succeed: %v", err)
+	}
+	if resumed != player || resumed.SpriteType != Runner {
+		t.Error("Expected the same player and sprite back")
+	}
+	if resumed.X != 100 || resumed.Y != 100 || world.GetScore(player.PlayerId) != 30 {
+		t.Error("Expected position and score to survive the reconnect")
+	}
+	if world.IsDisconnected(player.PlayerId) {
+		t.Error("Expected player to be connected again")
+	}
+}
+
+func TestWorld_DisconnectGraceExpires(t *testing.T) {
+	world := NewWorldState()
+
+	p1 := NewPlayerEntity(1, "Alice")
+	p2 := NewPlayerEntity(2, "Bob")
+	world.Join(p1, nil)
+	world.Join(p2, nil)
+	sprites := len(world.CharactersList)
+
+	now := time.Now()
+	world.HoldDisconnected(p1, nil, now)
+
+	world.Step(now.Add(ReconnectGrace - time.Second))
+	if !world.IsDisconnected(p1.PlayerId) {
+		t.Error("Expected player to be held within the grace period")
+	}
+
+	world.Step(now.Add(ReconnectGrace))
+	if world.IsDisconnected(p1.PlayerId) || len(world.CharactersList) != sprites+1 {
+		t.Error("Expected the sprite to be freed once the grace period ran out")
+	}
+	if _, err := world.Resume(p1.PlayerId, p1.ResumeToken(), nil); err == nil {
+		t.Error("Expected resume after the grace period to fail")
+	}
+}
+
+// Maze Map Tests
+func TestMazeMap_Load(t *testing.T) {
+	classic := DefaultMazeMap()
+	if classic.Width != 28 || classic.Height != 31 {
+		t.Errorf("Expected classic map to be 28x31, got %dx%d", classic.Width, classic.Height)
+	}
+	if classic.TotalPellets != 240 || len(classic.PowerUps) != 4 {
+		t.Errorf("Expected 240 pellets and 4 power-ups, got %d and %d", classic.TotalPellets, len(classic.PowerUps))
+	}
+
+	if _, err := GetMazeMap("compact"); err != nil {
+		t.Errorf("Expected compact map to load: %v", err)
+	}
+	if _, err := GetMazeMap("nope"); err == nil {
+		t.Error("Expected unknown map to fail")
+	}
+
+	cutOff := `{"name": "broken", "tiles": ["#####", "#. #.", "#####"],
+		"spawns": {"runner": {"x": 1, "y": 1}, "ch0": {"x": 2, "y": 1}, "ch1": {"x": 2, "y": 1}, "ch2": {"x": 2, "y": 1}}}`
+	if _, err := LoadMazeMap([]byte(cutOff)); err == nil {
+		t.Error("Expected a map with an unreachable pellet to fail")
+	}
+}
+
+func TestGenerateMazeMap(t *testing.T) {
+	first, err := GenerateMazeMap(42, MazeGenOptions{Mirror: true})
+	if err != nil {
+		t.Fatalf("Expected maze to generate: %v", err)
+	}
+	again, _ := GenerateMazeMap(42, MazeGenOptions{Mirror: true})
+	if strings.Join(first.Tiles, "\n") != strings.Join(again.Tiles, "\n") {
+		t.Error("Expected the same seed to give the same maze")
+	}
+
+	for y, row := range first.Tiles {
+		for x := 0; x < first.Width/2; x++ {
+			if row[x] != row[first.Width-1-x] {
+				t.Fatalf("Expected mirrored maze, tile %d,%d differs", x, y)
+			}
+		}
+	}
+	if len(first.PowerUps) != 4 || first.TotalPellets == 0 || first.Seed != 42 {
+		t.Errorf("Expected pellets, 4 power-ups and seed 42, got %d, %d and %d", first.TotalPellets, len(first.PowerUps), first.Seed)
+	}
+
+	for seed := int64(1); seed <= 20; seed++ {
+		for _, mirror := range []bool{true, false} {
+			m, err := GenerateMazeMap(seed, MazeGenOptions{Mirror: mirror})
+			if err != nil {
+				t.Fatalf("Expected seed %d to generate: %v", seed, err)
+			}
+			if err := verifyReachable(m); err != nil {
+				t.Errorf("Expected seed %d to be fully reachable: %v", seed, err)
+			}
+		}
+	}
+
+	other, _ := GenerateMazeMap(43, MazeGenOptions{Mirror: true})
+	if strings.Join(first.Tiles, "\n") == strings.Join(other.Tiles, "\n") {
+		t.Error("Expected different seeds to give different mazes")
+	}
+}
+
+func TestWorld_UsesMapSpawnsAndPellets(t *testing.T) {
+	compact, _ := GetMazeMap("compact")
+	world := NewWorldStateWithMap(ModeClassic, compact)
+
+	player := NewPlayerEntity(1, "Alice")
+	world.Join(player, nil)
+
+	x, y := TileToPixel(compact.Spawns[Runner].X, compact.Spawns[Runner].Y)
+	if player.X != x || player.Y != y {
+		t.Error("Expected runner to spawn at the map's runner spawn")
+	}
+	if world.TotalPellets != compact.TotalPellets {
+		t.Error("Expected pellet total to come from the map")
+	}
+}
+
+func TestWorld_TunnelWrapsPlayer(t *testing.T) {
+	world := NewWorldState()
+
+	player := NewPlayerEntity(1, "Alice")
+	world.Join(player, nil)
+	_, tunnelY := TileToPixel(0, 14)
+	world.MovePlayer(player, 1, tunnelY)
+
+	world.QueueInput(PlayerInput{PlayerId: player.PlayerId, Dir: "left"})
+	world.Step(time.Now())
+
+	exitX, _ := TileToPixel(27, 14)
+	if player.X != exitX || player.Y != tunnelY {
+		t.Errorf("Expected player to come out at the other tunnel end, got %v,%v", player.X, player.Y)
+	}
+}
+
+func testLoopMap(t *testing.T) *MazeMap {
+	// Two loops joined by the single tile at 4,3
+	m, err := LoadMazeMap([]byte(`{"name": "loops", "tiles": [
+		"#########",
+		"#...#...#",
+		"#.#.#.#.#",
+		"#.......#",
+		"#########"],
+		"spawns": {"runner": {"x": 1, "y": 1}, "ch0": {"x": 5, "y": 1}, "ch1": {"x": 7, "y": 1}, "ch2": {"x": 7, "y": 3}}}`))
+	if err != nil {
+		t.Fatalf("Expected test map to load: %v", err)
+	}
+	return m
+}
+
+func TestWorld_MazeUpdateChangesCollision(t *testing.T) {
+	world := NewWorldStateWithMap(ModeClassic, testLoopMap(t))
+
+	if world.applyMazeUpdateLocked(MazeUpdate{Type: "wall_add", X: 2, Y: 3}) {
+		t.Error("Expected a wall on a pellet to be refused")
+	}
+
+	world.MazeData.EatPellet(2, 3)
+	if !world.applyMazeUpdateLocked(MazeUpdate{Type: "wall_add", X: 2, Y: 3}) {
+		t.Fatal("Expected wall to be added")
+	}
+	if !world.MazeData.IsWall(2, 3) || world.PathGrid.GetNode(2, 3).Walkable || world.EntityManager.mazeData[3][2] != 1 {
+		t.Error("Expected the wall in MazeData, the path grid and the entity layout")
+	}
+
+	world.DynamicWorld.SetMazeUpdateFunc(world.applyMazeUpdateLocked)
+	now := time.Now()
+	world.DynamicWorld.MazeUpdates = []MazeUpdate{{Type: "wall_add", X: 2, Y: 3, revertAt: now}}
+	world.DynamicWorld.revertMazeUpdates(now)
+	if world.MazeData.IsWall(2, 3) || !world.PathGrid.GetNode(2, 3).Walkable || len(world.DynamicWorld.MazeUpdates) != 0 {
+		t.Error("Expected the wall to be removed when its lifetime is over")
+	}
+}
+
+func TestWorld_MazeUpdateNeverTrapsOrSeals(t *testing.T) {
+	world := NewWorldStateWithMap(ModeClassic, testLoopMap(t))
+
+	world.MazeData.EatPellet(4, 3)
+	if world.applyMazeUpdateLocked(MazeUpdate{Type: "wall_add", X: 4, Y: 3}) {
+		t.Error("Expected a wall splitting the maze to be refused")
+	}
+
+	player := NewPlayerEntity(1, "Alice")
+	world.Join(player, nil)
+	world.MazeData.EatPellet(2, 3)
+	x, y := TileToPixel(2, 3)
+	world.MovePlayer(player, x, y)
+	if world.applyMazeUpdateLocked(MazeUpdate{Type: "wall_add", X: 2, Y: 3}) {
+		t.Error("Expected a wall on a player to be refused")
+	}
+
+	if world.applyMazeUpdateLocked(MazeUpdate{Type: "wall_remove", X: 4, Y: 0}) {
+		t.Error("Expected the border to stay")
+	}
+}
+
+// Entity Tests
+
+// testEntityManager is an entity manager on the loop map with one zone
+// covering all of it
+func testEntityManager(t *testing.T, zoneType ZoneType) *EntityManager {
+	mazeMap := testLoopMap(t)
+	dynamicWorld := NewDynamicWorld(mazeMap.Width, mazeMap.Height)
+	dynamicWorld.Zones = []Zone{{ID: 0, Type: zoneType, Width: mazeMap.Width, Height: mazeMap.Height, IsActive: true}}
+
+	em := NewEntityManager(mazeMap.Width, mazeMap.Height, dynamicWorld)
+	em.SetRand(rand.New(rand.NewSource(1)))
+	em.SetMazeData(mazeMap.WalkGrid())
+	em.SpawnInitialEntities()
+	return em
+}
+
+// onCorridor reports whether an entity stands on a walkable tile, on the line
+// between two tile centers
+func onCorridor(em *EntityManager, entity *DangerEntity) bool {
+	tile := entityTile(entity)
+	onAxis := entity.X-math.Floor(entity.X) == 0.5 || entity.Y-math.Floor(entity.Y) == 0.5
+	return em.walkable(tile.X, tile.Y) && onAxis
+}
+
+func TestEntityManager_HunterPathsAroundWalls(t *testing.T) {
+	em := testEntityManager(t, ZoneDanger)
+
+	var hunter *DangerEntity
+	for _, entity := range em.Entities {
+		if entity.Type == EntityHunter {
+			hunter = entity
+		}
+	}
+	if hunter == nil {
+		t.Fatal("Expected a hunter in the danger zone")
+	}
+	if !onCorridor(em, hunter) {
+		t.Fatalf("Expected hunter to spawn in a corridor, got %v,%v", hunter.X, hunter.Y)
+	}
+
+	// The wall at 4,1 and 4,2 splits the top, the way round is through 4,3
+	em.placeOnTile(hunter, TilePoint{X: 3, Y: 1})
+	for i := 0; i < 200 && entityTile(hunter) != (TilePoint{X: 5, Y: 1}); i++ {
+		em.moveToward(hunter, 5.5, 1.5, hunter.Speed)
+		if !onCorridor(em, hunter) {
+			t.Fatalf("Expected hunter to stay in the corridors, got %v,%v", hunter.X, hunter.Y)
+		}
+	}
+	if entityTile(hunter) != (TilePoint{X: 5, Y: 1}) {
+		t.Errorf("Expected hunter to reach its target around the wall, got %v,%v", hunter.X, hunter.Y)
+	}
+}
+
+func TestEntityManager_SweeperPatrolsCorridorLoop(t *testing.T) {
+	em := testEntityManager(t, ZoneNeutral)
+
+	var sweeper *DangerEntity
+	for _, entity := range em.Entities {
+		sweeper = entity
+	}
+	if sweeper == nil || sweeper.Type != EntitySweeper {
+		t.Fatal("Expected a sweeper in the neutral zone")
+	}
+
+	checkLoop := func() {
+		path := sweeper.PatrolPath
+		if len(path) < 2 {
+			t.Fatalf("Expected a patrol loop, got %v", path)
+		}
+		for i, point := range path {
+			tile, next := pointTile(point), pointTile(path[(i+1)%len(path)])
+			if !em.walkable(tile.X, tile.Y) {
+				t.Fatalf("Expected patrol loop to stay off walls, got %v", tile)
+			}
+			if abs(tile.X-next.X)+abs(tile.Y-next.Y) != 1 {
+				t.Fatalf("Expected patrol loop of adjacent tiles, got %v then %v", tile, next)
+			}
+		}
+	}
+	checkLoop()
+
+	for i := 0; i < 100; i++ {
+		em.update()
+		if !onCorridor(em, sweeper) {
+			t.Fatalf("Expected sweeper to stay in the corridors, got %v,%v", sweeper.X, sweeper.Y)
+		}
+	}
+
+	// A new wall on the loop makes the sweeper plan another one
+	wall := pointTile(sweeper.PatrolPath[len(sweeper.PatrolPath)/2])
+	em.SetWall(wall.X, wall.Y, true)
+	checkLoop()
+	if routeCrosses(sweeper.PatrolPath, wall) {
+		t.Errorf("Expected patrol loop around the new wall at %v", wall)
+	}
+	for i := 0; i < 100; i++ {
+		em.update()
+		if !onCorridor(em, sweeper) {
+			t.Fatalf("Expected sweeper to stay in the corridors after the wall, got %v,%v", sweeper.X, sweeper.Y)
+		}
+	}
+}
+
+// entityOn puts a hunter on the tile of a player
+func entityOn(world *World, id string, player *PlayerEntity) {
+	world.EntityManager.Entities[id] = &DangerEntity{ID: id, Type: EntityHunter, X: player.X / TileSizeFloat, Y: player.Y / TileSizeFloat}
+}
+
+// entityEvents returns the pending entity catches and warnings of a world
+func entityEvents(world *World) []*gamev1.Envelope {
+	var events []*gamev1.Envelope
+	for _, event := range world.pendingEvents {
+		if event.GetEntityCollision() != nil || event.GetEntityNear() != nil {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func TestWorld_EntityCatchStunsRunner(t *testing.T) {
+	world := NewWorldStateWithMap(ModeClassic, testLoopMap(t))
+	runner := NewPlayerEntity(1, "Alice")
+	chaser := NewPlayerEntity(2, "Bob")
+	world.Join(runner, nil)
+	world.Join(chaser, nil)
+	entityOn(world, "H-aaaa", runner)
+	entityOn(world, "H-bbbb", chaser)
+
+	now := time.Now()
+	world.resolveEntityCatchesLocked(now)
+	events := entityEvents(world)
+	if len(events) != 1 {
+		t.Fatalf("Expected only the runner to be caught, got %v", events)
+	}
+	collision := events[0].GetEntityCollision()
+	if collision == nil || collision.PlayerId != runner.PlayerId || collision.Outcome != string(CatchStun) || collision.StunMs != EntityStunMs {
+		t.Errorf("Expected the runner to be stunned, got %v", events[0])
+	}
+
+	startY := runner.Y
+	world.QueueInput(PlayerInput{PlayerId: runner.PlayerId, Dir: "down"})
+	world.Step(now)
+	if runner.Y != startY {
+		t.Error("Expected a stunned runner not to move")
+	}
+
+	world.pendingEvents = nil
+	world.resolveEntityCatchesLocked(now.Add(time.Second))
+	if events := entityEvents(world); len(events) != 0 {
+		t.Errorf("Expected entities to leave a caught runner alone, got %v", events)
+	}
+
+	world.QueueInput(PlayerInput{PlayerId: runner.PlayerId, Dir: "down"})
+	world.Step(now.Add(EntityStunMs * time.Millisecond))
+	if runner.Y == startY {
+		t.Error("Expected the runner to move once the stun ends")
+	}
+}
+
+func TestWorld_EntityCatchEliminatesInBattle(t *testing.T) {
+	world := NewWorldStateWithMap(ModeBattle, testLoopMap(t))
+	p1 := NewPlayerEntity(1, "Alice")
+	p2 := NewPlayerEntity(2, "Bob")
+	world.Join(p1, nil)
+	world.Join(p2, nil)
+	world.StartMatch(time.Now())
+	entityOn(world, "H-aaaa", p2)
+
+	world.resolveEntityCatchesLocked(time.Now())
+
+	if _, alive := world.Players[p2.PlayerId]; alive {
+		t.Error("Expected Bob to be eliminated by the entity")
+	}
+	if _, scored := world.Scores["H-aaaa"]; scored {
+		t.Error("Expected the entity not to score")
+	}
+	var collision *gamev1.EntityCollision
+	for _, event := range world.pendingEvents {
+		if event.GetEntityCollision() != nil {
+			collision = event.GetEntityCollision()
+		}
+		if eliminated := event.GetEliminated(); eliminated != nil && collision == nil {
+			t.Error("Expected the catch before the elimination")
+		}
+	}
+	if collision == nil || collision.Outcome != string(CatchEliminated) {
+		t.Errorf("Expected an elimination by the entity, got %v", collision)
+	}
+}
+
+func TestWorld_EntityWarnsInSafeZone(t *testing.T) {
+	world := NewWorldStateWithMap(ModeClassic, testLoopMap(t))
+	runner := NewPlayerEntity(1, "Alice")
+	world.Join(runner, nil)
+	world.DynamicWorld.Zones = []Zone{{ID: 0, Type: ZoneSafe, Width: 3, Height: 3, IsActive: true}}
+	entityOn(world, "H-aaaa", runner)
+
+	// Zones are in tiles, players in pixels
+	if zone := world.GetCurrentZone(runner.X, runner.Y); zone == nil {
+		t.Fatalf("Expected the runner at %v,%v in the safe zone", runner.X, runner.Y)
+	}
+
+	world.resolveEntityCatchesLocked(time.Now())
+	events := entityEvents(world)
+	if len(events) != 1 || events[0].GetEntityNear() == nil || events[0].GetEntityNear().PlayerId != runner.PlayerId {
+		t.Errorf("Expected a warning instead of a catch, got %v", events)
+	}
+	if len(world.StunnedUntil) != 0 {
+		t.Error("Expected no stun in a safe zone")
+	}
+}
+
+// Bot Tests
+func TestChaserAI_Moves(t *testing.T) {
+	grid := NewMazeDataFromMap(testLoopMap(t)).PathGrid()
+	ai := NewChaserAI(grid, DifficultyHard)
+
+	path := NewAStarPathfinder(grid).FindPath(1, 1, 7, 3)
+	if x, y := ai.GetNextMove(1, 1, 7, 3, BehaviorChase); x != int(path[1].X) || y != int(path[1].Y) {
+		t.Errorf("Expected hard chaser to follow the shortest path, got %d,%d", x, y)
+	}
+
+	if x, y := ai.GetNextMove(3, 3, 2, 3, BehaviorFrightened); abs(x-2)+abs(y-3) <= 1 {
+		t.Errorf("Expected frightened chaser to move away, got %d,%d", x, y)
+	}
+
+	ai.SetScatterTarget(7, 1)
+	for i := 0; i < 20; i++ {
+		x, y := ai.GetNextMove(1, 1, 1, 1, BehaviorScatter)
+		if grid.GetNode(x, y) == nil || !grid.GetNode(x, y).Walkable {
+			t.Fatalf("Expected scatter move onto a walkable tile, got %d,%d", x, y)
+		}
+	}
+}
+
+func TestParseDifficulty(t *testing.T) {
+	if level, err := ParseDifficulty("normal"); err != nil || level != DifficultyMedium {
+		t.Errorf("Expected normal to be the medium difficulty, got %v, %v", level, err)
+	}
+	if DifficultyEasy.ReactionTime() <= DifficultyHard.ReactionTime() || DifficultyEasy.PathAccuracy() >= DifficultyHard.PathAccuracy() {
+		t.Error("Expected easy bots to react slower and steer worse than hard bots")
+	}
+	if _, err := ParseDifficulty("nightmare"); err == nil {
+		t.Error("Expected unknown difficulty to fail")
+	}
+}
+
+func TestBotDifficultyMessage_RejectsOnlyToSender(t *testing.T) {
+	world := NewWorldState()
+	host := NewPlayerEntity(1, "Alice")
+	guest := NewPlayerEntity(2, "Bob")
+	world.Join(host, nil)
+	world.Join(guest, nil)
+	host.IsHost = true
+
+	for _, data := range []MessageData{
+		{msgInfo: map[string]interface{}{"difficulty": "hard"}, world: world, playerSession: guest, session: &melody.Session{}},
+		{msgInfo: map[string]interface{}{"difficulty": "impossible"}, world: world, playerSession: host, session: &melody.Session{}},
+	} {
+		if msg := BotDifficultyMessage().handler(data); msg != nil {
+			t.Errorf("Expected a rejected difficulty not to be broadcast, got %v", msg)
+		}
+	}
+	if msg := BotDifficultyMessage().handler(MessageData{msgInfo: map[string]interface{}{"difficulty": "hard"}, world: world, playerSession: host}); msg.GetLobbystatus() == nil {
+		t.Errorf("Expected the host's difficulty to broadcast the lobby status, got %v", msg)
+	}
+}
+
+func TestWorld_ChaserBehaviorCycles(t *testing.T) {
+	world := NewWorldState()
+	start := time.Now()
+	world.StartMatch(start)
+
+	if world.chaserBehaviorLocked(Chaser1, start.Add(time.Second), 0) != BehaviorScatter {
+		t.Error("Expected chasers to scatter at the start of a cycle")
+	}
+	if world.chaserBehaviorLocked(Chaser1, start.Add((BotScatterSec+1)*time.Second), 0) != BehaviorChase {
+		t.Error("Expected chasers to chase after scattering")
+	}
+	if world.chaserBehaviorLocked(Chaser1, start.Add(time.Second), 0.9) != BehaviorChase {
+		t.Error("Expected aggressive chasers to cut scattering short")
+	}
+
+	world.chaserStatusLocked(Chaser1).Phase = ChaserFrightened
+	if world.chaserBehaviorLocked(Chaser1, start.Add(time.Second), 0) != BehaviorFrightened {
+		t.Error("Expected frightened chasers to flee from the runner")
+	}
+	if world.chaserBehaviorLocked(Chaser2, start.Add(time.Second), 0) != BehaviorScatter {
+		t.Error("Expected chasers that are not frightened to keep scattering")
+	}
+}
+
+func TestBot_ChaserSteersToRunner(t *testing.T) {
+	world := NewWorldState()
+	world.BotManager = NewBotManager(world, func(*gamev1.Envelope) error { return nil })
+
+	runner := NewPlayerEntity(1, "Alice")
+	world.Join(runner, nil)
+	if err := world.SetBotDifficulty(DifficultyHard); err != nil {
+		t.Fatalf("Expected difficulty to change before the match: %v", err)
+	}
+	world.BotManager.FillWithBots()
+
+	runnerTile := world.Map.Spawn(Runner)
+	distance := func() int {
+		best := math.MaxInt
+		for _, bot := range world.BotManager.GetBots() {
+			x, y := PixelToTile(bot.PlayerEntity.X, bot.PlayerEntity.Y)
+			if path := NewAStarPathfinder(world.MazeData.PathGrid()).FindPath(x, y, runnerTile.X, runnerTile.Y); path != nil {
+				best = min(best, len(path))
+			}
+		}
+		return best
+	}
+
+	before := distance()
+	now := time.Now()
+	for i := 0; i < 1000 && !world.gameEnded; i++ {
+		now = now.Add(TickRateMs * time.Millisecond)
+		world.Step(now)
+	}
+	if after := distance(); after >= before && !world.gameEnded {
+		t.Errorf("Expected chaser bots to close in on the runner, path went from %d to %d", before, after)
+	}
+
+	world.StartMatch(now)
+	if err := world.SetBotDifficulty(DifficultyEasy); err == nil {
+		t.Error("Expected difficulty to be fixed once the match started")
+	}
+}
+
+func TestRunnerAI_Moves(t *testing.T) {
+	maze := NewMazeDataFromMap(testLoopMap(t))
+	ai := NewRunnerAI(maze.PathGrid(), DifficultyHard)
+
+	maze.EatPellet(2, 1)
+	maze.EatPellet(3, 1)
+	if x, y := ai.GetNextMove(1, 1, nil, false, maze); x != 1 || y != 2 {
+		t.Errorf("Expected runner to head for the nearest pellet, got %d,%d", x, y)
+	}
+
+	if x, y := ai.GetNextMove(1, 1, []TilePoint{{X: 2, Y: 1}}, false, maze); x != 1 || y != 2 {
+		t.Errorf("Expected runner to flee from a close chaser, got %d,%d", x, y)
+	}
+
+	if x, y := ai.GetNextMove(1, 1, []TilePoint{{X: 3, Y: 1}}, true, maze); x != 2 || y != 1 {
+		t.Errorf("Expected powered up runner to hunt the chaser, got %d,%d", x, y)
+	}
+
+	maze.PowerUps[maze.coordKey(1, 3)] = true
+	if x, y := ai.GetNextMove(1, 2, []TilePoint{{X: 3, Y: 2}}, false, maze); x != 1 || y != 3 {
+		t.Errorf("Expected cornered runner to go for the power-up, got %d,%d", x, y)
+	}
+}
+
+func TestBot_RunnerCollectsPellets(t *testing.T) {
+	world := NewWorldState()
+	world.BotManager = NewBotManager(world, func(*gamev1.Envelope) error { return nil })
+	world.SetBotDifficulty(DifficultyHard)
+	world.BotManager.FillWithBots()
+
+	now := time.Now()
+	for i := 0; i < 1000 && !world.gameEnded; i++ {
+		now = now.Add(TickRateMs * time.Millisecond)
+		world.Step(now)
+	}
+
+	if eaten := world.TotalPellets - world.MazeData.GetPelletCount(); eaten < 5 {
+		t.Errorf("Expected the runner bot to collect pellets, got %d", eaten)
+	}
+}
+
+// Player Entity Tests
+func TestPlayerEntity_ToJSON(t *testing.T) {
+	player := NewPlayerEntity(1, "TestPlayer")
+	player.SpriteType = Runner
+	player.X = 100
+	player.Y = 200
+
+	jsonBytes, err := player.ToJSON()
+	if err != nil {
+		t.Errorf("ToJSON failed: %v", err)
+	}
+
+	if len(jsonBytes) == 0 {
+		t.Error("Expected non-empty JSON")
+	}
+}
+
+func TestPlayerEntity_ToMap(t *testing.T) {
+	player := NewPlayerEntity(1, "TestPlayer")
+	player.SpriteType = Chaser1
+	player.X = 100
+	player.Y = 200
+	player.Dir = "left"
+
+	m := player.ToMap()
+
+	if m["user"] != "TestPlayer" {
+		t.Error("Expected username in map")
+	}
+	if m["spriteType"] != Chaser1 {
+		t.Error("Expected sprite type in map")
+	}
+	if m["x"] != float64(100) {
+		t.Error("Expected x coordinate in map")
+	}
+}
+
+// Coordinate List Tests
+func TestCoordList_Add(t *testing.T) {
+	cl := NewCordList()
+
+	cl.Add(10, 20)
+	cl.Add(30, 40)
+	cl.Add(10, 20) // Duplicate
+
+	list := cl.GetList()
+	if len(list) != 2 {
+		t.Errorf("Expected 2 unique coordinates, got %d", len(list))
+	}
+}
+
+func TestCoordList_Len(t *testing.T) {
+	cl := NewCordList()
+
+	if cl.Len() != 0 {
+		t.Error("Expected empty list")
+	}
+
+	cl.Add(10, 20)
+	if cl.Len() != 1 {
+		t.Error("Expected length 1")
+	}
+}
+
+// Message Batcher Tests
+func TestMessageBatcher_Add(t *testing.T) {
+	batcher := NewMessageBatcher(100*time.Millisecond, 5)
+	defer batcher.Stop()
+
+	msg := map[string]interface{}{"type": "test"}
+	batcher.Add(msg)
+
+	// Force flush
+	batcher.Flush()
+
+	select {
+	case data := <-batcher.FlushChan():
+		if len(data) == 0 {
+			t.Error("Expected non-empty batch data")
+		}
+	case <-time.After(200 * time.Millisecond):
+		t.Error("Expected to receive flushed batch")
+	}
+}
+
+// Delta Encoder Tests
+func TestDeltaEncoder_ShouldUpdate(t *testing.T) {
+	de := NewDeltaEncoder(5.0) // 5 pixel threshold
+
+	// First update should always trigger
+	if !de.ShouldUpdate("player1", 100, 100, "up") {
+		t.Error("First update should always trigger")
+	}
+
+	// Small movement should not trigger
+	if de.ShouldUpdate("player1", 102, 102, "up") {
+		t.Error("Small movement should not trigger update")
+	}
+
+	// Large movement should trigger
+	if !de.ShouldUpdate("player1", 200, 200, "up") {
+		t.Error("Large movement should trigger update")
+	}
+
+	// Direction change should trigger
+	de.UpdatePosition("player1", 200, 200, "up")
+	if !de.ShouldUpdate("player1", 200, 200, "down") {
+		t.Error("Direction change should trigger update")
+	}
+}
+
+// Clock and Seed Tests
+func TestManualClock(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := NewManualClock(start)
+	after := clock.After(time.Second)
+	ticker := clock.NewTicker(300 * time.Millisecond)
+	defer ticker.Stop()
+
+	clock.Advance(500 * time.Millisecond)
+	select {
+	case <-after:
+		t.Error("Expected timer not to fire before its time")
+	default:
+	}
+	if tick := <-ticker.C(); !tick.Equal(start.Add(300 * time.Millisecond)) {
+		t.Errorf("Expected tick at 300ms, got %v", tick.Sub(start))
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	if fired := <-after; !fired.Equal(start.Add(time.Second)) {
+		t.Errorf("Expected timer to fire at 1s, got %v", fired.Sub(start))
+	}
+	if !clock.Now().Equal(start.Add(time.Second)) {
+		t.Errorf("Expected clock at 1s, got %v", clock.Now().Sub(start))
+	}
+}
+
+func TestWorld_SeedReplaysMatch(t *testing.T) {
+	play := func(seed int64) string {
+		clock := NewManualClock(time.Unix(0, 0))
+		world := NewWorldState()
+		world.SetClock(clock)
+		world.SetSeed(seed)
+		world.BotManager = NewBotManager(world, func(*gamev1.Envelope) error { return nil })
+
+		runner := NewPlayerEntity(1, "Alice")
+		world.Join(runner, nil)
+		world.BotManager.FillWithBots()
+		world.StartMatch(clock.Now())
+		world.StartDynamicSystems()
+
+		var record strings.Builder
+		dirs := []string{"left", "up", "right", "down"}
+		for i := 0; i < 3000; i++ {
+			clock.Advance(TickRateMs * time.Millisecond)
+			world.QueueInput(PlayerInput{PlayerId: runner.PlayerId, Dir: dirs[i/40%len(dirs)]})
+			if snapshot := world.Step(clock.Now()); snapshot != nil {
+				msg, _ := proto.MarshalOptions{Deterministic: true}.Marshal(snapshot)
+				record.Write(msg)
+			}
+		}
+		return record.String()
+	}
+
+	first := play(42)
+	if first == "" {
+		t.Fatal("Expected the match to produce snapshots")
+	}
+	if play(42) != first {
+		t.Error("Expected the same seed and inputs to replay the match exactly")
+	}
+	if play(43) == first {
+		t.Error("Expected another seed to play out differently")
+	}
+}
+
+// Simulation Tests
+func TestSimulate(t *testing.T) {
+	profiles := []BotProfile{{StrategyPatrol, 0}, {StrategyChaser, 1}, {StrategyAmbush, 1}, {StrategyBlocker, 1}}
+	result, err := Simulate(SimConfig{
+		Map:         GeneratedMapName,
+		Mode:        ModeClassic,
+		Bots:        profiles,
+		Difficulty:  DifficultyHard,
+		Seed:        7,
+		Games:       4,
+		MaxDuration: 60 * time.Second,
+		Workers:     2,
+	})
+	if err != nil {
+		t.Fatalf("Expected simulation to run: %v", err)
+	}
+
+	if result.Games != 4 || result.Map != GeneratedMapName || result.Mode != ModeClassic {
+		t.Errorf("Expected summary of 4 classic games on a generated map, got %+v", result)
+	}
+	wins, rate := 0, 0.0
+	for _, outcome := range result.Outcomes {
+		wins += outcome.Wins
+		rate += outcome.WinRate
+		if outcome.AvgDurationSec <= 0 || outcome.AvgDurationSec > 60 {
+			t.Errorf("Expected games to end within the max duration, %s took %.1fs", outcome.Winner, outcome.AvgDurationSec)
+		}
+	}
+	if wins != 4 || math.Abs(rate-1) > 1e-9 {
+		t.Errorf("Expected outcomes to cover every game, got %d wins and rate %.2f", wins, rate)
+	}
+	if result.AvgPellets <= 0 {
+		t.Error("Expected the runner bot to eat pellets")
+	}
+
+	var csvOut strings.Builder
+	if err := result.WriteCSV(&csvOut); err != nil || !strings.HasPrefix(csvOut.String(), "winner,games,winRate") {
+		t.Errorf("Expected CSV with a header, got %q (%v)", csvOut.String(), err)
+	}
+
+	if _, err := Simulate(SimConfig{Map: "nowhere", Games: 1}); err == nil {
+		t.Error("Expected an unknown map to fail")
+	}
+}
+
+func TestSimulate_TimeoutIsNotADraw(t *testing.T) {
+	result := summarizeSimulation(SimConfig{Mode: ModeRace}, []SimGame{
+		{Winner: "Gelijkspel", Duration: time.Minute},
+		{Winner: SimTimeoutWinner, Duration: 10 * time.Minute, TimedOut: true},
+		{Winner: SimTimeoutWinner, Duration: 10 * time.Minute, TimedOut: true},
+	})
+	if len(result.Outcomes) != 2 {
+		t.Fatalf("Expected a draw and a timeout outcome, got %+v", result.Outcomes)
+	}
+	if timeout := result.Outcomes[0]; !timeout.TimedOut || timeout.Wins != 2 {
+		t.Errorf("Expected two timed out games, got %+v", timeout)
+	}
+	if draw := result.Outcomes[1]; draw.TimedOut || draw.Winner != "Gelijkspel" || draw.Wins != 1 {
+		t.Errorf("Expected one draw, got %+v", draw)
+	}
+}
+
+func TestSimGame_JSONDurationInSeconds(t *testing.T) {
+	data, err := json.Marshal(SimGame{Seed: 3, Winner: "Runner", Duration: 1500 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Unable to marshal a simulated game: %v", err)
+	}
+	var game map[string]interface{}
+	if err := json.Unmarshal(data, &game); err != nil {
+		t.Fatalf("Invalid simulated game: %v", err)
+	}
+	if game["duration_sec"] != 1.5 || game["winner"] != "Runner" || game["duration"] != nil {
+		t.Errorf("Expected the duration in seconds next to the other fields, got %s", data)
+	}
+}
+
+func TestParseBotStrategy(t *testing.T) {
+	for _, strategy := range []BotStrategy{StrategyChaser, StrategyAmbush, StrategyBlocker, StrategyPatrol} {
+		if parsed, err := ParseBotStrategy(strategy.String()); err != nil || parsed != strategy {
+			t.Errorf("Expected %s to parse back, got %v (%v)", strategy, parsed, err)
+		}
+	}
+	if _, err := ParseBotStrategy("camper"); err == nil {
+		t.Error("Expected unknown strategy to fail")
+	}
+}
+
+// Replay Tests
+func TestReplayRecorder_RoundTrip(t *testing.T) {
+	start := time.Unix(1000, 0)
+	recorder := NewReplayRecorder()
+
+	recorder.RecordMessage(start, []byte(`{"type":"lobbystatus"}`))
+	if recorder.Started() {
+		t.Error("Expected recorder to wait for the match start")
+	}
+
+	recorder.Start(start, []byte(`{"type":"state"}`))
+	recorder.RecordInput(start.Add(50*time.Millisecond), PlayerInput{PlayerId: "1", Dir: "left"})
+	recorder.RecordMessage(start.Add(120*time.Millisecond), []byte(`{"type":"snapshot"}`))
+
+	replay := recorder.Replay(ReplayHeader{MatchID: 7, Mode: ModeClassic, Seed: 42})
+	if len(replay.Frames) != 3 || replay.Header.DurationMs != 120 {
+		t.Fatalf("Expected 3 frames over 120ms, got %d over %dms", len(replay.Frames), replay.Header.DurationMs)
+	}
+
+	var buf strings.Builder
+	if _, err := replay.WriteTo(&buf); err != nil {
+		t.Fatalf("Unable to write replay: %v", err)
+	}
+	read, err := ReadReplay(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("Unable to read replay: %v", err)
+	}
+
+	if read.Header.MatchID != 7 || read.Header.Seed != 42 || read.Header.Version != ReplayVersion {
+		t.Errorf("Expected header to survive, got %+v", read.Header)
+	}
+	if len(read.Frames) != 3 || string(read.Frames[0].Message) != `{"type":"state"}` {
+		t.Fatalf("Expected the state message first, got %+v", read.Frames)
+	}
+	if read.Frames[1].Input == nil || read.Frames[1].Input.Dir != "left" || read.Frames[1].At != 50 {
+		t.Errorf("Expected the input at 50ms, got %+v", read.Frames[1])
+	}
+}
+
+func TestReplayPlayer_Seek(t *testing.T) {
+	replay := &Replay{
+		Header: ReplayHeader{DurationMs: 300},
+		Frames: []ReplayFrame{
+			{At: 0, Message: json.RawMessage(`"state"`)},
+			{At: 100, Message: json.RawMessage(`"a"`)},
+			{At: 150, Input: &PlayerInput{PlayerId: "1"}},
+			{At: 300, Message: json.RawMessage(`"b"`)},
+		},
+	}
+
+	var sent []string
+	player := newReplayPlayer(replay, func(msg []byte) error {
+		sent = append(sent, string(msg))
+		return nil
+	}, NewManualClock(time.Unix(0, 0)))
+
+	if err := player.seek(200); err != nil || strings.Join(sent, ",") != `"state","a"` || player.at != 200 {
+		t.Errorf("Expected seek forward to send state and a, got %v at %d (%v)", sent, player.at, err)
+	}
+
+	sent = nil
+	if err := player.seek(50); err != nil || strings.Join(sent, ",") != `"state"` || player.next != 1 {
+		t.Errorf("Expected seek back to start over from the state, got %v next %d (%v)", sent, player.next, err)
+	}
+
+	_ = player.apply(replayControl{Action: "pause"})
+	player.advance(time.Second)
+	if player.at != 50 {
+		t.Errorf("Expected a paused replay to stay at 50ms, got %d", player.at)
+	}
+
+	_ = player.apply(replayControl{Action: "play"})
+	player.advance(time.Second)
+	if player.at != 100 {
+		t.Errorf("Expected playback to stop at the next unsent frame, got %d", player.at)
+	}
+
+	_ = player.apply(replayControl{Action: "speed", Speed: 100})
+	if player.speed != replayMaxSpeed {
+		t.Errorf("Expected speed to be capped at %v, got %v", replayMaxSpeed, player.speed)
+	}
+}
+
+// Spectator Tests
+func TestRejectSpectatorMiddleware(t *testing.T) {
+	handled := 0
+	handler := RejectSpectatorMiddleware(func(data MessageData) *gamev1.Envelope {
+		handled++
+		return &gamev1.Envelope{Payload: &gamev1.Envelope_Pel{Pel: &gamev1.Pellet{}}}
+	})
+
+	spectator := NewPlayerEntity(2, "Bob")
+	spectator.IsSpectator = true
+	if msg := handler(MessageData{playerSession: spectator}); msg != nil || handled != 0 {
+		t.Errorf("Expected spectator message to be rejected, got %v", msg)
+	}
+
+	player := NewPlayerEntity(1, "Alice")
+	if msg := handler(MessageData{playerSession: player}); msg == nil || handled != 1 {
+		t.Error("Expected player message to be handled")
+	}
+}
+
+func TestWorld_SpectatorStateReport(t *testing.T) {
+	world := NewWorldState()
+	world.BotManager = NewBotManager(world, func(*gamev1.Envelope) error { return nil })
+
+	world.Join(NewPlayerEntity(1, "Alice"), nil)
+	world.BotManager.FillWithBots()
+
+	spectator := NewPlayerEntity(2, "Bob")
+	world.JoinAsSpectator(spectator, nil)
+	if spectator.IsSpectator != true || spectator.SpriteType != "" {
+		t.Errorf("Expected a spectator without sprite, got %+v", spectator)
+	}
+
+	report, err := MarshalEnvelopeJSON(world.SpectatorStateReport(spectator))
+	if err != nil {
+		t.Fatalf("Unable to build spectator state: %v", err)
+	}
+	var envelope struct {
+		Type    string                 `json:"type"`
+		Payload map[string]interface{} `json:"payload"`
+	}
+	if err := json.Unmarshal(report, &envelope); err != nil {
+		t.Fatalf("Invalid spectator state: %v", err)
+	}
+	state := envelope.Payload
+	if envelope.Type != "state" {
+		t.Errorf("Expected a state message, got %s", envelope.Type)
+	}
+
+	if state["isSpectator"] != true || state["playerId"] != spectator.PlayerId {
+		t.Errorf("Expected the state of spectator %s, got %v", spectator.PlayerId, state)
+	}
+	if players, _ := state["activePlayers"].(map[string]interface{}); len(players) != len(world.Rules.Sprites()) {
+		t.Errorf("Expected every player including bots, got %v", players)
+	}
+}
+
+// Anti-cheat Tests
+func TestWorld_AdmitSpectatorKeepsBots(t *testing.T) {
+	world := NewWorldState()
+	world.BotManager = NewBotManager(world, func(*gamev1.Envelope) error { return nil })
+	world.Join(NewPlayerEntity(1, "Alice"), nil)
+	world.BotManager.FillWithBots()
+	bots := world.BotManager.GetBotCount()
+	world.StartMatch(time.Now())
+
+	if err := world.Admit(true); err != nil {
+		t.Errorf("Expected a spectator to watch a running match: %v", err)
+	}
+	if err := world.Admit(false); err == nil {
+		t.Error("Expected a player to be refused once the match started")
+	}
+	if world.BotManager.GetBotCount() != bots {
+		t.Errorf("Expected the %d bots of the running match to stay, got %d", bots, world.BotManager.GetBotCount())
+	}
+}
+
+func TestWorld_AdmitSpectatorToFullLobby(t *testing.T) {
+	world := NewWorldState()
+	for i := range world.Rules.Sprites() {
+		world.Join(NewPlayerEntity(uint(i+1), "Player"), nil)
+	}
+	if !world.IsLobbyFull() {
+		t.Fatal("Expected the lobby to be full")
+	}
+
+	if err := world.Admit(true); err != nil {
+		t.Errorf("Expected a spectator to watch a full lobby: %v", err)
+	}
+	if err := world.Admit(false); err == nil {
+		t.Error("Expected a player to be refused by a full lobby")
+	}
+}
+
+func TestWorld_RejectsTeleport(t *testing.T) {
+	world := NewWorldState()
+	runner := NewPlayerEntity(1, "Alice")
+	world.Join(runner, nil)
+	startX, startY := runner.X, runner.Y
+
+	now := time.Now()
+	world.QueueInput(PlayerInput{PlayerId: runner.PlayerId, HasPos: true, X: startX + 5*TileSizeFloat, Y: startY})
+	world.Step(now)
+	if runner.X != startX || world.AntiCheat.Violations(runner.PlayerId) != 1 {
+		t.Errorf("Expected a teleport to be rejected, runner at %.0f with %d violations", runner.X, world.AntiCheat.Violations(runner.PlayerId))
+	}
+
+	world.QueueInput(PlayerInput{PlayerId: runner.PlayerId, HasPos: true, X: startX, Y: startY + 2})
+	world.Step(now.Add(TickRateMs * time.Millisecond))
+	if runner.Y != startY+2 {
+		t.Errorf("Expected a move within PlayerSpeed to be accepted, runner at %.0f", runner.Y)
+	}
+
+	world.QueueInput(PlayerInput{PlayerId: runner.PlayerId, HasPos: true, X: -10, Y: startY})
+	world.Step(now.Add(2 * TickRateMs * time.Millisecond))
+	if runner.X != startX || world.AntiCheat.Violations(runner.PlayerId) != 2 {
+		t.Error("Expected a position off the map to be rejected")
+	}
+}
+
+func TestWorld_ClaimsNeedServerAgreement(t *testing.T) {
+	world := NewWorldState()
+	world.SetLives(1)
+	runner := NewPlayerEntity(1, "Alice")
+	chaser := NewPlayerEntity(2, "Bob")
+	world.Join(runner, nil)
+	world.Join(chaser, nil)
+
+	if err := world.ClaimPellet(runner, 1, 1); err == nil {
+		t.Error("Expected a pellet far from the runner to be rejected")
+	}
+	if err := world.ClaimKill(runner, chaser.SpriteType); err == nil {
+		t.Error("Expected a kill claim between players far apart to be rejected")
+	}
+
+	// The server's own collision check decides, its radius is exclusive
+	for _, gap := range []float64{CollisionRadius, 2 * CollisionRadius} {
+		world.MovePlayer(chaser, runner.X+gap, runner.Y)
+		if err := world.ClaimKill(runner, chaser.SpriteType); err == nil {
+			t.Errorf("Expected a kill claim %v pixels apart to be rejected", gap)
+		}
+	}
+
+	world.MovePlayer(chaser, runner.X+CollisionRadius-1, runner.Y)
+	if err := world.ClaimKill(runner, chaser.SpriteType); err != nil {
+		t.Errorf("Expected a kill claim of touching players to be accepted: %v", err)
+	}
+	if len(world.gameOverChan) != 1 {
+		t.Error("Expected the accepted kill to end the game")
+	}
+}
+
+func TestWorld_KillClaimsRefusedWhileFrozenOrOver(t *testing.T) {
+	world := NewWorldState()
+	clock := NewManualClock(time.Unix(0, 0))
+	world.SetClock(clock)
+	runner := NewPlayerEntity(1, "Alice")
+	chaser := NewPlayerEntity(2, "Bob")
+	world.Join(runner, nil)
+	world.Join(chaser, nil)
+	world.SetLives(2)
+	world.StartMatch(clock.Now())
+
+	world.MovePlayer(chaser, runner.X, runner.Y)
+	if err := world.ClaimKill(chaser, chaser.SpriteType); err != nil || world.Lives != 1 {
+		t.Fatalf("Expected the first claim to cost a life, got %d lives (%v)", world.Lives, err)
+	}
+	if err := world.ClaimKill(chaser, chaser.SpriteType); err == nil || world.Lives != 1 {
+		t.Errorf("Expected a claim during the freeze to be refused, got %d lives", world.Lives)
+	}
+
+	// After the respawn the last life ends the game, later claims are refused
+	clock.Advance(CatchFreezeMs * time.Millisecond)
+	world.Step(clock.Now())
+	world.MovePlayer(chaser, runner.X, runner.Y)
+	if err := world.ClaimKill(chaser, chaser.SpriteType); err != nil || len(world.gameOverChan) != 1 {
+		t.Fatalf("Expected the claim on the last life to end the game: %v", err)
+	}
+	if err := world.ClaimKill(chaser, chaser.SpriteType); err == nil {
+		t.Error("Expected a claim after the game over to be refused")
+	}
+}
+
+func TestGetCoordFromMessage_InvalidTypes(t *testing.T) {
+	for _, msg := range []map[string]interface{}{
+		{"x": "12", "y": 3.0},
+		{"x": 1.0},
+		{"x": 1.0, "y": []interface{}{}},
+	} {
+		if _, _, err := getCoordFromMessage(msg); err == nil {
+			t.Errorf("Expected %v to be rejected", msg)
+		}
+	}
+	if x, y, err := getCoordFromMessage(map[string]interface{}{"x": 1.5, "y": 2.0}); err != nil || x != 1.5 || y != 2 {
+		t.Errorf("Expected 1.5,2 got %v,%v (%v)", x, y, err)
+	}
+}
+
+func TestAntiCheat_FlagsRepeatOffenders(t *testing.T) {
+	ac := NewAntiCheat()
+	for i := 1; i < SuspicionThreshold; i++ {
+		if ac.Report("1", ViolationTeleport, nil) {
+			t.Fatalf("Expected no flag after %d violations", i)
+		}
+	}
+	if !ac.Report("1", ViolationTeleport, nil) || !ac.Flagged("1") {
+		t.Error("Expected the player to be flagged at the threshold")
+	}
+	if ac.Flagged("2") {
+		t.Error("Expected other players to stay unflagged")
+	}
+}
+
+func TestAntiCheatMetrics_OnlyAntiCheat(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	antiCheatMetrics(recorder, httptest.NewRequest("GET", "/api/metrics", nil))
+
+	var metrics map[string]json.RawMessage
+	if err := json.Unmarshal(recorder.Body.Bytes(), &metrics); err != nil {
+		t.Fatalf("Invalid metrics: %v", err)
+	}
+	if len(metrics) != 2 || metrics["game_cheat_violations"] == nil || metrics["game_flagged_players"] == nil {
+		t.Errorf("Expected only the anti-cheat counters, got %s", recorder.Body.String())
+	}
+}
+
+// Protocol Tests
+func TestCheckProtocol(t *testing.T) {
+	if err := checkProtocol(url.Values{"v": {strconv.Itoa(ProtocolVersion)}}); err != nil {
+		t.Errorf("Expected version %d to be accepted: %v", ProtocolVersion, err)
+	}
+
+	// Version 2 clients still report entity collisions themselves
+	for _, v := range []string{"", "1", "2", "4", "abc"} {
+		if err := checkProtocol(url.Values{"v": {v}}); err == nil {
+			t.Errorf("Expected protocol version %q to be rejected", v)
+		}
+	}
+}
+
+func TestEnvelopeJSON_RoundTrip(t *testing.T) {
+	world := NewWorldState()
+	runner := NewPlayerEntity(1, "Alice")
+	world.Join(runner, nil)
+
+	snapshot := &gamev1.Envelope{Payload: &gamev1.Envelope_Snapshot{Snapshot: &gamev1.Snapshot{
+		Tick: 7,
+		Messages: []*gamev1.Envelope{
+			{Payload: &gamev1.Envelope_Pos{Pos: runner.ToProto()}},
+			{Payload: &gamev1.Envelope_Powend{Powend: &gamev1.PowerUpEnd{}}},
+		},
+	}}}
+
+	first, err := world.marshal(snapshot)
+	if err != nil {
+		t.Fatalf("Unable to marshal snapshot: %v", err)
+	}
+	second, _ := world.marshal(errorMessage("fout"))
+
+	var wire struct {
+		Version uint32 `json:"v"`
+		Type    string `json:"type"`
+		Seq     uint64 `json:"seq"`
+		Ts      int64  `json:"ts"`
+		Payload struct {
+			Tick     uint64                   `json:"tick"`
+			Messages []map[string]interface{} `json:"messages"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(first, &wire); err != nil {
+		t.Fatalf("Invalid snapshot JSON: %v", err)
+	}
+	if wire.Version != ProtocolVersion || wire.Type != "snapshot" || wire.Seq != 1 || wire.Ts == 0 {
+		t.Errorf("Expected a stamped snapshot envelope, got %s", first)
+	}
+	if len(wire.Payload.Messages) != 2 || wire.Payload.Messages[0]["type"] != "pos" {
+		t.Fatalf("Expected the snapshot messages as envelopes, got %s", first)
+	}
+	if pos, _ := wire.Payload.Messages[0]["payload"].(map[string]interface{}); pos["playerid"] != runner.PlayerId || pos["spriteType"] != string(runner.SpriteType) {
+		t.Errorf("Expected the pos payload of the runner, got %v", pos)
+	}
+
+	decoded, err := UnmarshalEnvelopeJSON(first)
+	if err != nil {
+		t.Fatalf("Unable to decode snapshot: %v", err)
+	}
+	if !proto.Equal(decoded, snapshot) {
+		t.Errorf("Expected the snapshot to survive the JSON wire, got %v", decoded)
+	}
+
+	decoded, err = UnmarshalEnvelopeJSON(second)
+	if err != nil || decoded.Seq != 2 || decoded.GetError().GetError() != "fout" {
+		t.Errorf("Expected error message with seq 2, got %v (%v)", decoded, err)
+	}
+
+	if _, err := UnmarshalEnvelopeJSON([]byte(`{"v":1,"type":"nope","payload":{}}`)); err == nil {
+		t.Error("Expected unknown message type to be rejected")
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	for query, want := range map[string]Encoding{"": EncodingJSON, "json": EncodingJSON, "proto": EncodingProto} {
+		if encoding, err := negotiateEncoding(url.Values{"enc": {query}}); err != nil || encoding != want {
+			t.Errorf("Expected enc=%q to give %s, got %s (%v)", query, want, encoding, err)
+		}
+	}
+	if _, err := negotiateEncoding(url.Values{"enc": {"xml"}}); err == nil {
+		t.Error("Expected unknown encoding to be rejected")
+	}
+}
+
+func TestFrames_EncodeOncePerEncoding(t *testing.T) {
+	snapshot := tickSnapshot(t)
+	message := newFrames(snapshot)
+
+	first, err := message.encode(EncodingProto)
+	if err != nil {
+		t.Fatalf("Unable to encode snapshot: %v", err)
+	}
+	if second, _ := message.encode(EncodingProto); &second[0] != &first[0] {
+		t.Error("Expected the binary frame to be encoded once")
+	}
+
+	decoded := &gamev1.Envelope{}
+	if err := proto.Unmarshal(first, decoded); err != nil || !proto.Equal(decoded, snapshot) {
+		t.Errorf("Expected the snapshot to survive the binary wire, got %v (%v)", decoded, err)
+	}
+
+	text, _ := message.encode(EncodingJSON)
+	if len(first) >= len(text) {
+		t.Errorf("Expected the binary frame (%d bytes) to be smaller than JSON (%d bytes)", len(first), len(text))
+	}
+}
+
+// tickSnapshot plays a bot match until a tick moves players and entities, the
+// typical high-frequency message
+func tickSnapshot(tb testing.TB) *gamev1.Envelope {
+	clock := NewManualClock(time.Unix(0, 0))
+	world := NewWorldState()
+	world.SetClock(clock)
+	world.SetSeed(1)
+	world.BotManager = NewBotManager(world, func(*gamev1.Envelope) error { return nil })
+	world.Join(NewPlayerEntity(1, "Alice"), nil)
+	world.BotManager.FillWithBots()
+	world.StartMatch(clock.Now())
+	world.StartDynamicSystems()
+
+	for i := 0; i < 1000; i++ {
+		clock.Advance(TickRateMs * time.Millisecond)
+		snapshot := world.Step(clock.Now())
+		if snapshot == nil {
+			continue
+		}
+		types := map[string]bool{}
+		for _, message := range snapshot.Messages {
+			types[MessageType(message)] = true
+		}
+		if types["pos"] && types["entities_update"] {
+			return world.stamp(&gamev1.Envelope{Payload: &gamev1.Envelope_Snapshot{Snapshot: snapshot}})
+		}
+	}
+	tb.Fatal("Expected a tick with pos and entities_update")
+	return nil
+}
+
+func benchmarkEncodeSnapshot(b *testing.B, encoding Encoding) {
+	snapshot := tickSnapshot(b)
+
+	var frame []byte
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		frame, _ = EncodeEnvelope(snapshot, encoding)
+	}
+	b.ReportMetric(float64(len(frame)), "bytes/msg")
+}
+
+func BenchmarkEncodeSnapshot_JSON(b *testing.B) {
+	benchmarkEncodeSnapshot(b, EncodingJSON)
+}
+
+func BenchmarkEncodeSnapshot_Proto(b *testing.B) {
+	benchmarkEncodeSnapshot(b, EncodingProto)
+}
+
+// Session View Tests
+func TestSessionView_DeltaAgainstAcknowledgedTick(t *testing.T) {
+	view := newSessionView()
+	all := func(*gamev1.Entity) bool { return true }
+	hunter := &gamev1.Entity{Id: "hunter_0", X: 1, Y: 1}
+	scanner := &gamev1.Entity{Id: "scanner_0", X: 5, Y: 5}
+
+	first := view.entities(3, []*gamev1.Entity{hunter, scanner}, all)
+	if first.Baseline != 0 || len(first.Entities) != 2 {
+		t.Fatalf("Expected the full set without an acknowledged tick, got %v", first)
+	}
+
+	// Not acknowledged yet, still the full set
+	moved := &gamev1.Entity{Id: "hunter_0", X: 2, Y: 1}
+	if update := view.entities(6, []*gamev1.Entity{moved, scanner}, all); update.Baseline != 0 || len(update.Entities) != 2 {
+		t.Errorf("Expected the full set before an ack, got %v", update)
+	}
+
+	if view.ack(4) {
+		t.Error("Expected an ack of a tick never sent to be ignored")
+	}
+	if !view.ack(3) {
+		t.Fatal("Expected the ack of tick 3 to be accepted")
+	}
+
+	update := view.entities(9, []*gamev1.Entity{moved}, all)
+	if update.Baseline != 3 {
+		t.Errorf("Expected baseline 3, got %d", update.Baseline)
+	}
+	if len(update.Entities) != 1 || update.Entities[0].Id != "hunter_0" {
+		t.Errorf("Expected only the moved hunter, got %v", update.Entities)
+	}
+	if len(update.Removed) != 1 || update.Removed[0] != "scanner_0" {
+		t.Errorf("Expected the scanner to be removed, got %v", update.Removed)
+	}
+
+	if view.ack(3) {
+		t.Error("Expected a repeated ack to be ignored")
+	}
+	if !view.ack(9) {
+		t.Fatal("Expected the ack of tick 9 to be accepted")
+	}
+	if update := view.entities(12, []*gamev1.Entity{moved}, all); len(update.Entities) != 0 || len(update.Removed) != 0 {
+		t.Errorf("Expected nothing changed since tick 9, got %v", update)
+	}
+}
+
+func TestSessionView_FullSetWhenBaselineIsGone(t *testing.T) {
+	view := newSessionView()
+	all := func(*gamev1.Entity) bool { return true }
+	hunter := &gamev1.Entity{Id: "hunter_0"}
+
+	view.entities(1, []*gamev1.Entity{hunter}, all)
+	view.ack(1)
+	for tick := uint64(2); tick <= SnapshotHistory+1; tick++ {
+		view.entities(tick, []*gamev1.Entity{hunter}, all)
+	}
+
+	if update := view.entities(SnapshotHistory+2, []*gamev1.Entity{hunter}, all); update.Baseline != 0 || len(update.Entities) != 1 {
+		t.Errorf("Expected the full set once the acknowledged tick left the history, got %v", update)
+	}
+}
+
+func TestWorld_InterestFilter(t *testing.T) {
+	world := NewWorldState()
+	runner := NewPlayerEntity(1, "Alice")
+	world.Join(runner, nil)
+	runner.X, runner.Y = 5*TileSizeFloat, 5*TileSizeFloat
+
+	near := &gamev1.Entity{Id: "near", X: 8, Y: 9}
+	far := &gamev1.Entity{Id: "far", X: 5 + InterestRadiusTiles + 1, Y: 5}
+
+	visible := world.interestFilter(runner)
+	if !visible(near) || visible(far) {
+		t.Errorf("Expected only entities within %d tiles of the runner", InterestRadiusTiles)
+	}
+
+	spectator := NewPlayerEntity(2, "Bob")
+	world.JoinAsSpectator(spectator, nil)
+	if visible := world.interestFilter(spectator); !visible(near) || !visible(far) {
+		t.Error("Expected spectators to see every entity")
+	}
+}
+
+func TestWorld_SessionSnapshot(t *testing.T) {
+	world := NewWorldState()
+	runner := NewPlayerEntity(1, "Alice")
+	world.Join(runner, nil)
+	runner.X, runner.Y = 5*TileSizeFloat, 5*TileSizeFloat
+
+	session := &melody.Session{}
+	session.Set(userInfoKey, runner)
+
+	pos := &gamev1.Envelope{Payload: &gamev1.Envelope_Pos{Pos: runner.ToProto()}}
+	entities := &gamev1.Envelope{Payload: &gamev1.Envelope_EntitiesUpdate{EntitiesUpdate: &gamev1.EntitiesUpdate{
+		Entities: []*gamev1.Entity{{Id: "near", X: 6, Y: 5}, {Id: "far", X: 40, Y: 40}},
+	}}}
+	snapshot := world.stamp(&gamev1.Envelope{Payload: &gamev1.Envelope_Snapshot{Snapshot: &gamev1.Snapshot{
+		Tick:     3,
+		Messages: []*gamev1.Envelope{pos, entities},
+	}}})
+	if !hasEntitiesUpdate(snapshot) {
+		t.Fatal("Expected the snapshot to have an entity update")
+	}
+
+	own := world.sessionSnapshot(session, snapshot)
+	if own.Seq != snapshot.Seq || own.GetSnapshot().Tick != 3 || own.GetSnapshot().Messages[0] != pos {
+		t.Errorf("Expected the session snapshot to keep seq, tick and shared messages, got %v", own)
+	}
+	update := own.GetSnapshot().Messages[1].GetEntitiesUpdate()
+	if len(update.GetEntities()) != 1 || update.Entities[0].Id != "near" {
+		t.Errorf("Expected only the entity near the runner, got %v", update)
+	}
+	if len(snapshot.GetSnapshot().Messages[1].GetEntitiesUpdate().Entities) != 2 {
+		t.Error("Expected the shared snapshot to keep every entity")
+	}
+
+	AckMessage().handler(MessageData{msgInfo: map[string]interface{}{"tick": 3.0}, world: world, playerSession: runner, session: session})
+	if viewOf(session).acked != 3 {
+		t.Errorf("Expected tick 3 to be acknowledged, got %d", viewOf(session).acked)
 	}
 }
 
@@ -2,10 +2,15 @@ package game
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
+	"github.com/frank2889/mazechase/internal/config"
 	"github.com/frank2889/mazechase/internal/lobby"
+	"github.com/frank2889/mazechase/internal/match"
 	"github.com/frank2889/mazechase/internal/user"
 	"github.com/frank2889/mazechase/pkg"
 	"github.com/olahol/melody"
@@ -17,36 +22,42 @@ const (
 	userInfKey  = "userInf"
 	worldKey    = "lobbyEntity"
 	lobbyIdKey  = "lobbyIdKey"
-	powerUpTime = 8 * time.Second
 )
 
 type WsHandler struct {
 	lobbyService    *lobby.Service
 	msgHandlerFuncs map[string]MessageHandlerFunc
 	manager         *Manager
+	rateLimiter     *PlayerRateLimiter
 }
 
-func RegisterGameWSHandler(mux *http.ServeMux, authService *user.Service, lobbyService *lobby.Service) {
+func RegisterGameWSHandler(mux *http.ServeMux, authService *user.Service, lobbyService *lobby.Service, matchService *match.Service) {
 	mel := melody.New()
 	manager := &Manager{
 		lobbyService:  lobbyService,
+		matchService:  matchService,
 		mel:           mel,
 		activeLobbies: pkg.Map[uint, *World]{},
+		replayDir:     config.Opts.ReplayDir,
 	}
 
 	handler := WsHandler{
 		lobbyService: lobbyService,
 		manager:      manager,
+		rateLimiter:  NewPlayerRateLimiter(DefaultRateLimitConfig()),
 		msgHandlerFuncs: registerMessageHandlers(
-			MovMessage().WithMiddleware(CheckCollisionMiddleware).WithMiddleware(CheckGameOverMiddleware),
-			KillPlayer().WithMiddleware(CheckGameOverMiddleware),
-			PowerUpMessage(manager),
-			PelletMessage().WithMiddleware(CheckGameOverMiddleware),
-			ReadyToggleMessage(),
-			StartGameMessage(manager),
+			MovMessage().WithMiddleware(RejectSpectatorMiddleware),
+			KillPlayer().WithMiddleware(CheckGameOverMiddleware).WithMiddleware(RejectSpectatorMiddleware),
+			PowerUpMessage().WithMiddleware(RejectSpectatorMiddleware),
+			PelletMessage().WithMiddleware(CheckGameOverMiddleware).WithMiddleware(RejectSpectatorMiddleware),
+			ReadyToggleMessage().WithMiddleware(RejectSpectatorMiddleware),
+			StartGameMessage(manager).WithMiddleware(RejectSpectatorMiddleware),
+			BotDifficultyMessage().WithMiddleware(RejectSpectatorMiddleware),
+			RoundSettingsMessage().WithMiddleware(RejectSpectatorMiddleware),
+			LivesMessage().WithMiddleware(RejectSpectatorMiddleware),
 			LobbyStatusMessage(),
+			AckMessage(),
 			// Dynamic world messages
-			EntityCollisionMessage().WithMiddleware(CheckGameOverMiddleware),
 			ZoneQueryMessage(),
 			DynamicStateMessage(),
 		),
@@ -65,35 +76,59 @@ func RegisterGameWSHandler(mux *http.ServeMux, authService *user.Service, lobbyS
 	}
 
 	mux.Handle("/api/game", WSAuthMiddleware(authService, http.HandlerFunc(wsHandler)))
+	mux.Handle("/api/replay", WSAuthMiddleware(authService, newReplayWSHandler(matchService)))
+	mux.Handle("/api/metrics", WSAuthMiddleware(authService, http.HandlerFunc(antiCheatMetrics)))
 }
 
 ////////////////////////////
 // main handlers
 
 func (h *WsHandler) HandleConnect(newPlayerSession *melody.Session) {
+	// Clients speak the server's protocol version or not at all
+	if err := checkProtocol(newPlayerSession.Request.URL.Query()); err != nil {
+		log.Warn().Err(err).Msg("Client protocol not supported")
+		sendMessage(newPlayerSession, wsError(err))
+		return
+	}
+	encoding, err := negotiateEncoding(newPlayerSession.Request.URL.Query())
+	if err != nil {
+		log.Warn().Err(err).Msg("Client encoding not supported")
+		sendMessage(newPlayerSession, wsError(err))
+		return
+	}
+	newPlayerSession.Set(encodingKey, encoding)
+
 	userInfo, lobbyInfo, err := h.manager.getUserAndLobbyInfo(newPlayerSession)
 	if err != nil {
 		log.Error().Err(err).Msg("Unable to find lobby or user info")
 		return
 	}
 
-	world, err := h.manager.getWorld(lobbyInfo)
+	// A dropped player reclaims its sprite instead of joining again
+	if world, held := h.manager.heldWorld(lobbyInfo.ID, userInfo.ID); held {
+		h.resumePlayer(newPlayerSession, world, userInfo, lobbyInfo)
+		return
+	}
+
+	queryParams := newPlayerSession.Request.URL.Query()
+
+	// Watch with ?spectate=true, a running match only takes spectators
+	spectate := queryParams.Get("spectate") == "true"
+	world, err := h.manager.getWorld(lobbyInfo, spectate)
 	if err != nil {
-		sendMessage(newPlayerSession, wsError(err))
+		sendError(newPlayerSession, err)
 		return
 	}
 
 	player := NewPlayerEntity(userInfo.ID, userInfo.Username)
-
-	// Check if lobby is full - join as spectator
-	if world.IsLobbyFull() {
+	if spectate {
 		world.JoinAsSpectator(player, newPlayerSession)
 		log.Info().Str("user", userInfo.Username).Msg("Player joined as spectator")
 	} else {
 		err = world.Join(player, newPlayerSession)
 		if err != nil {
 			log.Error().Err(err).Msg("Unable to join lobby")
-			sendMessage(newPlayerSession, wsError(err))
+			sendError(newPlayerSession, err)
 			return
 		}
 
@@ -105,12 +140,6 @@ func (h *WsHandler) HandleConnect(newPlayerSession *melody.Session) {
 		}
 	}
 
-	newPlayerJson, err := player.ToJSON()
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to convert player to JSON")
-		return
-	}
-
 	// store session info
 	newPlayerSession.Set(userInfoKey, player)
 	newPlayerSession.Set(worldKey, world)
@@ -125,8 +154,16 @@ func (h *WsHandler) HandleConnect(newPlayerSession *melody.Session) {
 		return
 	}
 
+	// Spectators have no sprite, the others only see them in the lobby status
+	if player.IsSpectator {
+		log.Info().Any("user", *userInfo).Any("lobby", lobbyInfo).Msgf("New spectator joined lobby")
+		h.broadcastLobbyStatus(world)
+		return
+	}
+
 	// inform new player has joined to existing players
-	if err := h.manager.broadcastExceptPlayer(newPlayerSession, newPlayerJson); err != nil {
+	joined := &gamev1.Envelope{Payload: &gamev1.Envelope_Active{Active: player.ToProto()}}
+	if err := h.manager.broadcastExceptPlayer(world, newPlayerSession, joined); err != nil {
 		log.Error().Err(err).Msg("Unable to broadcast status")
 		return
 	}
@@ -141,7 +178,6 @@ func (h *WsHandler) HandleConnect(newPlayerSession *melody.Session) {
 	h.broadcastLobbyStatus(world)
 
 	// Check if this is a solo game - if so, immediately fill with bots
-	queryParams := newPlayerSession.Request.URL.Query()
 	isSinglePlayer := queryParams.Get("single") == "true"
 	
 	if isSinglePlayer && world.BotManager != nil {
@@ -166,21 +202,57 @@ func (h *WsHandler) HandleDisconnect(s *melody.Session) {
 		return
 	}
 
-	world.Leave(exitingPlayer)
-	// set disconnect status
-	exitingPlayer.Type = "dis"
+	// Players keep their sprite for a while, they may come back with a resume token
+	if world.HoldDisconnected(exitingPlayer, s, world.Clock().Now()) {
+		log.Info().Any("player", *exitingPlayer).Msg("client disconnected, waiting for resume")
+		h.updateLobbyPlayerCount(s, world)
+		return
+	}
 
-	// inform other players
-	marshal, err := exitingPlayer.ToJSON()
-	if err != nil {
-		log.Error().Err(err).Any("other entity", exitingPlayer).Msg("Failed to convert PlayerEntity to JSON")
+	world.Leave(exitingPlayer)
+	h.rateLimiter.RemoveLimiter(exitingPlayer.PlayerId)
+	if exitingPlayer.IsSpectator {
+		log.Info().Any("player", *exitingPlayer).Msg("spectator disconnected")
+		h.broadcastLobbyStatus(world)
 		return
 	}
+
 	// inform active players about player that left
-	pkg.Elog(h.manager.broadcastAll(world, marshal))
+	pkg.Elog(h.manager.broadcastAll(world, &gamev1.Envelope{Payload: &gamev1.Envelope_Dis{Dis: exitingPlayer.ToProto()}}))
 
 	log.Info().Any("player", *exitingPlayer).Msg("client disconnected")
 
+	h.updateLobbyPlayerCount(s, world)
+}
+
+// resumePlayer gives a held player back to its new session and sends a fresh
+// state report, the resume token must come from the last state it received
+func (h *WsHandler) resumePlayer(newPlayerSession *melody.Session, world *World, userInfo *user.User, lobbyInfo *lobby.Lobby) {
+	token := newPlayerSession.Request.URL.Query().Get("resume")
+	player, err := world.Resume(strconv.Itoa(int(userInfo.ID)), token, newPlayerSession)
+	if err != nil {
+		log.Warn().Err(err).Str("user", userInfo.Username).Msg("Unable to resume session")
+		sendError(newPlayerSession, err)
+		return
+	}
+
+	newPlayerSession.Set(userInfoKey, player)
+	newPlayerSession.Set(worldKey, world)
+	newPlayerSession.Set(userInfKey, userInfo)
+	newPlayerSession.Set(lobbyIdKey, lobbyInfo.ID)
+
+	if err := h.manager.sendGameStateInfo(newPlayerSession, world); err != nil {
+		log.Error().Err(err).Msg("Unable to send game state info")
+		return
+	}
+
+	log.Info().Any("user", *userInfo).Any("lobby", lobbyInfo).Msgf("Player resumed")
+
+	h.updateLobbyPlayerCount(newPlayerSession, world)
+	h.broadcastLobbyStatus(world)
+}
+
+func (h *WsHandler) updateLobbyPlayerCount(s *melody.Session, world *World) {
 	lobbyId, exist := s.Get(lobbyIdKey)
 	if exist {
 		h.lobbyService.UpdateLobbyPlayerCount(lobbyId.(uint), len(world.ConnectedPlayers.GetValues()))
@@ -194,49 +266,47 @@ func (h *WsHandler) HandleMessage(s *melody.Session, msg []byte) {
 		return
 	}
 
-	msgInfo := map[string]interface{}{}
-	if err = json.Unmarshal(msg, &msgInfo); err != nil {
-		log.Error().Err(err).Msg("Unable to unmarshal msg")
+	world, err := getWorldFromSession(s)
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to find lobby info")
 		return
 	}
 
-	secretToken, _ := msgInfo["secretToken"].(string)
-	if secretToken != playerSession.secretToken {
-		log.Error().Msg("unable to verify secret token")
+	// Every inbound message counts against the player's budget
+	if !h.rateLimiter.GetLimiter(playerSession.PlayerId).Allow() {
+		world.AntiCheat.Report(playerSession.PlayerId, ViolationRateLimit, fmt.Errorf("too many messages"))
 		return
 	}
 
-	world, err := getWorldFromSession(s)
-	if err != nil {
-		log.Error().Err(err).Msg("Unable to find lobby info")
+	var envelope clientEnvelope
+	if err = json.Unmarshal(msg, &envelope); err != nil {
+		world.AntiCheat.Report(playerSession.PlayerId, ViolationInvalidInput, err)
 		return
 	}
-	msgType, _ := msgInfo["type"].(string)
 
-	msgHandler, ok := h.msgHandlerFuncs[msgType]
-	if !ok {
-		log.Warn().Msgf("Unknown message type: %s", msgType)
+	if envelope.SecretToken != playerSession.secretToken {
+		log.Error().Msg("unable to verify secret token")
 		return
 	}
 
-	data := msgHandler(MessageData{msgInfo, world, playerSession})
-	if data == nil {
-		log.Debug().Msg("null message, something went wrong while handling message")
+	msgHandler, ok := h.msgHandlerFuncs[envelope.Type]
+	if !ok {
+		world.AntiCheat.Report(playerSession.PlayerId, ViolationInvalidInput, fmt.Errorf("unknown message type: %s", envelope.Type))
 		return
 	}
 
-	marshal, err := json.Marshal(data)
-	if err != nil {
-		log.Error().Err(err).Any("msg", data).Msg("Unable to marshal msg")
-		return
+	msgInfo := envelope.Payload
+	if msgInfo == nil {
+		msgInfo = map[string]interface{}{}
 	}
 
-	if msgType == "pos" {
-		// player self does not need the pos update adds lag
-		pkg.Elog(h.manager.broadcastExceptPlayer(s, marshal))
-	} else {
-		pkg.Elog(h.manager.broadcastAll(world, marshal))
+	data := msgHandler(MessageData{msgInfo, world, playerSession, s})
+	if data == nil {
+		// nothing to broadcast, e.g. queued movement or a rejected message
+		return
 	}
+
+	pkg.Elog(h.manager.broadcastAll(world, data))
 }
 
 func sendMessage(session *melody.Session, message []byte) {
@@ -247,45 +317,17 @@ func sendMessage(session *melody.Session, message []byte) {
 }
 
 func wsError(err error) []byte {
-	marshal, _ := json.Marshal(map[string]string{"error": err.Error()})
-	return marshal
+	return marshalStandalone(errorMessage(err.Error()), time.Now())
 }
 
-// broadcastLobbyStatus sends lobby status to all connected players
-func (h *WsHandler) broadcastLobbyStatus(world *World) {
-	players := []map[string]interface{}{}
-
-	for _, session := range world.ConnectedPlayers.GetValues() {
-		if session == nil {
-			continue
-		}
-		player, err := getPlayerEntityFromSession(session)
-		if err != nil {
-			continue
-		}
-		players = append(players, map[string]interface{}{
-			"playerId":   player.PlayerId,
-			"username":   player.Username,
-			"spriteType": player.SpriteType,
-			"isReady":    player.IsReady,
-			"isHost":     player.IsHost,
-		})
-	}
-
-	statusMsg := map[string]interface{}{
-		"type":         "lobbystatus",
-		"players":      players,
-		"playerCount":  world.GetPlayerCount(),
-		"readyCount":   world.GetReadyCount(),
-		"matchStarted": world.MatchStarted,
-		"hostId":       world.HostPlayerId,
-	}
-
-	marshal, err := json.Marshal(statusMsg)
-	if err != nil {
-		log.Error().Err(err).Msg("Unable to marshal lobby status")
-		return
+// sendError sends an error outside a match in the session's encoding
+func sendError(session *melody.Session, err error) {
+	if err := writeEnvelope(session, stampEnvelope(errorMessage(err.Error()), 0, time.Now())); err != nil {
+		log.Error().Err(err).Msg("Unable to send message")
 	}
+}
 
-	pkg.Elog(h.manager.broadcastAll(world, marshal))
+// broadcastLobbyStatus sends lobby status to all connected players
+func (h *WsHandler) broadcastLobbyStatus(world *World) {
+	pkg.Elog(h.manager.broadcastAll(world, world.LobbyStatus()))
 }
@@ -0,0 +1,152 @@
+package game
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
+	"github.com/olahol/melody"
+	"google.golang.org/protobuf/proto"
+)
+
+const viewKey = "view"
+
+// sessionView is the entity state a session was sent per tick. Its entity
+// updates only hold the changes since the tick the client acknowledged last,
+// a client that acknowledged nothing yet gets the full set.
+type sessionView struct {
+	mu    sync.Mutex
+	sent  map[uint64]map[string]*gamev1.Entity
+	ticks []uint64 // ticks in sent, oldest first
+	acked uint64
+}
+
+func newSessionView() *sessionView {
+	return &sessionView{sent: map[uint64]map[string]*gamev1.Entity{}}
+}
+
+// viewOf returns the view of a session, created on its first snapshot
+func viewOf(s *melody.Session) *sessionView {
+	if value, ok := s.Get(viewKey); ok {
+		return value.(*sessionView)
+	}
+	view := newSessionView()
+	s.Set(viewKey, view)
+	return view
+}
+
+// ack marks the entity update of a tick as received. Ticks the session was
+// never sent, or older than the last acknowledged one, are ignored.
+func (v *sessionView) ack(tick uint64) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if _, ok := v.sent[tick]; !ok || tick <= v.acked {
+		return false
+	}
+	v.acked = tick
+
+	// Older updates will never be a baseline again
+	for len(v.ticks) > 0 && v.ticks[0] < tick {
+		delete(v.sent, v.ticks[0])
+		v.ticks = v.ticks[1:]
+	}
+	return true
+}
+
+// entities is the entity update of a tick for the session: the visible
+// entities that changed since the acknowledged tick and the ones no longer
+// visible, or every visible entity when there is no baseline
+func (v *sessionView) entities(tick uint64, all []*gamev1.Entity, visible func(*gamev1.Entity) bool) *gamev1.EntitiesUpdate {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	update := &gamev1.EntitiesUpdate{}
+	baseline, hasBaseline := v.sent[v.acked]
+	if hasBaseline {
+		update.Baseline = v.acked
+	}
+
+	current := make(map[string]*gamev1.Entity, len(all))
+	for _, entity := range all {
+		if !visible(entity) {
+			continue
+		}
+		current[entity.Id] = entity
+		if !hasBaseline || !proto.Equal(baseline[entity.Id], entity) {
+			update.Entities = append(update.Entities, entity)
+		}
+	}
+
+	for id := range baseline {
+		if _, ok := current[id]; !ok {
+			update.Removed = append(update.Removed, id)
+		}
+	}
+	sort.Strings(update.Removed)
+
+	v.sent[tick] = current
+	v.ticks = append(v.ticks, tick)
+	if len(v.ticks) > SnapshotHistory {
+		delete(v.sent, v.ticks[0])
+		v.ticks = v.ticks[1:]
+	}
+	return update
+}
+
+// interestFilter tells which entities a player is sent: the ones within
+// InterestRadiusTiles, every entity for spectators
+func (w *World) interestFilter(player *PlayerEntity) func(*gamev1.Entity) bool {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+
+	if player == nil || player.IsSpectator {
+		return func(*gamev1.Entity) bool { return true }
+	}
+	if _, playing := w.Players[player.PlayerId]; !playing {
+		return func(*gamev1.Entity) bool { return true }
+	}
+
+	tileX, tileY := player.X/TileSizeFloat, player.Y/TileSizeFloat
+	return func(entity *gamev1.Entity) bool {
+		return math.Hypot(entity.X-tileX, entity.Y-tileY) <= InterestRadiusTiles
+	}
+}
+
+// sessionSnapshot is the snapshot of a session, its entity update holds the
+// entities the session is interested in as changes to its view
+func (w *World) sessionSnapshot(s *melody.Session, envelope *gamev1.Envelope) *gamev1.Envelope {
+	snapshot := envelope.GetSnapshot()
+
+	messages := make([]*gamev1.Envelope, len(snapshot.Messages))
+	for i, message := range snapshot.Messages {
+		update := message.GetEntitiesUpdate()
+		if update == nil {
+			messages[i] = message
+			continue
+		}
+		player, _ := getPlayerEntityFromSession(s)
+		messages[i] = &gamev1.Envelope{Payload: &gamev1.Envelope_EntitiesUpdate{
+			EntitiesUpdate: viewOf(s).entities(snapshot.Tick, update.Entities, w.interestFilter(player)),
+		}}
+	}
+
+	return &gamev1.Envelope{
+		Version: envelope.Version,
+		Seq:     envelope.Seq,
+		Ts:      envelope.Ts,
+		Payload: &gamev1.Envelope_Snapshot{Snapshot: &gamev1.Snapshot{Tick: snapshot.Tick, Messages: messages}},
+	}
+}
+
+// hasEntitiesUpdate reports whether a message is a snapshot with an entity
+// update, the only messages that differ per session
+func hasEntitiesUpdate(envelope *gamev1.Envelope) bool {
+	for _, message := range envelope.GetSnapshot().GetMessages() {
+		if message.GetEntitiesUpdate() != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
+	"github.com/rs/zerolog/log"
+)
+
+// SetLives changes the number of lives the runner starts with, only before
+// the match
+func (w *World) SetLives(lives int) error {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+	if w.MatchStarted {
+		return fmt.Errorf("de game is al gestart")
+	}
+	if lives < 1 || lives > RunnerLivesMax {
+		return fmt.Errorf("aantal levens moet tussen 1 en %d liggen", RunnerLivesMax)
+	}
+
+	w.Lives = lives
+	return nil
+}
+
+// loseLifeLocked takes a life of the caught runner and reports whether it
+// has any left, if so everyone freezes and then respawns (caller must hold
+// worldLock)
+func (w *World) loseLifeLocked(now time.Time) bool {
+	w.Lives = max(0, w.Lives-1)
+	if w.Lives == 0 {
+		return false
+	}
+
+	w.freezeLocked(now)
+	w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Lifelost{Lifelost: &gamev1.LifeLost{
+		Lives:    int32(w.Lives),
+		FreezeMs: CatchFreezeMs,
+	}}})
+	log.Info().Int("lives", w.Lives).Msg("Runner lost a life")
+	return true
+}
+
+// advanceLevelLocked starts the next level once the maze is cleared: the
+// pellets and power-ups come back, chasers get faster and power-ups shorter,
+// and everyone respawns after a freeze (caller must hold worldLock)
+func (w *World) advanceLevelLocked(now time.Time) {
+	w.Level++
+	w.MazeData.Reset()
+	w.PelletsCoordEaten = NewCordList()
+	w.PowerUpsCoordsEaten = NewCordList()
+	if w.suddenDeath {
+		for _, tile := range w.MazeData.ClearPowerUps() {
+			w.PowerUpsCoordsEaten.Add(float64(tile.X), float64(tile.Y))
+		}
+	}
+
+	w.freezeLocked(now)
+	w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Level{Level: &gamev1.Level{
+		Level:             int32(w.Level),
+		ChaserSpeedFactor: w.chaserSpeedFactorLocked(),
+		PowerUpDuration:   int32(w.powerUpDurationLocked().Seconds()),
+		FreezeMs:          CatchFreezeMs,
+	}}})
+	log.Info().Int("level", w.Level).Msg("Level cleared")
+}
+
+// freezeLocked stops every player until CatchFreezeMs from now, the loop
+// respawns them once it ends (caller must hold worldLock)
+func (w *World) freezeLocked(now time.Time) {
+	w.frozenUntil = now.Add(CatchFreezeMs * time.Millisecond)
+	w.respawnPending = true
+}
+
+// isFrozenLocked reports whether players stand still for a respawn (caller
+// must hold worldLock)
+func (w *World) isFrozenLocked(now time.Time) bool {
+	return now.Before(w.frozenUntil)
+}
+
+// tickRespawnLocked respawns everyone once a freeze ends (called from the
+// loop with worldLock held)
+func (w *World) tickRespawnLocked(now time.Time) {
+	if !w.respawnPending || w.isFrozenLocked(now) {
+		return
+	}
+	w.respawnPending = false
+
+	if w.IsPoweredUp {
+		w.IsPoweredUp = false
+		w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Powend{Powend: &gamev1.PowerUpEnd{}}})
+	}
+	w.resetChasersLocked()
+	clear(w.StunnedUntil)
+
+	positions := make(map[string]*gamev1.Point)
+	for _, id := range w.sortedPlayerIdsLocked() {
+		player := w.Players[id]
+		spawn := w.Map.Spawn(player.SpriteType)
+		x, y := TileToPixel(spawn.X, spawn.Y)
+		w.movePlayerLocked(player, x, y)
+		positions[string(player.SpriteType)] = &gamev1.Point{X: x, Y: y}
+	}
+	w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Respawn{Respawn: &gamev1.Respawn{Positions: positions}}})
+}
+
+// chaserSpeedFactorLocked is the factor on the chasers' speed in the current
+// level (caller must hold worldLock)
+func (w *World) chaserSpeedFactorLocked() float64 {
+	return 1 + float64(w.Level-1)*LevelChaserSpeedStep
+}
+
+// powerUpDurationLocked is how long a runner power-up lasts in the current
+// level (caller must hold worldLock)
+func (w *World) powerUpDurationLocked() time.Duration {
+	return time.Duration(max(PowerUpMinDurationSec, PowerUpDurationSec-(w.Level-1)*LevelPowerUpStepSec)) * time.Second
+}
+
+// playerSpeedFactorLocked is the factor on a player's speed: sudden death for
+// everyone and the level for chasers (caller must hold worldLock)
+func (w *World) playerSpeedFactorLocked(player *PlayerEntity) float64 {
+	factor := w.speedFactorLocked()
+	if !w.Rules.IsRunner(player.SpriteType) {
+		factor *= w.chaserSpeedFactorLocked()
+	}
+	return factor
+}
+
+// progressLocked returns the level and the runner's lives for the state
+// report and the game over, only classic mode has them (caller must hold
+// worldLock)
+func (w *World) progressLocked() (level, lives int32) {
+	if w.Rules.Mode() != ModeClassic {
+		return 0, 0
+	}
+	return int32(w.Level), int32(w.Lives)
+}
+
+// Progress returns the level and the runner's lives, zero outside classic mode
+func (w *World) Progress() (level, lives int32) {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+	return w.progressLocked()
+}
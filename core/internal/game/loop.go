@@ -0,0 +1,236 @@
+package game
+
+import (
+	"sort"
+	"time"
+
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
+	"github.com/rs/zerolog/log"
+)
+
+// PlayerInput is a movement intent sent by a client, queued until the next tick
+type PlayerInput struct {
+	PlayerId string `json:"p"`
+	Dir      string `json:"d,omitempty"`
+	// HasPos marks legacy input carrying an absolute position instead of a direction
+	HasPos bool    `json:"hasPos,omitempty"`
+	X      float64 `json:"x,omitempty"`
+	Y      float64 `json:"y,omitempty"`
+}
+
+// QueueInput stores a player input for the next simulation step
+func (w *World) QueueInput(input PlayerInput) {
+	w.inputLock.Lock()
+	defer w.inputLock.Unlock()
+	w.inputs = append(w.inputs, input)
+}
+
+// StartLoop runs the fixed-rate simulation loop until StopLoop is called
+func (w *World) StartLoop() {
+	w.worldLock.Lock()
+	if w.stopLoop != nil {
+		w.worldLock.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	w.stopLoop = stop
+	ticker := w.clock.NewTicker(TickRateMs * time.Millisecond)
+	w.worldLock.Unlock()
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C():
+				w.broadcastSnapshot(w.Step(now))
+			}
+		}
+	}()
+}
+
+// StopLoop halts the simulation loop
+func (w *World) StopLoop() {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+
+	if w.stopLoop != nil {
+		close(w.stopLoop)
+		w.stopLoop = nil
+	}
+}
+
+// Step advances the world by one tick. It returns the snapshot of everything
+// that happened, the single message a World emits per tick, or nil for a
+// quiet tick.
+func (w *World) Step(now time.Time) *gamev1.Snapshot {
+	inputs := w.drainInputs()
+
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+
+	if w.gameEnded {
+		return nil
+	}
+	w.Tick++
+
+	// Players neither move nor collide while frozen, then respawn
+	w.tickRespawnLocked(now)
+	frozen := w.isFrozenLocked(now)
+	if !frozen {
+		w.applyInputsLocked(inputs, now)
+	}
+
+	// Bots and the stand-ins for dropped players
+	if w.everyMs(BotMoveIntervalMs) && w.BotManager != nil && !frozen {
+		for _, bot := range append(w.BotManager.GetBots(), w.BotManager.getStandIns()...) {
+			if _, alive := w.Players[bot.PlayerEntity.PlayerId]; !alive || w.isStunnedLocked(bot.PlayerEntity.PlayerId, now) || w.isChaserEatenLocked(bot.PlayerEntity.SpriteType) {
+				continue
+			}
+			if event := bot.Step(now); event != nil {
+				w.emit(event)
+			}
+		}
+	}
+
+	// Entities and their catches, zone phases
+	if w.dynamicActive {
+		if w.everyMs(EntityTickMs) {
+			w.EntityManager.update()
+			if !frozen {
+				w.resolveEntityCatchesLocked(now)
+			}
+		}
+		if w.everyMs(PhaseTickMs) {
+			w.DynamicWorld.tick(now)
+		}
+	}
+
+	// Power-ups expire, the global one and those per player
+	if w.IsPoweredUp && !now.Before(w.PowerUpEndTime) {
+		w.IsPoweredUp = false
+		w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Powend{Powend: &gamev1.PowerUpEnd{}}})
+		log.Info().Msg("Power-up ended")
+	}
+	w.expirePlayerPowerUpsLocked(now)
+
+	// The chaser house, reconnect grace and the round timer
+	w.tickChasersLocked(now)
+	w.expireDisconnectsLocked(now)
+	w.tickRoundTimerLocked(now)
+
+	if !frozen {
+		w.Rules.ResolveCollisions(w, now)
+	}
+
+	// The rules end the match, or their time-up outcome once the timer runs out
+	reason, winner := w.Rules.CheckGameOver(w, now)
+	if reason == "" && w.roundOverLocked(now) {
+		reason, winner = w.Rules.TimeUp(w)
+	}
+	if reason != "" && !w.gameEnded {
+		w.gameEnded = true
+		w.GameOver(reason, winner)
+	}
+
+	return w.takeSnapshotLocked()
+}
+
+// drainInputs takes all queued inputs, keeping only the latest per player
+// so a client can move at most once per tick
+func (w *World) drainInputs() []PlayerInput {
+	w.inputLock.Lock()
+	queued := w.inputs
+	w.inputs = nil
+	w.inputLock.Unlock()
+
+	latest := map[string]PlayerInput{}
+	for _, input := range queued {
+		latest[input.PlayerId] = input
+	}
+
+	result := make([]PlayerInput, 0, len(latest))
+	for _, input := range latest {
+		result = append(result, input)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].PlayerId < result[j].PlayerId })
+	return result
+}
+
+func (w *World) applyInputsLocked(inputs []PlayerInput, now time.Time) {
+	for _, input := range inputs {
+		player, ok := w.Players[input.PlayerId]
+		// Eaten chasers are steered back to the house by the server
+		if !ok || w.isStunnedLocked(player.PlayerId, now) || w.isChaserEatenLocked(player.SpriteType) {
+			continue
+		}
+
+		// Client-reported positions must be reachable at PlayerSpeed
+		if input.HasPos {
+			if violation, err := w.checkMoveLocked(player, input.X, input.Y, now); err != nil {
+				w.AntiCheat.Report(player.PlayerId, violation, err)
+				continue
+			}
+		}
+		if w.recorder != nil {
+			w.recorder.RecordInput(now, input)
+		}
+
+		if input.HasPos {
+			w.movePlayerLocked(player, input.X, input.Y)
+			if input.Dir != "" {
+				player.Dir = input.Dir
+			}
+			w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Pos{Pos: player.ToProto()}})
+			continue
+		}
+
+		if event, moved := w.stepPlayerLocked(player, input.Dir, PlayerSpeed*TickRateSec*w.playerSpeedFactorLocked(player), now); moved {
+			w.emit(event)
+		}
+	}
+}
+
+// emit queues an event for the current tick snapshot (caller must hold worldLock)
+func (w *World) emit(event *gamev1.Envelope) {
+	w.pendingEvents = append(w.pendingEvents, event)
+}
+
+func (w *World) takeSnapshotLocked() *gamev1.Snapshot {
+	if len(w.pendingEvents) == 0 {
+		return nil
+	}
+	snapshot := &gamev1.Snapshot{
+		Tick:     w.Tick,
+		Messages: w.pendingEvents,
+	}
+	w.pendingEvents = nil
+	return snapshot
+}
+
+func (w *World) broadcastSnapshot(snapshot *gamev1.Snapshot) {
+	if snapshot == nil || w.broadcastFunc == nil {
+		return
+	}
+	if err := w.broadcastFunc(&gamev1.Envelope{Payload: &gamev1.Envelope_Snapshot{Snapshot: snapshot}}); err != nil {
+		log.Warn().Err(err).Msg("Unable to broadcast snapshot")
+	}
+}
+
+// everyMs reports whether the current tick crosses a boundary of the given interval
+func (w *World) everyMs(intervalMs int) bool {
+	elapsed := w.Tick * TickRateMs
+	return elapsed/uint64(intervalMs) != (elapsed-TickRateMs)/uint64(intervalMs)
+}
+
+// sortedPlayerIdsLocked returns player ids in a stable order
+func (w *World) sortedPlayerIdsLocked() []string {
+	ids := make([]string, 0, len(w.Players))
+	for id := range w.Players {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
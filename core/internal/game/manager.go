@@ -1,19 +1,23 @@
 package game
 
 import (
-	"encoding/json"
 	"fmt"
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
 	"github.com/frank2889/mazechase/internal/lobby"
+	"github.com/frank2889/mazechase/internal/match"
 	"github.com/frank2889/mazechase/internal/user"
 	"github.com/frank2889/mazechase/pkg"
 	"github.com/olahol/melody"
 	"github.com/rs/zerolog/log"
+	"strconv"
 )
 
 type Manager struct {
 	activeLobbies pkg.Map[uint, *World]
 	lobbyService  *lobby.Service
+	matchService  *match.Service
 	mel           *melody.Melody
+	replayDir     string // matches are not recorded when empty
 }
 
 func (manager *Manager) getLobbyIdFromSession(s *melody.Session) uint {
@@ -25,31 +29,44 @@ func (manager *Manager) getLobbyIdFromSession(s *melody.Session) uint {
 	return lobbyId.(uint)
 }
 
-func (manager *Manager) broadcastAll(world *World, message []byte) error {
-	broadCastSessions := world.ConnectedPlayers.GetValues()
-	// Filter out nil sessions (bots don't have real sessions)
-	validSessions := make([]*melody.Session, 0, len(broadCastSessions))
-	for _, s := range broadCastSessions {
-		if s != nil {
-			validSessions = append(validSessions, s)
-		}
-	}
+// broadcastAll sends a message to everyone in a world, spectators included
+func (manager *Manager) broadcastAll(world *World, envelope *gamev1.Envelope) error {
+	message := newFrames(world.stamp(envelope))
+	world.recordBroadcast(message)
+
+	// Spectators (including eliminated players) follow the match too
+	validSessions := world.sessions()
 	if len(validSessions) == 0 {
 		return nil
 	}
-	err := manager.mel.BroadcastMultiple(message, validSessions)
-	if err != nil {
-		return err
+
+	// Entity updates depend on what a session was sent and acknowledged
+	if hasEntitiesUpdate(envelope) {
+		for _, s := range validSessions {
+			if err := writeEnvelope(s, world.sessionSnapshot(s, envelope)); err != nil {
+				log.Warn().Err(err).Msg("Unable to send snapshot")
+			}
+		}
+		return nil
 	}
-	return nil
+	return writeFrames(manager.mel, message, validSessions)
 }
 
-func (manager *Manager) broadcastExceptPlayer(player *melody.Session, message []byte) error {
-	err := manager.mel.BroadcastOthers(message, player)
-	if err != nil {
-		return err
+// broadcastExceptPlayer sends a message to everyone in the world of a player,
+// spectators included
+func (manager *Manager) broadcastExceptPlayer(world *World, player *melody.Session, envelope *gamev1.Envelope) error {
+	message := newFrames(world.stamp(envelope))
+
+	others := make([]*melody.Session, 0)
+	for _, s := range world.sessions() {
+		if s != player {
+			others = append(others, s)
+		}
 	}
-	return nil
+	if len(others) == 0 {
+		return nil
+	}
+	return writeFrames(manager.mel, message, others)
 }
 
 func (manager *Manager) sendGameStateInfo(newPlayerSession *melody.Session, world *World) error {
@@ -58,12 +75,13 @@ func (manager *Manager) sendGameStateInfo(newPlayerSession *melody.Session, worl
 		return fmt.Errorf("unable to find player: %v", err)
 	}
 
-	gameState, err := world.GetGameStateReport(player.secretToken, player.Username, string(player.SpriteType), newPlayerSession)
-	if err != nil {
-		return fmt.Errorf("unable to marshal game state: %v", err)
+	var state *gamev1.Envelope
+	if player.IsSpectator {
+		state = world.SpectatorStateReport(player)
+	} else {
+		state = world.GetGameStateReport(player.secretToken, player.Username, string(player.SpriteType), newPlayerSession)
 	}
-
-	err = newPlayerSession.Write(gameState)
+	err = writeEnvelope(newPlayerSession, world.stamp(state))
 	if err != nil {
 		return fmt.Errorf("unable to send game state: %v", err)
 	}
@@ -71,24 +89,48 @@ func (manager *Manager) sendGameStateInfo(newPlayerSession *melody.Session, worl
 	return nil
 }
 
-func (manager *Manager) getWorld(lobby *lobby.Lobby) (*World, error) {
+// heldWorld returns the active world in which the user's player is held
+// after a dropped connection
+func (manager *Manager) heldWorld(lobbyId uint, userId uint) (*World, bool) {
+	world, exists := manager.activeLobbies.Load(lobbyId)
+	if !exists || !world.IsDisconnected(strconv.Itoa(int(userId))) {
+		return nil, false
+	}
+	return world, true
+}
+
+func (manager *Manager) getWorld(lobby *lobby.Lobby, spectate bool) (*World, error) {
 	activeWorld, exists := manager.activeLobbies.Load(lobby.ID)
 	if !exists {
 		log.Info().Msgf("creating new lobby")
 
-		newWorld := NewWorldState()
+		mazeMap, err := ResolveMazeMap(lobby.MapName, lobby.MapSeed)
+		if err != nil {
+			log.Warn().Err(err).Uint("id", lobby.ID).Msg("lobby map not found, using default map")
+			mazeMap = DefaultMazeMap()
+		}
+
+		newWorld := NewWorldStateWithMap(GameMode(lobby.GameMode), mazeMap)
+		newWorld.LobbyID = lobby.ID
+		if manager.replayDir != "" {
+			newWorld.recorder = NewReplayRecorder()
+		}
 		manager.activeLobbies.Store(lobby.ID, newWorld)
 		
 		// Create broadcast function for bots and power-up timer
-		broadcastFunc := func(msg []byte) error {
+		broadcastFunc := func(msg *gamev1.Envelope) error {
 			return manager.broadcastAll(newWorld, msg)
 		}
 		newWorld.broadcastFunc = broadcastFunc
 		newWorld.BotManager = NewBotManager(newWorld, broadcastFunc)
+		newWorld.StartLoop()
 		
 		go func() {
 			gameOverInfo := newWorld.waitForGameOver()
 			
+			// Stop the simulation before tearing the world down
+			newWorld.StopLoop()
+			
 			// Stop all bots when game ends
 			if newWorld.BotManager != nil {
 				newWorld.BotManager.StopAllBots()
@@ -99,12 +141,12 @@ func (manager *Manager) getWorld(lobby *lobby.Lobby) (*World, error) {
 			
 			// endgame with scores
 			msg := EndGameMessage(gameOverInfo.Reason, gameOverInfo.Winner).handler(MessageData{world: newWorld})
-			marshal, err := json.Marshal(msg)
-			if err != nil {
-				log.Error().Err(err).Msg("Unable to marshal msg")
-			} else {
-				pkg.Elog(manager.broadcastAll(newWorld, marshal))
+			pkg.Elog(manager.broadcastAll(newWorld, msg))
+
+			if record := manager.recordMatch(lobby, newWorld, gameOverInfo); record != nil {
+				manager.saveReplay(record, newWorld)
 			}
+			manager.lobbyService.SetLobbyMatchStarted(lobby.ID, false)
 
 			log.Debug().Uint("id", lobby.ID).Str("reason", gameOverInfo.Reason).Str("winner", gameOverInfo.Winner).Msg("game end deleting lobby")
 			manager.activeLobbies.Delete(lobby.ID)
@@ -113,17 +155,74 @@ func (manager *Manager) getWorld(lobby *lobby.Lobby) (*World, error) {
 		return newWorld, nil
 	}
 
-	// If a real player is joining and there are bots, remove one bot
-	if activeWorld.BotManager != nil && activeWorld.BotManager.GetBotCount() > 0 {
-		activeWorld.BotManager.RemoveOneBot()
+	if err := activeWorld.Admit(spectate); err != nil {
+		return nil, err
 	}
+	return activeWorld, nil
+}
 
-	if activeWorld.IsLobbyFull() {
-		log.Warn().Any("lobby_state", activeWorld).Msgf("lobby is full, more players are not allowed")
-		return nil, fmt.Errorf("lobby is vol")
+// recordMatch stores the finished match and a score per human player,
+// lobbies that never started a match leave no record
+func (manager *Manager) recordMatch(lobbyInfo *lobby.Lobby, world *World, gameOverInfo GameOverInfo) *match.Match {
+	if manager.matchService == nil || !world.MatchStarted {
+		return nil
 	}
 
-	return activeWorld, nil
+	record := &match.Match{
+		LobbyID:   lobbyInfo.ID,
+		LobbyName: lobbyInfo.LobbyName,
+		GameMode:  string(world.Rules.Mode()),
+		Duration:  int(world.MatchDuration(world.Clock().Now()).Seconds()),
+		Reason:    gameOverInfo.Reason,
+		Winner:    gameOverInfo.Winner,
+	}
+
+	var scores []user.Score
+	for _, result := range world.MatchResults(gameOverInfo.Winner) {
+		scores = append(scores, user.Score{
+			UserID:            result.UserID,
+			Username:          result.Username,
+			Score:             result.Score,
+			PelletsCollected:  result.PelletsCollected,
+			PlayersEliminated: result.PlayersEliminated,
+			ChasersEaten:      result.ChasersEaten,
+			Won:               result.Won,
+		})
+	}
+
+	if err := manager.matchService.RecordMatch(record, scores); err != nil {
+		pkg.Elog(err)
+		return nil
+	}
+	return record
+}
+
+// saveReplay writes the recording of a finished match to disk and indexes it
+// by the match id
+func (manager *Manager) saveReplay(record *match.Match, world *World) {
+	if world.recorder == nil || !world.recorder.Started() {
+		return
+	}
+
+	replay := world.recorder.Replay(ReplayHeader{
+		MatchID: record.ID,
+		LobbyID: record.LobbyID,
+		Mode:    world.Rules.Mode(),
+		Seed:    world.Seed,
+	})
+	path, size, err := SaveReplayFile(manager.replayDir, replay)
+	if err != nil {
+		log.Error().Err(err).Uint("match", record.ID).Msg("Unable to write replay")
+		return
+	}
+
+	pkg.Elog(manager.matchService.SaveReplay(&match.Replay{
+		MatchID:    record.ID,
+		Path:       path,
+		Size:       size,
+		DurationMs: replay.Header.DurationMs,
+	}))
+	log.Info().Uint("match", record.ID).Int64("bytes", size).Int("frames", len(replay.Frames)).Msg("Replay saved")
 }
 
 func (manager *Manager) getUserAndLobbyInfo(newPlayerSession *melody.Session) (*user.User, *lobby.Lobby, error) {
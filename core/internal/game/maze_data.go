@@ -12,60 +12,34 @@ type MazeData struct {
 	Walls   [][]bool          // true = wall
 	Pellets map[string]bool   // "x_y" -> exists
 	PowerUps map[string]bool  // "x_y" -> exists
+	Tunnels []Tunnel
+	// 1 = wall, 0 = path/pellet, 2 = power-up, 3 = ghost house, 4 = empty (no pellet)
+	layout  [][]int
 	mu      sync.RWMutex
 }
 
-// Standard Pac-Man maze layout (28x31)
-// 1 = wall, 0 = path/pellet, 2 = power-up, 3 = ghost house, 4 = empty (no pellet)
-var StandardMazeLayout = [][]int{
-	{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
-	{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
-	{1, 0, 1, 1, 1, 1, 0, 1, 1, 1, 1, 1, 0, 1, 1, 0, 1, 1, 1, 1, 1, 0, 1, 1, 1, 1, 0, 1},
-	{1, 2, 1, 1, 1, 1, 0, 1, 1, 1, 1, 1, 0, 1, 1, 0, 1, 1, 1, 1, 1, 0, 1, 1, 1, 1, 2, 1},
-	{1, 0, 1, 1, 1, 1, 0, 1, 1, 1, 1, 1, 0, 1, 1, 0, 1, 1, 1, 1, 1, 0, 1, 1, 1, 1, 0, 1},
-	{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
-	{1, 0, 1, 1, 1, 1, 0, 1, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 0, 1, 1, 0, 1, 1, 1, 1, 0, 1},
-	{1, 0, 1, 1, 1, 1, 0, 1, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 0, 1, 1, 0, 1, 1, 1, 1, 0, 1},
-	{1, 0, 0, 0, 0, 0, 0, 1, 1, 0, 0, 0, 0, 1, 1, 0, 0, 0, 0, 1, 1, 0, 0, 0, 0, 0, 0, 1},
-	{1, 1, 1, 1, 1, 1, 0, 1, 1, 1, 1, 1, 4, 1, 1, 4, 1, 1, 1, 1, 1, 0, 1, 1, 1, 1, 1, 1},
-	{1, 1, 1, 1, 1, 1, 0, 1, 1, 1, 1, 1, 4, 1, 1, 4, 1, 1, 1, 1, 1, 0, 1, 1, 1, 1, 1, 1},
-	{1, 1, 1, 1, 1, 1, 0, 1, 1, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 1, 1, 0, 1, 1, 1, 1, 1, 1},
-	{1, 1, 1, 1, 1, 1, 0, 1, 1, 4, 1, 1, 1, 3, 3, 1, 1, 1, 4, 1, 1, 0, 1, 1, 1, 1, 1, 1},
-	{1, 1, 1, 1, 1, 1, 0, 1, 1, 4, 1, 3, 3, 3, 3, 3, 3, 1, 4, 1, 1, 0, 1, 1, 1, 1, 1, 1},
-	{4, 4, 4, 4, 4, 4, 0, 4, 4, 4, 1, 3, 3, 3, 3, 3, 3, 1, 4, 4, 4, 0, 4, 4, 4, 4, 4, 4},
-	{1, 1, 1, 1, 1, 1, 0, 1, 1, 4, 1, 3, 3, 3, 3, 3, 3, 1, 4, 1, 1, 0, 1, 1, 1, 1, 1, 1},
-	{1, 1, 1, 1, 1, 1, 0, 1, 1, 4, 1, 1, 1, 1, 1, 1, 1, 1, 4, 1, 1, 0, 1, 1, 1, 1, 1, 1},
-	{1, 1, 1, 1, 1, 1, 0, 1, 1, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 1, 1, 0, 1, 1, 1, 1, 1, 1},
-	{1, 1, 1, 1, 1, 1, 0, 1, 1, 4, 1, 1, 1, 1, 1, 1, 1, 1, 4, 1, 1, 0, 1, 1, 1, 1, 1, 1},
-	{1, 1, 1, 1, 1, 1, 0, 1, 1, 4, 1, 1, 1, 1, 1, 1, 1, 1, 4, 1, 1, 0, 1, 1, 1, 1, 1, 1},
-	{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
-	{1, 0, 1, 1, 1, 1, 0, 1, 1, 1, 1, 1, 0, 1, 1, 0, 1, 1, 1, 1, 1, 0, 1, 1, 1, 1, 0, 1},
-	{1, 0, 1, 1, 1, 1, 0, 1, 1, 1, 1, 1, 0, 1, 1, 0, 1, 1, 1, 1, 1, 0, 1, 1, 1, 1, 0, 1},
-	{1, 2, 0, 0, 1, 1, 0, 0, 0, 0, 0, 0, 0, 4, 4, 0, 0, 0, 0, 0, 0, 0, 1, 1, 0, 0, 2, 1},
-	{1, 1, 1, 0, 1, 1, 0, 1, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 0, 1, 1, 0, 1, 1, 0, 1, 1, 1},
-	{1, 1, 1, 0, 1, 1, 0, 1, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 0, 1, 1, 0, 1, 1, 0, 1, 1, 1},
-	{1, 0, 0, 0, 0, 0, 0, 1, 1, 0, 0, 0, 0, 1, 1, 0, 0, 0, 0, 1, 1, 0, 0, 0, 0, 0, 0, 1},
-	{1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 0, 1, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 0, 1},
-	{1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 0, 1, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 0, 1},
-	{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
-	{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
-}
-
-// NewMazeData creates a new MazeData from the standard layout
+// NewMazeData creates a new MazeData from the default map
 func NewMazeData() *MazeData {
+	return NewMazeDataFromMap(DefaultMazeMap())
+}
+
+// NewMazeDataFromMap creates the collision data of a map
+func NewMazeDataFromMap(mazeMap *MazeMap) *MazeData {
 	maze := &MazeData{
-		Width:    MazeWidth,
-		Height:   MazeHeight,
-		Walls:    make([][]bool, MazeHeight),
+		Width:    mazeMap.Width,
+		Height:   mazeMap.Height,
+		Walls:    make([][]bool, mazeMap.Height),
 		Pellets:  make(map[string]bool),
 		PowerUps: make(map[string]bool),
+		Tunnels:  mazeMap.Tunnels,
+		layout:   mazeMap.layout(),
 	}
 
 	// Initialize walls and pellets from layout
-	for y := 0; y < MazeHeight; y++ {
-		maze.Walls[y] = make([]bool, MazeWidth)
-		for x := 0; x < MazeWidth; x++ {
-			tile := StandardMazeLayout[y][x]
+	for y := 0; y < maze.Height; y++ {
+		maze.Walls[y] = make([]bool, maze.Width)
+		for x := 0; x < maze.Width; x++ {
+			tile := maze.layout[y][x]
 			switch tile {
 			case 1: // Wall
 				maze.Walls[y][x] = true
@@ -99,6 +73,30 @@ func (m *MazeData) IsWall(tileX, tileY int) bool {
 	return m.Walls[tileY][tileX]
 }
 
+// SetWall adds or removes a wall at a tile
+func (m *MazeData) SetWall(tileX, tileY int, wall bool) {
+	if tileX < 0 || tileX >= m.Width || tileY < 0 || tileY >= m.Height {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Walls[tileY][tileX] = wall
+}
+
+// PathGrid builds a pathfinding grid from the current walls
+func (m *MazeData) PathGrid() *PathGrid {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	grid := NewPathGrid(m.Width, m.Height)
+	for y, row := range m.Walls {
+		for x, wall := range row {
+			grid.SetWalkable(x, y, !wall)
+		}
+	}
+	return grid
+}
+
 // IsWalkable checks if a pixel position is walkable
 func (m *MazeData) IsWalkable(pixelX, pixelY float64) bool {
 	tileX, tileY := PixelToTile(pixelX, pixelY)
@@ -120,6 +118,28 @@ func (m *MazeData) CanMoveTo(fromX, fromY, toX, toY float64) bool {
 	return true
 }
 
+// TunnelExit returns where a move off the map comes out when it starts on a
+// tunnel end, the pixel center of the other end
+func (m *MazeData) TunnelExit(fromX, fromY, toX, toY float64) (float64, float64, bool) {
+	if toX >= 0 && toY >= 0 && toX < float64(m.Width)*TileSizeFloat && toY < float64(m.Height)*TileSizeFloat {
+		return 0, 0, false
+	}
+
+	tileX, tileY := PixelToTile(fromX, fromY)
+	from := TilePoint{X: tileX, Y: tileY}
+	for _, tunnel := range m.Tunnels {
+		switch from {
+		case tunnel.A:
+			x, y := TileToPixel(tunnel.B.X, tunnel.B.Y)
+			return x, y, true
+		case tunnel.B:
+			x, y := TileToPixel(tunnel.A.X, tunnel.A.Y)
+			return x, y, true
+		}
+	}
+	return 0, 0, false
+}
+
 // HasPellet checks if a pellet exists at the given tile
 func (m *MazeData) HasPellet(tileX, tileY int) bool {
 	m.mu.RLock()
@@ -165,6 +185,30 @@ func (m *MazeData) GetPelletCount() int {
 	return len(m.Pellets)
 }
 
+// GetPowerUpCount returns remaining power-up count
+func (m *MazeData) GetPowerUpCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.PowerUps)
+}
+
+// ClearPowerUps removes every power-up left and returns their tiles
+func (m *MazeData) ClearPowerUps() []TilePoint {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cleared := make([]TilePoint, 0, len(m.PowerUps))
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			if key := m.coordKey(x, y); m.PowerUps[key] {
+				delete(m.PowerUps, key)
+				cleared = append(cleared, TilePoint{X: x, Y: y})
+			}
+		}
+	}
+	return cleared
+}
+
 // Reset restores all pellets and power-ups
 func (m *MazeData) Reset() {
 	m.mu.Lock()
@@ -173,9 +217,9 @@ func (m *MazeData) Reset() {
 	m.Pellets = make(map[string]bool)
 	m.PowerUps = make(map[string]bool)
 	
-	for y := 0; y < MazeHeight; y++ {
-		for x := 0; x < MazeWidth; x++ {
-			tile := StandardMazeLayout[y][x]
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			tile := m.layout[y][x]
 			switch tile {
 			case 0:
 				m.Pellets[m.coordKey(x, y)] = true
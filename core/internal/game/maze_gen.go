@@ -0,0 +1,230 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// GeneratedMapName is the map name that makes a lobby play on a maze
+// generated from its seed
+const GeneratedMapName = "generated"
+
+// Generated mazes have the size of the classic map, each half is carved on
+// its own 14 columns
+const (
+	genWidth      = 28
+	genHeight     = 31
+	genHalfWidth  = genWidth / 2
+	genMaxRetries = 10
+)
+
+// MazeGenOptions tunes the maze generator
+type MazeGenOptions struct {
+	// Mirror makes the right half a mirror image of the left half
+	Mirror bool
+}
+
+// ResolveMazeMap returns the map a lobby plays on, a generated map is built
+// from the lobby's seed
+func ResolveMazeMap(name string, seed int64) (*MazeMap, error) {
+	if name == GeneratedMapName {
+		return GenerateMazeMap(seed, MazeGenOptions{Mirror: true})
+	}
+	return GetMazeMap(name)
+}
+
+// GenerateMazeMap builds a maze with walls, pellets, power-ups, a chaser house
+// and a tunnel from a seed, the same seed always gives the same maze. Every
+// pellet, power-up and spawn is checked to be reachable from the runner spawn.
+func GenerateMazeMap(seed int64, opts MazeGenOptions) (*MazeMap, error) {
+	var lastErr error
+	for attempt := int64(0); attempt < genMaxRetries; attempt++ {
+		m := generateMaze(rand.New(rand.NewSource(seed+attempt)), opts)
+		m.Seed = seed
+
+		if lastErr = m.validate(); lastErr != nil {
+			continue
+		}
+		if lastErr = verifyReachable(m); lastErr != nil {
+			continue
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("unable to generate maze for seed %d: %v", seed, lastErr)
+}
+
+func generateMaze(rng *rand.Rand, opts MazeGenOptions) *MazeMap {
+	// Layout choices shared by both halves so they line up in the middle
+	tunnelRow := 9 + 2*rng.Intn(7)
+	powerRows := [2]int{1 + 2*rng.Intn(3), 21 + 2*rng.Intn(3)}
+	crossings := map[int]bool{23: true} // the runner spawns on the bottom crossing
+	crossings[1+2*rng.Intn(5)] = true
+	for y := 1; y < genHeight-1; y += 2 {
+		if (y < 11 || y > 17) && rng.Float64() < 0.25 {
+			crossings[y] = true
+		}
+	}
+
+	left := generateHalf(rng, tunnelRow, powerRows, crossings)
+	right := left
+	if !opts.Mirror {
+		right = generateHalf(rng, tunnelRow, powerRows, crossings)
+	}
+
+	tiles := make([]string, genHeight)
+	for y := range tiles {
+		row := make([]byte, genWidth)
+		for x := 0; x < genHalfWidth; x++ {
+			row[x] = left[y][x]
+			row[genWidth-1-x] = right[y][x]
+		}
+		tiles[y] = string(row)
+	}
+
+	return &MazeMap{
+		Name:  GeneratedMapName,
+		Tiles: tiles,
+		Spawns: map[SpriteType]TilePoint{
+			Runner:  {X: 14, Y: 23},
+			Chaser1: {X: 12, Y: 11},
+			Chaser2: {X: 14, Y: 11},
+			Chaser3: {X: 16, Y: 11},
+		},
+		Tunnels: []Tunnel{{A: TilePoint{X: 0, Y: tunnelRow}, B: TilePoint{X: genWidth - 1, Y: tunnelRow}}},
+	}
+}
+
+// generateHalf carves the left half of a maze: a randomized depth-first maze
+// over cells on odd coordinates with extra loops, then the chaser house, the
+// crossings to the other half, the tunnel and the power-ups on top
+func generateHalf(rng *rand.Rand, tunnelRow int, powerRows [2]int, crossings map[int]bool) [][]byte {
+	half := make([][]byte, genHeight)
+	for y := range half {
+		half[y] = make([]byte, genHalfWidth)
+		for x := range half[y] {
+			half[y][x] = mapWall
+		}
+	}
+
+	// Cells inside the chaser house are left out of the maze
+	isCell := func(x, y int) bool {
+		if x < 1 || x > 11 || y < 1 || y > genHeight-2 || x%2 == 0 || y%2 == 0 {
+			return false
+		}
+		return !(x == 11 && (y == 13 || y == 15))
+	}
+	steps := [][2]int{{0, -2}, {0, 2}, {-2, 0}, {2, 0}}
+
+	for y := 1; y < genHeight-1; y += 2 {
+		for x := 1; x <= 11; x += 2 {
+			if isCell(x, y) {
+				half[y][x] = mapPellet
+			}
+		}
+	}
+
+	// Depth-first carving gives a spanning tree, so every cell is connected
+	visited := map[TilePoint]bool{{X: 1, Y: 1}: true}
+	stack := []TilePoint{{X: 1, Y: 1}}
+	for len(stack) > 0 {
+		cell := stack[len(stack)-1]
+		var options []TilePoint
+		for _, step := range steps {
+			next := TilePoint{X: cell.X + step[0], Y: cell.Y + step[1]}
+			if isCell(next.X, next.Y) && !visited[next] {
+				options = append(options, next)
+			}
+		}
+		if len(options) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		next := options[rng.Intn(len(options))]
+		half[(cell.Y+next.Y)/2][(cell.X+next.X)/2] = mapPellet
+		visited[next] = true
+		stack = append(stack, next)
+	}
+
+	// Extra loops, and no dead ends so nobody gets cornered without a way out
+	for y := 1; y < genHeight-1; y += 2 {
+		for x := 1; x <= 11; x += 2 {
+			if !isCell(x, y) {
+				continue
+			}
+			var closed []TilePoint
+			open := 0
+			for _, step := range steps {
+				next := TilePoint{X: x + step[0], Y: y + step[1]}
+				if !isCell(next.X, next.Y) {
+					continue
+				}
+				if half[y+step[1]/2][x+step[0]/2] == mapWall {
+					closed = append(closed, next)
+				} else {
+					open++
+				}
+			}
+			if len(closed) > 0 && (open <= 1 || rng.Float64() < 0.15) {
+				next := closed[rng.Intn(len(closed))]
+				half[(y+next.Y)/2][(x+next.X)/2] = mapPellet
+			}
+		}
+	}
+
+	for y := range crossings {
+		half[y][12] = mapPellet
+		half[y][13] = mapPellet
+	}
+
+	// Chaser house with an empty ring around it, the door opens at the top
+	for y := 11; y <= 17; y++ {
+		half[y][9] = mapEmpty
+	}
+	for x := 9; x < genHalfWidth; x++ {
+		half[11][x] = mapEmpty
+		half[17][x] = mapEmpty
+	}
+	for x := 10; x < genHalfWidth; x++ {
+		half[12][x] = mapWall
+		half[16][x] = mapWall
+	}
+	half[12][13] = mapHouse
+	for y := 13; y <= 15; y++ {
+		half[y][10] = mapWall
+		for x := 11; x < genHalfWidth; x++ {
+			half[y][x] = mapHouse
+		}
+	}
+
+	half[tunnelRow][0] = mapEmpty
+	half[powerRows[0]][1] = mapPowerUp
+	half[powerRows[1]][1] = mapPowerUp
+	half[23][13] = mapEmpty // runner spawn
+
+	return half
+}
+
+// verifyReachable checks with the AStarPathfinder that every pellet,
+// power-up and spawn can be reached from the runner spawn
+func verifyReachable(m *MazeMap) error {
+	pathfinder := NewAStarPathfinder(NewPathGridFromWalkGrid(m.WalkGrid()))
+
+	start := m.Spawns[Runner]
+	reachable := func(target TilePoint) bool {
+		return pathfinder.FindPath(start.X, start.Y, target.X, target.Y) != nil
+	}
+
+	for _, spawn := range m.Spawns {
+		if !reachable(spawn) {
+			return fmt.Errorf("spawn %d,%d cannot be reached", spawn.X, spawn.Y)
+		}
+	}
+	for y, row := range m.Tiles {
+		for x, tile := range row {
+			if (tile == mapPellet || tile == mapPowerUp) && !reachable(TilePoint{X: x, Y: y}) {
+				return fmt.Errorf("tile %d,%d cannot be reached", x, y)
+			}
+		}
+	}
+	return nil
+}
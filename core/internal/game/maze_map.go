@@ -0,0 +1,362 @@
+package game
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
+)
+
+// Map files, one JSON MazeMap per file, see maps/classic.json
+//
+//go:embed maps/*.json
+var mapFiles embed.FS
+
+// DefaultMapName is the map used when a lobby does not pick one
+const DefaultMapName = "classic"
+
+// Map tiles, one character per tile
+const (
+	mapWall    = '#'
+	mapPellet  = '.'
+	mapPowerUp = 'o'
+	mapHouse   = 'h' // chaser house, walkable without pellet
+	mapEmpty   = ' ' // walkable without pellet
+)
+
+// Tunnel connects two border tiles, walking off the map at one end comes
+// out at the other
+type Tunnel struct {
+	A TilePoint `json:"a"`
+	B TilePoint `json:"b"`
+}
+
+// ZoneHint places a DynamicWorld zone on a map
+type ZoneHint struct {
+	Type   ZoneType `json:"type"`
+	X      int      `json:"x"`
+	Y      int      `json:"y"`
+	Width  int      `json:"width"`
+	Height int      `json:"height"`
+}
+
+// MazeMap is a playable maze: its tiles, a spawn point per sprite, tunnels
+// and zone hints. Power-up spots and the pellet total follow from the tiles.
+type MazeMap struct {
+	Name    string                   `json:"name"`
+	Tiles   []string                 `json:"tiles"`
+	Spawns  map[SpriteType]TilePoint `json:"spawns"`
+	Tunnels []Tunnel                 `json:"tunnels"`
+	Zones   []ZoneHint               `json:"zones"`
+	// Seed the map was generated from, 0 for map files
+	Seed int64 `json:"seed,omitempty"`
+
+	Width        int         `json:"-"`
+	Height       int         `json:"-"`
+	PowerUps     []TilePoint `json:"-"`
+	TotalPellets int         `json:"-"`
+}
+
+var mazeMaps = mustLoadMazeMaps()
+
+// LoadMazeMap parses and validates a map file
+func LoadMazeMap(data []byte) (*MazeMap, error) {
+	m := &MazeMap{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("invalid map file: %v", err)
+	}
+	if err := m.validate(); err != nil {
+		return nil, fmt.Errorf("map %q: %v", m.Name, err)
+	}
+	return m, nil
+}
+
+// GetMazeMap returns a map by name, an empty name gives the default map
+func GetMazeMap(name string) (*MazeMap, error) {
+	if name == "" {
+		name = DefaultMapName
+	}
+	m, ok := mazeMaps[name]
+	if !ok {
+		return nil, fmt.Errorf("onbekende map: %s", name)
+	}
+	return m, nil
+}
+
+// DefaultMazeMap returns the map used when a lobby does not pick one
+func DefaultMazeMap() *MazeMap {
+	return mazeMaps[DefaultMapName]
+}
+
+// MapNames lists the available maps, the default first and the generated
+// map last
+func MapNames() []string {
+	names := []string{DefaultMapName}
+	for name := range mazeMaps {
+		if name != DefaultMapName {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+	return append(names, GeneratedMapName)
+}
+
+func mustLoadMazeMaps() map[string]*MazeMap {
+	files, err := mapFiles.ReadDir("maps")
+	if err != nil {
+		panic(err)
+	}
+
+	maps := map[string]*MazeMap{}
+	for _, file := range files {
+		data, err := mapFiles.ReadFile(path.Join("maps", file.Name()))
+		if err != nil {
+			panic(err)
+		}
+		m, err := LoadMazeMap(data)
+		if err != nil {
+			panic(err)
+		}
+		maps[m.Name] = m
+	}
+
+	if _, ok := maps[DefaultMapName]; !ok {
+		panic("default map " + DefaultMapName + " is missing")
+	}
+	return maps
+}
+
+func (m *MazeMap) validate() error {
+	if m.Name == "" {
+		return fmt.Errorf("name is missing")
+	}
+	if len(m.Tiles) == 0 || len(m.Tiles[0]) == 0 {
+		return fmt.Errorf("tiles are missing")
+	}
+
+	m.Height = len(m.Tiles)
+	m.Width = len(m.Tiles[0])
+	m.PowerUps = nil
+	m.TotalPellets = 0
+	for y, row := range m.Tiles {
+		if len(row) != m.Width {
+			return fmt.Errorf("row %d is %d tiles wide, expected %d", y, len(row), m.Width)
+		}
+		for x, tile := range row {
+			switch tile {
+			case mapPellet:
+				m.TotalPellets++
+			case mapPowerUp:
+				m.PowerUps = append(m.PowerUps, TilePoint{X: x, Y: y})
+			case mapWall, mapHouse, mapEmpty:
+			default:
+				return fmt.Errorf("unknown tile %q at %d,%d", tile, x, y)
+			}
+		}
+	}
+
+	for _, sprite := range []SpriteType{Chaser1, Chaser2, Chaser3, Runner} {
+		spawn, ok := m.Spawns[sprite]
+		if !ok {
+			return fmt.Errorf("spawn for %s is missing", sprite)
+		}
+		if !m.walkable(spawn.X, spawn.Y) {
+			return fmt.Errorf("spawn for %s is not on a walkable tile", sprite)
+		}
+	}
+
+	for i, tunnel := range m.Tunnels {
+		for _, end := range []TilePoint{tunnel.A, tunnel.B} {
+			if !m.walkable(end.X, end.Y) || !m.onBorder(end) {
+				return fmt.Errorf("tunnel %d must connect walkable border tiles", i)
+			}
+		}
+	}
+
+	for i, zone := range m.Zones {
+		switch zone.Type {
+		case ZoneSafe, ZoneNeutral, ZoneDanger:
+		default:
+			return fmt.Errorf("zone %d has unknown type %q", i, zone.Type)
+		}
+		if zone.Width <= 0 || zone.Height <= 0 || zone.X < 0 || zone.Y < 0 ||
+			zone.X+zone.Width > m.Width || zone.Y+zone.Height > m.Height {
+			return fmt.Errorf("zone %d lies outside the map", i)
+		}
+	}
+
+	return m.validateConnectivity()
+}
+
+// validateConnectivity checks every walkable tile can be reached from the
+// runner spawn, so no pellet or spawn is cut off
+func (m *MazeMap) validateConnectivity() error {
+	start := m.Spawns[Runner]
+	seen := map[TilePoint]bool{start: true}
+	queue := []TilePoint{start}
+
+	for len(queue) > 0 {
+		tile := queue[0]
+		queue = queue[1:]
+		for _, next := range m.neighbours(tile) {
+			if !seen[next] && m.walkable(next.X, next.Y) {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	for y, row := range m.Tiles {
+		for x := range row {
+			if m.walkable(x, y) && !seen[TilePoint{X: x, Y: y}] {
+				return fmt.Errorf("tile %d,%d cannot be reached", x, y)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *MazeMap) neighbours(tile TilePoint) []TilePoint {
+	result := []TilePoint{
+		{X: tile.X, Y: tile.Y - 1},
+		{X: tile.X, Y: tile.Y + 1},
+		{X: tile.X - 1, Y: tile.Y},
+		{X: tile.X + 1, Y: tile.Y},
+	}
+	for _, tunnel := range m.Tunnels {
+		switch tile {
+		case tunnel.A:
+			result = append(result, tunnel.B)
+		case tunnel.B:
+			result = append(result, tunnel.A)
+		}
+	}
+	return result
+}
+
+func (m *MazeMap) walkable(x, y int) bool {
+	if x < 0 || x >= m.Width || y < 0 || y >= m.Height {
+		return false
+	}
+	return m.Tiles[y][x] != mapWall
+}
+
+func (m *MazeMap) onBorder(tile TilePoint) bool {
+	return tile.X == 0 || tile.Y == 0 || tile.X == m.Width-1 || tile.Y == m.Height-1
+}
+
+// layout converts the tiles to MazeData codes:
+// 1 = wall, 0 = path/pellet, 2 = power-up, 3 = ghost house, 4 = empty (no pellet)
+func (m *MazeMap) layout() [][]int {
+	codes := map[rune]int{mapWall: 1, mapPellet: 0, mapPowerUp: 2, mapHouse: 3, mapEmpty: 4}
+	layout := make([][]int, m.Height)
+	for y, row := range m.Tiles {
+		layout[y] = make([]int, m.Width)
+		for x, tile := range row {
+			layout[y][x] = codes[tile]
+		}
+	}
+	return layout
+}
+
+// WalkGrid returns the EntityManager grid, 0 = walkable, 1 = wall
+func (m *MazeMap) WalkGrid() [][]int {
+	grid := make([][]int, m.Height)
+	for y := range grid {
+		grid[y] = make([]int, m.Width)
+		for x := range grid[y] {
+			if !m.walkable(x, y) {
+				grid[y][x] = 1
+			}
+		}
+	}
+	return grid
+}
+
+// zones turns the zone hints into DynamicWorld zones
+func (m *MazeMap) zones() []Zone {
+	zones := make([]Zone, 0, len(m.Zones))
+	for i, hint := range m.Zones {
+		zones = append(zones, Zone{
+			ID:       i,
+			Type:     hint.Type,
+			X:        hint.X,
+			Y:        hint.Y,
+			Width:    hint.Width,
+			Height:   hint.Height,
+			IsActive: true,
+		})
+	}
+	return zones
+}
+
+// Spawn returns the spawn tile of a sprite, unknown sprites use the runner spawn
+func (m *MazeMap) Spawn(sprite SpriteType) TilePoint {
+	if spawn, ok := m.Spawns[sprite]; ok {
+		return spawn
+	}
+	return m.Spawns[Runner]
+}
+
+// House returns the chaser house tile eaten chasers return to, the house
+// tile nearest to the middle of the house, or the sprite's spawn on maps
+// without a house
+func (m *MazeMap) House(sprite SpriteType) TilePoint {
+	var tiles []TilePoint
+	var sumX, sumY int
+	for y, row := range m.Tiles {
+		for x, tile := range row {
+			if tile == mapHouse {
+				tiles = append(tiles, TilePoint{X: x, Y: y})
+				sumX += x
+				sumY += y
+			}
+		}
+	}
+	if len(tiles) == 0 {
+		return m.Spawn(sprite)
+	}
+
+	// Compare in units of 1/len(tiles) tiles to stay in integers
+	best, bestDist := tiles[0], -1
+	for _, tile := range tiles {
+		dist := abs(tile.X*len(tiles)-sumX) + abs(tile.Y*len(tiles)-sumY)
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = tile, dist
+		}
+	}
+	return best
+}
+
+// SpawnPixels returns spawn positions in pixel coordinates
+func (m *MazeMap) SpawnPixels() map[string]*gamev1.Point {
+	result := make(map[string]*gamev1.Point)
+	for spriteType, tilePos := range m.Spawns {
+		x, y := TileToPixel(tilePos.X, tilePos.Y)
+		result[string(spriteType)] = &gamev1.Point{X: x, Y: y}
+	}
+	return result
+}
+
+// ClientInfo is the map as sent to clients in the state report
+func (m *MazeMap) ClientInfo() *gamev1.MapInfo {
+	tunnels := make([]*gamev1.Tunnel, 0, len(m.Tunnels))
+	for _, tunnel := range m.Tunnels {
+		tunnels = append(tunnels, &gamev1.Tunnel{
+			A: &gamev1.TilePos{X: int32(tunnel.A.X), Y: int32(tunnel.A.Y)},
+			B: &gamev1.TilePos{X: int32(tunnel.B.X), Y: int32(tunnel.B.Y)},
+		})
+	}
+	return &gamev1.MapInfo{
+		Name:         m.Name,
+		Width:        int32(m.Width),
+		Height:       int32(m.Height),
+		Tiles:        m.Tiles,
+		Tunnels:      tunnels,
+		TotalPellets: int32(m.TotalPellets),
+		Seed:         m.Seed,
+	}
+}
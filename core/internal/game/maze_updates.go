@@ -0,0 +1,120 @@
+package game
+
+// applyMazeUpdateLocked applies a DynamicWorld maze update to the server's
+// collision: MazeData, the pathfinding grid and the entity layout. Unsafe
+// updates are refused. (called from the loop with worldLock held)
+func (w *World) applyMazeUpdateLocked(update MazeUpdate) bool {
+	switch update.Type {
+	case "wall_add":
+		if !w.canAddWallLocked(update.X, update.Y) {
+			return false
+		}
+		w.setWallLocked(update.X, update.Y, true)
+	case "wall_remove":
+		if !w.canRemoveWallLocked(update.X, update.Y) {
+			return false
+		}
+		w.setWallLocked(update.X, update.Y, false)
+	default:
+		return false
+	}
+	return true
+}
+
+func (w *World) setWallLocked(x, y int, wall bool) {
+	w.MazeData.SetWall(x, y, wall)
+	w.PathGrid.SetWalkable(x, y, !wall)
+	w.EntityManager.SetWall(x, y, wall)
+}
+
+// canAddWallLocked refuses walls on the border, the chaser house, tunnels,
+// spawns, pellets, power-ups and players, and walls that would cut the maze
+// in two
+func (w *World) canAddWallLocked(x, y int) bool {
+	if !w.insideMazeLocked(x, y) || w.MazeData.IsWall(x, y) {
+		return false
+	}
+	if w.Map.Tiles[y][x] == mapHouse || w.MazeData.HasPellet(x, y) || w.MazeData.HasPowerUp(x, y) {
+		return false
+	}
+
+	tile := TilePoint{X: x, Y: y}
+	for _, tunnel := range w.MazeData.Tunnels {
+		if tile == tunnel.A || tile == tunnel.B {
+			return false
+		}
+	}
+	for _, spawn := range w.Map.Spawns {
+		if tile == spawn {
+			return false
+		}
+	}
+	for _, player := range w.Players {
+		if tileX, tileY := PixelToTile(player.X, player.Y); tileX == x && tileY == y {
+			return false
+		}
+	}
+
+	return w.mazeConnectedWithWallLocked(tile)
+}
+
+// canRemoveWallLocked refuses the border and the walls around the chaser
+// house, and walls that would open up a pocket nobody can reach
+func (w *World) canRemoveWallLocked(x, y int) bool {
+	if !w.insideMazeLocked(x, y) || !w.MazeData.IsWall(x, y) {
+		return false
+	}
+
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if w.Map.Tiles[y+dy][x+dx] == mapHouse {
+				return false
+			}
+		}
+	}
+
+	for _, next := range w.Map.neighbours(TilePoint{X: x, Y: y}) {
+		if !w.MazeData.IsWall(next.X, next.Y) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *World) insideMazeLocked(x, y int) bool {
+	return x > 0 && y > 0 && x < w.MazeData.Width-1 && y < w.MazeData.Height-1
+}
+
+// mazeConnectedWithWallLocked checks every walkable tile can still reach
+// every other one when a wall is placed at the given tile
+func (w *World) mazeConnectedWithWallLocked(wall TilePoint) bool {
+	walkable := func(tile TilePoint) bool {
+		return tile != wall && !w.MazeData.IsWall(tile.X, tile.Y)
+	}
+
+	start, total := TilePoint{}, 0
+	for y := 0; y < w.MazeData.Height; y++ {
+		for x := 0; x < w.MazeData.Width; x++ {
+			if tile := (TilePoint{X: x, Y: y}); walkable(tile) {
+				if total == 0 {
+					start = tile
+				}
+				total++
+			}
+		}
+	}
+
+	seen := map[TilePoint]bool{start: true}
+	queue := []TilePoint{start}
+	for len(queue) > 0 {
+		tile := queue[0]
+		queue = queue[1:]
+		for _, next := range w.Map.neighbours(tile) {
+			if !seen[next] && walkable(next) {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return len(seen) == total
+}
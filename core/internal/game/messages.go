@@ -1,10 +1,11 @@
 package game
 
 import (
-	"encoding/json"
 	"fmt"
-	"github.com/frank2889/mazechase/pkg"
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
+	"github.com/olahol/melody"
 	"github.com/rs/zerolog/log"
+	"math"
 	"time"
 )
 
@@ -12,9 +13,10 @@ type MessageData struct {
 	msgInfo       map[string]interface{}
 	world         *World
 	playerSession *PlayerEntity
+	session       *melody.Session
 }
 
-type MessageHandlerFunc func(data MessageData) map[string]interface{}
+type MessageHandlerFunc func(data MessageData) *gamev1.Envelope
 
 type MessageHandler struct {
 	handler     MessageHandlerFunc
@@ -27,7 +29,7 @@ func (handler MessageHandler) WithMiddleware(middleware func(MessageHandlerFunc)
 }
 
 func CheckGameOverMiddleware(existingFunc MessageHandlerFunc) MessageHandlerFunc {
-	return func(data MessageData) map[string]interface{} {
+	return func(data MessageData) *gamev1.Envelope {
 		encodedMsg := existingFunc(data)
 		reason, winner := data.world.checkGameOver() // verify the state after message has been handled
 		if reason != "" {
@@ -38,27 +40,15 @@ func CheckGameOverMiddleware(existingFunc MessageHandlerFunc) MessageHandlerFunc
 	}
 }
 
-// CheckCollisionMiddleware checks for player-player collisions after movement
-func CheckCollisionMiddleware(existingFunc MessageHandlerFunc) MessageHandlerFunc {
-	return func(data MessageData) map[string]interface{} {
-		result := existingFunc(data)
-		
-		// Check for runner-chaser collision
-		collided, _, chaserId := data.world.CheckPlayerCollisions()
-		if collided {
-			if data.world.IsPoweredUp {
-				// Runner eats chaser
-				data.world.ChaserEatenAction(chaserId)
-				if result != nil {
-					result["chaserEaten"] = string(chaserId)
-				}
-			} else {
-				// Chaser catches runner - game over!
-				data.world.GameOver("Runner is gevangen!", "Chasers")
-			}
+// RejectSpectatorMiddleware drops messages of spectators, they watch the
+// match but cannot play in it
+func RejectSpectatorMiddleware(existingFunc MessageHandlerFunc) MessageHandlerFunc {
+	return func(data MessageData) *gamev1.Envelope {
+		if data.playerSession.IsSpectator {
+			log.Warn().Str("player", data.playerSession.PlayerId).Msg("Rejected message from spectator")
+			return nil
 		}
-		
-		return result
+		return existingFunc(data)
 	}
 }
 
@@ -86,76 +76,46 @@ func registerMessageHandlers(opts ...MessageHandler) map[string]MessageHandlerFu
 	return handlers
 }
 
+// MovMessage queues a movement input, the world loop applies it on the next
+// tick and broadcasts the result in its snapshot
 func MovMessage() MessageHandler {
 	name := "pos"
 	return MessageHandler{
 		messageName: name,
-		handler: func(data MessageData) map[string]interface{} {
+		handler: func(data MessageData) *gamev1.Envelope {
 			// Get direction from message (new 3D mode)
 			dir, hasDir := data.msgInfo["dir"].(string)
 			
 			if hasDir && dir != "" {
-				// Direction-based movement: server calculates new position
-				newX, newY, moved := data.world.MovePlayerByDirection(data.playerSession, dir)
-				if !moved {
-					return nil // Wall collision, don't broadcast
-				}
-				
-				data.playerSession.Type = name
-				data.playerSession.Dir = dir
-				data.playerSession.X = newX
-				data.playerSession.Y = newY
-				
-				// Check if pellet was eaten
-				tileX, tileY := PixelToTile(newX, newY)
-				pelletEaten := false
-				powerUpEaten := false
-				
-				// Check last eaten pellet
-				if data.world.PelletsCoordEaten.Len() > 0 {
-					lastPellet := data.world.PelletsCoordEaten.GetLast()
-					if lastPellet != nil && int(lastPellet[0]) == tileX && int(lastPellet[1]) == tileY {
-						pelletEaten = true
-					}
+				if err := data.world.Validator.ValidateDirection(dir); err != nil {
+					data.world.AntiCheat.Report(data.playerSession.PlayerId, ViolationInvalidInput, err)
+					return nil
 				}
-				
-				// Check last eaten power-up
-				if data.world.PowerUpsCoordsEaten.Len() > 0 {
-					lastPowerUp := data.world.PowerUpsCoordsEaten.GetLast()
-					if lastPowerUp != nil && int(lastPowerUp[0]) == tileX && int(lastPowerUp[1]) == tileY {
-						powerUpEaten = true
-					}
-				}
-				
-				result := data.playerSession.ToMap()
-				if pelletEaten {
-					result["pellet"] = map[string]int{"x": tileX, "y": tileY}
-					result["score"] = data.world.GetScore(data.playerSession.PlayerId)
-				}
-				if powerUpEaten {
-					result["powerUp"] = map[string]int{"x": tileX, "y": tileY}
-					result["powered"] = true
-				}
-				
-				return result
+
+				// Direction-based movement: server calculates new position
+				data.world.QueueInput(PlayerInput{
+					PlayerId: data.playerSession.PlayerId,
+					Dir:      dir,
+				})
+				return nil
 			}
 			
-			// Legacy: x/y coordinates from message (old mode)
+			// Legacy: x/y coordinates from message (old mode), the loop
+			// rejects positions the player cannot reach
 			x, y, err := getCoordFromMessage(data.msgInfo)
 			if err != nil {
-				log.Error().Err(err).
-					Any("data", data.msgInfo).
-					Msg("Unable to find coordinates or direction from message")
+				data.world.AntiCheat.Report(data.playerSession.PlayerId, ViolationInvalidInput, err)
 				return nil
 			}
 
-			data.world.MovePlayer(data.playerSession, x, y)
-			data.playerSession.Type = name
-			if dir != "" {
-				data.playerSession.Dir = dir
-			}
-
-			return data.playerSession.ToMap()
+			data.world.QueueInput(PlayerInput{
+				PlayerId: data.playerSession.PlayerId,
+				Dir:      dir,
+				HasPos:   true,
+				X:        x,
+				Y:        y,
+			})
+			return nil
 		},
 	}
 }
@@ -164,113 +124,96 @@ func EndGameMessage(reason string, winner string) MessageHandler {
 	mesName := "gameover"
 	return MessageHandler{
 		messageName: mesName,
-		handler: func(data MessageData) map[string]interface{} {
-			// Get scores if world is available
+		handler: func(data MessageData) *gamev1.Envelope {
+			// Get scores, names, the level and lives if world is available
 			scores := map[string]int{}
+			names := map[string]string{}
+			var level, lives int32
 			if data.world != nil {
 				scores = data.world.GetAllScores()
+				names = data.world.ParticipantNames()
+				level, lives = data.world.Progress()
 			}
 			
-			return map[string]interface{}{
-				"type":   mesName,
-				"reason": reason,
-				"winner": winner,
-				"scores": scores,
-			}
+			return &gamev1.Envelope{Payload: &gamev1.Envelope_Gameover{Gameover: &gamev1.GameOver{
+				Reason: reason,
+				Winner: winner,
+				Scores: protoScores(scores),
+				Names:  names,
+				Level:  level,
+				Lives:  lives,
+			}}}
 		},
 	}
 }
 
+// PelletMessage checks a client claim of an eaten pellet, the server eats
+// pellets itself on movement and only accepts claims it agrees with
 func PelletMessage() MessageHandler {
 	name := "pel"
 	return MessageHandler{
 		messageName: name,
-		handler: func(data MessageData) map[string]interface{} {
+		handler: func(data MessageData) *gamev1.Envelope {
 			x, y, err := getCoordFromMessage(data.msgInfo)
 			if err != nil {
-				log.Error().Err(err).Any("msg", data.msgInfo).Msg("Unable to find coordinates or direction from message")
+				data.world.AntiCheat.Report(data.playerSession.PlayerId, ViolationInvalidInput, err)
 				return nil
 			}
-			data.world.EatPellet(x, y)
 
-			return map[string]interface{}{
-				"type": name,
-				"x":    x,
-				"y":    y,
+			// an accepted pellet goes out with the next snapshot
+			if err := data.world.ClaimPellet(data.playerSession, x, y); err != nil {
+				data.world.AntiCheat.Report(data.playerSession.PlayerId, ViolationPelletClaim, err)
 			}
+			return nil
 		},
 	}
 }
 
-func PowerUpMessage(manager *Manager) MessageHandler {
+// PowerUpMessage checks a client claim of an eaten power-up like PelletMessage
+func PowerUpMessage() MessageHandler {
 	name := "pow"
 	return MessageHandler{
 		messageName: name,
-		handler: func(data MessageData) map[string]interface{} {
+		handler: func(data MessageData) *gamev1.Envelope {
 			x, y, err := getCoordFromMessage(data.msgInfo)
 			if err != nil {
+				data.world.AntiCheat.Report(data.playerSession.PlayerId, ViolationInvalidInput, err)
 				return nil
 			}
 
-			data.world.EatPowerUp(x, y)
-
-			time.AfterFunc(powerUpTime, func() {
-				mess := EndPowerUpMessage(data.world).handler(data)
-				marshal, err := json.Marshal(mess)
-				if err != nil {
-					log.Warn().Err(err).Msg("Unable to marshal json")
-					return
-				}
-
-				pkg.Elog(manager.broadcastAll(data.world, marshal))
-			})
-
-			return map[string]interface{}{
-				"type": name,
-				"x":    x,
-				"y":    y,
+			// the world loop broadcasts pow and ends the power-up with powend
+			if err := data.world.ClaimPowerUp(data.playerSession, x, y); err != nil {
+				data.world.AntiCheat.Report(data.playerSession.PlayerId, ViolationPowerUpClaim, err)
 			}
+			return nil
 		},
 	}
 }
 
+// KillPlayer checks a client claim that the runner and a chaser collided, the
+// world resolves the kill when it sees them touch
 func KillPlayer() MessageHandler {
 	name := "kill"
 	return MessageHandler{
 		messageName: name,
-		handler: func(data MessageData) map[string]interface{} {
+		handler: func(data MessageData) *gamev1.Envelope {
 			chaserId, exists := data.msgInfo["id"]
 			if !exists {
 				log.Warn().Any("msg", data.msgInfo).Msg("no chaser id found")
 				return nil
 			}
 
-			if data.world.IsPoweredUp {
-				data.world.ChaserEatenAction(SpriteType(chaserId.(string)))
-				return map[string]interface{}{
-					"type":     name, // chaser eliminated
-					"spriteId": chaserId,
-				}
-			}
-
-			data.world.GameOver("Runner is gevangen!", "Chasers")
-			return map[string]interface{}{
-				"type":     name,
-				"spriteId": Runner, // runner caught
+			// Only chasers can be part of a kill, modes without them ignore the claim
+			chaserSprite, ok := chaserId.(string)
+			if !ok || data.world.Rules.IsRunner(SpriteType(chaserSprite)) {
+				log.Warn().Any("msg", data.msgInfo).Msg("kill claim without a chaser")
+				return nil
 			}
-		},
-	}
-}
 
-func EndPowerUpMessage(lobbyEntity *World) MessageHandler {
-	mesName := "powend"
-	return MessageHandler{
-		messageName: mesName,
-		handler: func(data MessageData) map[string]interface{} {
-			lobbyEntity.IsPoweredUp = false
-			return map[string]interface{}{
-				"type": mesName,
+			if err := data.world.ClaimKill(data.playerSession, SpriteType(chaserSprite)); err != nil {
+				data.world.AntiCheat.Report(data.playerSession.PlayerId, ViolationKillClaim, err)
 			}
+			return nil
 		},
 	}
 }
@@ -280,37 +223,11 @@ func ReadyToggleMessage() MessageHandler {
 	name := "ready"
 	return MessageHandler{
 		messageName: name,
-		handler: func(data MessageData) map[string]interface{} {
+		handler: func(data MessageData) *gamev1.Envelope {
 			data.playerSession.IsReady = !data.playerSession.IsReady
 
 			// Return full lobby status so all clients get updated player list
-			players := []map[string]interface{}{}
-
-			for _, session := range data.world.ConnectedPlayers.GetValues() {
-				if session == nil {
-					continue
-				}
-				player, err := getPlayerEntityFromSession(session)
-				if err != nil {
-					continue
-				}
-				players = append(players, map[string]interface{}{
-					"playerId":   player.PlayerId,
-					"username":   player.Username,
-					"spriteType": player.SpriteType,
-					"isReady":    player.IsReady,
-					"isHost":     player.IsHost,
-				})
-			}
-
-			return map[string]interface{}{
-				"type":         "lobbystatus",
-				"players":      players,
-				"playerCount":  data.world.GetPlayerCount(),
-				"readyCount":   data.world.GetReadyCount(),
-				"matchStarted": data.world.MatchStarted,
-				"hostId":       data.world.HostPlayerId,
-			}
+			return data.world.LobbyStatus()
 		},
 	}
 }
@@ -320,13 +237,10 @@ func StartGameMessage(manager *Manager) MessageHandler {
 	name := "startgame"
 	return MessageHandler{
 		messageName: name,
-		handler: func(data MessageData) map[string]interface{} {
+		handler: func(data MessageData) *gamev1.Envelope {
 			// Only host can start
 			if !data.playerSession.IsHost {
-				return map[string]interface{}{
-					"type":  "error",
-					"error": "Alleen de host kan de game starten",
-				}
+				return errorMessage("Alleen de host kan de game starten")
 			}
 
 			// Check if already started
@@ -336,84 +250,146 @@ func StartGameMessage(manager *Manager) MessageHandler {
 
 			data.world.CountdownStarted = true
 
-			// Start countdown in goroutine
+			// Start countdown in goroutine, it runs on the world's clock
+			clock := data.world.Clock()
 			go func() {
 				for i := 3; i > 0; i-- {
-					countdownMsg := map[string]interface{}{
-						"type":  "countdown",
-						"count": i,
-					}
-					marshal, _ := json.Marshal(countdownMsg)
-					manager.broadcastAll(data.world, marshal)
-					time.Sleep(1 * time.Second)
+					manager.broadcastAll(data.world, &gamev1.Envelope{Payload: &gamev1.Envelope_Countdown{Countdown: &gamev1.Countdown{Count: int32(i)}}})
+					<-clock.After(1 * time.Second)
 				}
 
 				// Game start!
-				data.world.MatchStarted = true
+				data.world.StartMatch(clock.Now())
+				manager.lobbyService.SetLobbyMatchStarted(data.world.LobbyID, true)
 				
-				// Start dynamic systems with broadcast function
-				broadcastDynamic := func(msgType string, dynamicData interface{}) {
-					msg := map[string]interface{}{
-						"type": msgType,
-						"data": dynamicData,
-					}
-					marshal, err := json.Marshal(msg)
-					if err == nil {
-						manager.broadcastAll(data.world, marshal)
-					}
-				}
-				data.world.StartDynamicSystems(broadcastDynamic)
+				// Start dynamic systems, the world loop advances them
+				data.world.StartDynamicSystems()
 				
 				// Send game start with initial dynamic state
-				dynamicState := data.world.GetDynamicState()
-				startMsg := map[string]interface{}{
-					"type":         "gamestart",
-					"dynamicState": dynamicState,
+				startMsg := &gamev1.GameStart{
+					Mode:         string(data.world.Rules.Mode()),
+					DynamicState: data.world.GetDynamicState(),
+				}
+				if roundDuration := data.world.RoundSettings().Duration; roundDuration > 0 {
+					seconds := int32(roundDuration.Seconds())
+					startMsg.RoundDuration = &seconds
 				}
-				marshal, _ := json.Marshal(startMsg)
-				manager.broadcastAll(data.world, marshal)
+				manager.broadcastAll(data.world, &gamev1.Envelope{Payload: &gamev1.Envelope_Gamestart{Gamestart: startMsg}})
 			}()
 
-			return map[string]interface{}{
-				"type": "countdownstarted",
+			return &gamev1.Envelope{Payload: &gamev1.Envelope_Countdownstarted{Countdownstarted: &gamev1.CountdownStarted{}}}
+		},
+	}
+}
+
+// rejectMessage sends an error to the sender only and broadcasts nothing
+func rejectMessage(data MessageData, err error) *gamev1.Envelope {
+	if data.session != nil {
+		sendError(data.session, err)
+	}
+	return nil
+}
+
+// BotDifficultyMessage lets the host pick the bot difficulty before the
+// match, everyone gets the new lobby status
+func BotDifficultyMessage() MessageHandler {
+	name := "botdifficulty"
+	return MessageHandler{
+		messageName: name,
+		handler: func(data MessageData) *gamev1.Envelope {
+			if !data.playerSession.IsHost {
+				return rejectMessage(data, fmt.Errorf("Alleen de host kan de moeilijkheid kiezen"))
+			}
+
+			level, _ := data.msgInfo["difficulty"].(string)
+			difficulty, err := ParseDifficulty(level)
+			if err == nil {
+				err = data.world.SetBotDifficulty(difficulty)
+			}
+			if err != nil {
+				return rejectMessage(data, err)
+			}
+
+			return LobbyStatusMessage().handler(data)
+		},
+	}
+}
+
+// LivesMessage lets the host pick the runner's lives before the match,
+// everyone gets the new lobby status
+func LivesMessage() MessageHandler {
+	name := "lives"
+	return MessageHandler{
+		messageName: name,
+		handler: func(data MessageData) *gamev1.Envelope {
+			if !data.playerSession.IsHost {
+				return rejectMessage(data, fmt.Errorf("Alleen de host kan het aantal levens kiezen"))
 			}
+
+			lives, ok := data.msgInfo["lives"].(float64)
+			if !ok || lives != math.Trunc(lives) {
+				return rejectMessage(data, fmt.Errorf("ongeldig aantal levens"))
+			}
+			if err := data.world.SetLives(int(lives)); err != nil {
+				return rejectMessage(data, err)
+			}
+
+			return LobbyStatusMessage().handler(data)
 		},
 	}
 }
 
-// LobbyStatusMessage returns current lobby status
-func LobbyStatusMessage() MessageHandler {
-	name := "lobbystatus"
+// RoundSettingsMessage lets the host pick the round duration in seconds (0
+// for none) and sudden death before the match, everyone gets the new lobby
+// status
+func RoundSettingsMessage() MessageHandler {
+	name := "roundsettings"
 	return MessageHandler{
 		messageName: name,
-		handler: func(data MessageData) map[string]interface{} {
-			players := []map[string]interface{}{}
+		handler: func(data MessageData) *gamev1.Envelope {
+			if !data.playerSession.IsHost {
+				return rejectMessage(data, fmt.Errorf("Alleen de host kan de speelduur kiezen"))
+			}
 
-			for _, session := range data.world.ConnectedPlayers.GetValues() {
-				if session == nil {
-					continue
-				}
-				player, err := getPlayerEntityFromSession(session)
-				if err != nil {
-					continue
-				}
-				players = append(players, map[string]interface{}{
-					"playerId":   player.PlayerId,
-					"username":   player.Username,
-					"spriteType": player.SpriteType,
-					"isReady":    player.IsReady,
-					"isHost":     player.IsHost,
-				})
+			duration, ok := data.msgInfo["durationSec"].(float64)
+			if !ok || duration != math.Trunc(duration) {
+				return rejectMessage(data, fmt.Errorf("ongeldige speelduur"))
+			}
+			suddenDeath, _ := data.msgInfo["suddenDeath"].(bool)
+			if err := data.world.SetRoundSettings(int(duration), suddenDeath); err != nil {
+				return rejectMessage(data, err)
 			}
 
-			return map[string]interface{}{
-				"type":          name,
-				"players":       players,
-				"playerCount":   data.world.GetPlayerCount(),
-				"readyCount":    data.world.GetReadyCount(),
-				"matchStarted":  data.world.MatchStarted,
-				"hostId":        data.world.HostPlayerId,
+			return LobbyStatusMessage().handler(data)
+		},
+	}
+}
+
+// AckMessage acknowledges the snapshot of a tick, later entity updates of
+// the session only hold the changes since then
+func AckMessage() MessageHandler {
+	name := "ack"
+	return MessageHandler{
+		messageName: name,
+		handler: func(data MessageData) *gamev1.Envelope {
+			tick, ok := data.msgInfo["tick"].(float64)
+			if !ok || tick < 0 || tick != math.Trunc(tick) {
+				data.world.AntiCheat.Report(data.playerSession.PlayerId, ViolationInvalidInput, fmt.Errorf("invalid ack tick: %v", data.msgInfo["tick"]))
+				return nil
 			}
+			viewOf(data.session).ack(uint64(tick))
+			return nil
+		},
+	}
+}
+
+// LobbyStatusMessage returns current lobby status
+func LobbyStatusMessage() MessageHandler {
+	name := "lobbystatus"
+	return MessageHandler{
+		messageName: name,
+		handler: func(data MessageData) *gamev1.Envelope {
+			return data.world.LobbyStatus()
 		},
 	}
 }
@@ -425,87 +401,36 @@ func getCoordFromMessage(msgInfo map[string]interface{}) (X float64, Y float64,
 		return 0, 0, fmt.Errorf("unable to find coordinates in message")
 	}
 
-	//if _, err := strconv.ParseFloat(x.(), 64); err != nil {
-	//	return "", "", fmt.Errorf("invalid X coordinate in message")
-	//}
-	//if _, err := strconv.ParseFloat(y.(string), 64); err != nil {
-	//	return "", "", fmt.Errorf("invalid Y coordinate in message")
-	//}
+	// JSON numbers decode to float64, anything else is not a coordinate
+	X, okX := x.(float64)
+	Y, okY := y.(float64)
+	if !okX || !okY || math.IsNaN(X) || math.IsNaN(Y) || math.IsInf(X, 0) || math.IsInf(Y, 0) {
+		return 0, 0, fmt.Errorf("invalid coordinates in message")
+	}
 
-	return x.(float64), y.(float64), nil
+	return X, Y, nil
 }
 
 // =========================================
 // Dynamic World Messages
 // =========================================
 
-// EntityCollisionMessage handles when a player collides with a danger entity
-func EntityCollisionMessage() MessageHandler {
-	name := "entity_collision"
-	return MessageHandler{
-		messageName: name,
-		handler: func(data MessageData) map[string]interface{} {
-			x, y, err := getCoordFromMessage(data.msgInfo)
-			if err != nil {
-				return nil
-			}
-			
-			// Check collision on server side
-			entity := data.world.CheckEntityCollision(x, y)
-			if entity == nil {
-				return nil
-			}
-			
-			// Determine effect based on zone and entity type
-			zone := data.world.GetCurrentZone(int(x), int(y))
-			
-			// In safe zones, entities don't kill (unless zone is inactive)
-			if zone != nil && zone.Type == ZoneSafe && zone.IsActive {
-				return map[string]interface{}{
-					"type":     "entity_near",
-					"entityId": entity.ID,
-					"warning":  true,
-				}
-			}
-			
-			// In danger zones or inactive safe zones - player caught!
-			return map[string]interface{}{
-				"type":       name,
-				"entityId":   entity.ID,
-				"entityType": entity.Type,
-				"caught":     true,
-			}
-		},
-	}
-}
-
-// ZoneQueryMessage returns the zone at a specific position
+// ZoneQueryMessage returns the zone at a position in pixels
 func ZoneQueryMessage() MessageHandler {
 	name := "zone_query"
 	return MessageHandler{
 		messageName: name,
-		handler: func(data MessageData) map[string]interface{} {
+		handler: func(data MessageData) *gamev1.Envelope {
 			x, y, err := getCoordFromMessage(data.msgInfo)
 			if err != nil {
 				return nil
 			}
 			
-			zone := data.world.GetCurrentZone(int(x), int(y))
-			if zone == nil {
-				return map[string]interface{}{
-					"type": name,
-					"zone": nil,
-				}
-			}
-			
-			return map[string]interface{}{
-				"type": name,
-				"zone": map[string]interface{}{
-					"id":       zone.ID,
-					"zoneType": zone.Type,
-					"isActive": zone.IsActive,
-				},
+			query := &gamev1.ZoneQuery{}
+			if zone := data.world.GetCurrentZone(x, y); zone != nil {
+				query.Zone = zone.toProto()
 			}
+			return &gamev1.Envelope{Payload: &gamev1.Envelope_ZoneQuery{ZoneQuery: query}}
 		},
 	}
 }
@@ -515,10 +440,8 @@ func DynamicStateMessage() MessageHandler {
 	name := "dynamic_state"
 	return MessageHandler{
 		messageName: name,
-		handler: func(data MessageData) map[string]interface{} {
-			state := data.world.GetDynamicState()
-			state["type"] = name
-			return state
+		handler: func(data MessageData) *gamev1.Envelope {
+			return &gamev1.Envelope{Payload: &gamev1.Envelope_DynamicState{DynamicState: data.world.GetDynamicState()}}
 		},
 	}
 }
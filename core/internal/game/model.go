@@ -2,6 +2,7 @@ package game
 
 import (
 	"encoding/json"
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
 	"github.com/frank2889/mazechase/internal/user"
 	"strconv"
 )
@@ -71,6 +72,32 @@ func (p *PlayerEntity) ToMap() map[string]interface{} {
 	return playerMap
 }
 
+// ToProto converts the PlayerEntity to the payload of a pos, active or dis message
+func (p *PlayerEntity) ToProto() *gamev1.PlayerUpdate {
+	return &gamev1.PlayerUpdate{
+		Playerid:    p.PlayerId,
+		User:        p.Username,
+		SpriteType:  string(p.SpriteType),
+		X:           p.X,
+		Y:           p.Y,
+		Dir:         p.Dir,
+		IsReady:     p.IsReady,
+		IsHost:      p.IsHost,
+		IsSpectator: p.IsSpectator,
+	}
+}
+
+// lobbyPlayer is the player as listed in the lobby status and state messages
+func (p *PlayerEntity) lobbyPlayer() *gamev1.LobbyPlayer {
+	return &gamev1.LobbyPlayer{
+		PlayerId:   p.PlayerId,
+		Username:   p.Username,
+		SpriteType: string(p.SpriteType),
+		IsReady:    p.IsReady,
+		IsHost:     p.IsHost,
+	}
+}
+
 // FromJSON populates the PlayerEntity from a JSON string
 func (p *PlayerEntity) FromJSON(jsonStr string) error {
 	return json.Unmarshal([]byte(jsonStr), p)
@@ -0,0 +1,289 @@
+package game
+
+import (
+	"sort"
+	"time"
+
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
+)
+
+// GameMode identifies the rule set a World is played with
+type GameMode string
+
+const (
+	ModeClassic GameMode = "classic"
+	ModeRace    GameMode = "race"
+	ModeBattle  GameMode = "battle"
+)
+
+// GameRules decides sprite assignment, collision outcomes and win conditions
+// for a game mode. Methods taking a World are called with worldLock held.
+type GameRules interface {
+	Mode() GameMode
+	// Sprites lists the sprite slots of a match, players get them from the end
+	Sprites() []SpriteType
+	// IsRunner reports whether a sprite plays the runner role in this mode
+	IsRunner(sprite SpriteType) bool
+	// EatPowerUp applies a power-up picked up by a player
+	EatPowerUp(w *World, player *PlayerEntity, x, y float64, now time.Time)
+	// ResolveCollisions applies the outcome of player-vs-player collisions
+	ResolveCollisions(w *World, now time.Time)
+	// EntityCatch decides what a danger entity catching a player does to it
+	EntityCatch(player *PlayerEntity) CatchOutcome
+	// CheckGameOver returns a reason and winner once the match is decided
+	CheckGameOver(w *World, now time.Time) (reason string, winner string)
+	// TimeUp returns the reason and winner when the round timer runs out
+	TimeUp(w *World) (reason string, winner string)
+	// Won reports whether a player is on the winning side of a game over
+	Won(player *PlayerEntity, winner string) bool
+}
+
+// RulesForMode returns the rules of a mode, unknown modes fall back to classic
+func RulesForMode(mode GameMode) GameRules {
+	switch mode {
+	case ModeRace:
+		return raceRules{}
+	case ModeBattle:
+		return battleRules{}
+	default:
+		return classicRules{}
+	}
+}
+
+// classicRules is one Runner against three Chasers
+type classicRules struct{}
+
+func (classicRules) Mode() GameMode { return ModeClassic }
+
+func (classicRules) Sprites() []SpriteType {
+	return []SpriteType{Chaser1, Chaser2, Chaser3, Runner}
+}
+
+func (classicRules) IsRunner(sprite SpriteType) bool { return sprite == Runner }
+
+// EatPowerUp powers up the runner, there is only one so the flag is global
+func (classicRules) EatPowerUp(w *World, _ *PlayerEntity, x, y float64, now time.Time) {
+	w.eatPowerUpLocked(x, y, now)
+}
+
+func (classicRules) ResolveCollisions(w *World, now time.Time) {
+	collided, runnerId, chaserId := w.checkPlayerCollisionsLocked()
+	if !collided {
+		return
+	}
+	w.resolveCatchLocked(runnerId, chaserId, now)
+}
+
+// EntityCatch stuns the runner, entities hunt alongside the chasers
+func (classicRules) EntityCatch(player *PlayerEntity) CatchOutcome {
+	if player.SpriteType != Runner {
+		return CatchNone
+	}
+	return CatchStun
+}
+
+// resolveCatchLocked applies the outcome of the runner touching a chaser: a
+// frightened chaser is eaten and returns to the house, otherwise the runner
+// loses a life and the chasers win once it has none left (caller must hold
+// worldLock)
+func (w *World) resolveCatchLocked(runnerId string, chaserId SpriteType, now time.Time) {
+	if w.isFrightenedLocked(chaserId) {
+		// Runner eats chaser, its eyes follow the kill
+		w.playerStatsLocked(runnerId).ChasersEaten++
+		w.playerStatsLocked(runnerId).PlayersEliminated++
+		w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Kill{Kill: &gamev1.Kill{SpriteId: string(chaserId)}}})
+		w.eatChaserLocked(chaserId)
+		return
+	}
+
+	// Chaser catches runner
+	if catcherId := w.playerIdBySpriteLocked(chaserId); catcherId != "" {
+		w.playerStatsLocked(catcherId).PlayersEliminated++
+	}
+	w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Kill{Kill: &gamev1.Kill{
+		SpriteId: string(Runner),
+		ChaserId: string(chaserId),
+	}}})
+	if w.loseLifeLocked(now) {
+		return
+	}
+	w.gameEnded = true
+	w.GameOver("Runner is gevangen!", "Chasers")
+}
+
+// CheckGameOver lets the runner win by clearing the maze in every level, a
+// cleared maze before the last level starts the next one. Eaten chasers come
+// back from the house so they never run out
+func (classicRules) CheckGameOver(w *World, now time.Time) (string, string) {
+	if w.PelletsCoordEaten.Len() >= w.TotalPellets {
+		if w.Level >= LevelCount {
+			return "Alle levels gehaald!", "Runner"
+		}
+		w.advanceLevelLocked(now)
+	}
+
+	return "", ""
+}
+
+// TimeUp lets the chasers win, the runner did not clear the board in time
+func (classicRules) TimeUp(*World) (string, string) {
+	return "De tijd is om!", "Chasers"
+}
+
+func (classicRules) Won(player *PlayerEntity, winner string) bool {
+	if winner == "Chasers" {
+		return player.SpriteType != Runner
+	}
+	return winner == "Runner" && player.SpriteType == Runner
+}
+
+// raceRules makes every player a runner, the highest score after the round
+// timer (or once the maze is cleared) wins
+type raceRules struct{}
+
+func (raceRules) Mode() GameMode { return ModeRace }
+
+func (raceRules) Sprites() []SpriteType {
+	return []SpriteType{Chaser1, Chaser2, Chaser3, Runner}
+}
+
+func (raceRules) IsRunner(SpriteType) bool { return true }
+
+// EatPowerUp only scores in a race, nobody can be eaten
+func (raceRules) EatPowerUp(w *World, _ *PlayerEntity, x, y float64, _ time.Time) {
+	w.PowerUpsCoordsEaten.Add(x, y)
+}
+
+// ResolveCollisions is a no-op, runners pass through each other
+func (raceRules) ResolveCollisions(*World, time.Time) {}
+
+// EntityCatch stuns, a race has no eliminations but a stun costs time
+func (raceRules) EntityCatch(*PlayerEntity) CatchOutcome { return CatchStun }
+
+func (raceRules) CheckGameOver(w *World, now time.Time) (string, string) {
+	if !w.MatchStarted {
+		return "", ""
+	}
+
+	if w.PelletsCoordEaten.Len() >= w.TotalPellets {
+		return "Alle pellets verzameld!", w.topScorerLocked()
+	}
+
+	return "", ""
+}
+
+func (raceRules) TimeUp(w *World) (string, string) {
+	return "De tijd is om!", w.topScorerLocked()
+}
+
+// Won compares player ids, the top scorer may have left the match
+func (raceRules) Won(player *PlayerEntity, winner string) bool {
+	return player.PlayerId == winner
+}
+
+// battleRules is a free-for-all, a powered player eliminates any unpowered
+// player it touches and the last player standing wins
+type battleRules struct{}
+
+func (battleRules) Mode() GameMode { return ModeBattle }
+
+func (battleRules) Sprites() []SpriteType {
+	return []SpriteType{Chaser1, Chaser2, Chaser3, Runner}
+}
+
+func (battleRules) IsRunner(SpriteType) bool { return true }
+
+func (battleRules) EatPowerUp(w *World, player *PlayerEntity, x, y float64, now time.Time) {
+	w.powerUpPlayerLocked(player, x, y, now)
+}
+
+func (battleRules) ResolveCollisions(w *World, _ time.Time) {
+	ids := w.sortedPlayerIdsLocked()
+	for i, a := range ids {
+		for _, b := range ids[i+1:] {
+			posA, posB := w.PlayerPositions[a], w.PlayerPositions[b]
+			if posA == nil || posB == nil || w.Players[a] == nil || w.Players[b] == nil {
+				continue
+			}
+			if !CollisionCheck(posA.X, posA.Y, posB.X, posB.Y) {
+				continue
+			}
+
+			// Equal power bounces off, only a powered player can eliminate
+			_, poweredA := w.PoweredUntil[a]
+			_, poweredB := w.PoweredUntil[b]
+			switch {
+			case poweredA && !poweredB:
+				w.eliminatePlayerLocked(b, a)
+			case poweredB && !poweredA:
+				w.eliminatePlayerLocked(a, b)
+			}
+		}
+	}
+}
+
+// EntityCatch eliminates, entities are one more opponent in a free-for-all
+func (battleRules) EntityCatch(*PlayerEntity) CatchOutcome { return CatchEliminated }
+
+func (battleRules) CheckGameOver(w *World, now time.Time) (string, string) {
+	if !w.MatchStarted {
+		return "", ""
+	}
+
+	if len(w.Eliminated) > 0 {
+		switch len(w.Players) {
+		case 0:
+			return "Iedereen is uitgeschakeld", "Niemand"
+		case 1:
+			for id := range w.Players {
+				return "Laatste speler over!", id
+			}
+		}
+	}
+
+	if w.PelletsCoordEaten.Len() >= w.TotalPellets {
+		return "Alle pellets verzameld!", w.topScorerLocked()
+	}
+
+	return "", ""
+}
+
+// TimeUp lets the best scoring player still standing win
+func (battleRules) TimeUp(w *World) (string, string) {
+	return "De tijd is om!", w.topScorerOfLocked(w.sortedPlayerIdsLocked())
+}
+
+// Won compares player ids, the top scorer may have left the match
+func (battleRules) Won(player *PlayerEntity, winner string) bool {
+	return player.PlayerId == winner
+}
+
+// topScorerLocked returns the player id with the highest score, or a draw
+// when several players share it (caller must hold worldLock)
+func (w *World) topScorerLocked() string {
+	ids := make([]string, 0, len(w.Scores))
+	for id := range w.Scores {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return w.topScorerOfLocked(ids)
+}
+
+// topScorerOfLocked returns the player id with the highest score among the
+// given player ids, or a draw (caller must hold worldLock)
+func (w *World) topScorerOfLocked(ids []string) string {
+	best, bestScore, tied := "", -1, false
+	for _, id := range ids {
+		switch score := w.Scores[id]; {
+		case score > bestScore:
+			best, bestScore, tied = id, score, false
+		case score == bestScore:
+			tied = true
+		}
+	}
+
+	if best == "" || tied {
+		return "Gelijkspel"
+	}
+	return best
+}
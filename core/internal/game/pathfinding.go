@@ -2,7 +2,10 @@ package game
 
 import (
 	"container/heap"
+	"fmt"
 	"math"
+	"math/rand"
+	"time"
 )
 
 // PathNode represents a node in the pathfinding grid
@@ -35,6 +38,7 @@ func NewPathGrid(width, height int) *PathGrid {
 				X:        x,
 				Y:        y,
 				Walkable: true,
+				index:    -1,
 			}
 		}
 	}
@@ -42,6 +46,24 @@ func NewPathGrid(width, height int) *PathGrid {
 	return grid
 }
 
+// NewPathGridFromWalkGrid creates a pathfinding grid from a maze layout,
+// 0 = walkable, 1 = wall
+func NewPathGridFromWalkGrid(walkGrid [][]int) *PathGrid {
+	height := len(walkGrid)
+	width := 0
+	if height > 0 {
+		width = len(walkGrid[0])
+	}
+
+	grid := NewPathGrid(width, height)
+	for y, row := range walkGrid {
+		for x, tile := range row {
+			grid.SetWalkable(x, y, tile == 0)
+		}
+	}
+	return grid
+}
+
 // SetWalkable sets whether a tile is walkable
 func (g *PathGrid) SetWalkable(x, y int, walkable bool) {
 	if x >= 0 && x < g.Width && y >= 0 && y < g.Height {
@@ -66,6 +88,7 @@ func (g *PathGrid) ResetNodes() {
 			node.H = 0
 			node.F = 0
 			node.Parent = nil
+			node.index = -1
 		}
 	}
 }
@@ -219,6 +242,9 @@ func (pf *AStarPathfinder) reconstructPath(node *PathNode) []Point {
 type ChaserAI struct {
 	pathfinder *AStarPathfinder
 	difficulty DifficultyLevel
+	scatterX   int
+	scatterY   int
+	rng        *rand.Rand
 }
 
 // DifficultyLevel represents game difficulty
@@ -230,6 +256,52 @@ const (
 	DifficultyHard
 )
 
+// difficultyNames are the names clients use, medium is called normal
+var difficultyNames = map[DifficultyLevel]string{
+	DifficultyEasy:   "easy",
+	DifficultyMedium: "normal",
+	DifficultyHard:   "hard",
+}
+
+// ParseDifficulty turns a client difficulty name into a level
+func ParseDifficulty(name string) (DifficultyLevel, error) {
+	for level, levelName := range difficultyNames {
+		if levelName == name {
+			return level, nil
+		}
+	}
+	return DifficultyMedium, fmt.Errorf("onbekende moeilijkheid: %s", name)
+}
+
+func (d DifficultyLevel) String() string {
+	return difficultyNames[d]
+}
+
+// ReactionTime is how long a bot keeps chasing where it last saw its target
+func (d DifficultyLevel) ReactionTime() time.Duration {
+	switch d {
+	case DifficultyEasy:
+		return BotReactionEasyMs * time.Millisecond
+	case DifficultyHard:
+		return BotReactionHardMs * time.Millisecond
+	default:
+		return BotReactionNormalMs * time.Millisecond
+	}
+}
+
+// PathAccuracy is the chance a chase move follows the shortest path, the
+// other moves are random
+func (d DifficultyLevel) PathAccuracy() float64 {
+	switch d {
+	case DifficultyEasy:
+		return BotAccuracyEasy
+	case DifficultyHard:
+		return BotAccuracyHard
+	default:
+		return BotAccuracyNormal
+	}
+}
+
 // ChaserBehavior represents different chaser behaviors
 type ChaserBehavior int
 
@@ -244,9 +316,30 @@ func NewChaserAI(grid *PathGrid, difficulty DifficultyLevel) *ChaserAI {
 	return &ChaserAI{
 		pathfinder: NewAStarPathfinder(grid),
 		difficulty: difficulty,
+		rng:        newUnseededRand(),
 	}
 }
 
+// SetRand makes the AI draw its random moves from a world's generator
+func (ai *ChaserAI) SetRand(rng *rand.Rand) {
+	ai.rng = rng
+}
+
+// SetDifficulty changes the difficulty of an existing AI
+func (ai *ChaserAI) SetDifficulty(difficulty DifficultyLevel) {
+	ai.difficulty = difficulty
+}
+
+// Difficulty returns the AI's difficulty
+func (ai *ChaserAI) Difficulty() DifficultyLevel {
+	return ai.difficulty
+}
+
+// SetScatterTarget sets the tile the chaser heads for while scattering
+func (ai *ChaserAI) SetScatterTarget(x, y int) {
+	ai.scatterX, ai.scatterY = x, y
+}
+
 // GetNextMove calculates the next move for a chaser
 func (ai *ChaserAI) GetNextMove(chaserX, chaserY, runnerX, runnerY int, behavior ChaserBehavior) (nextX, nextY int) {
 	switch behavior {
@@ -261,101 +354,113 @@ func (ai *ChaserAI) GetNextMove(chaserX, chaserY, runnerX, runnerY int, behavior
 	}
 }
 
-// chaseMove calculates chase behavior (move towards Runner)
+// chaseMove calculates chase behavior (move towards Runner), the difficulty
+// decides how often the shortest path is followed
 func (ai *ChaserAI) chaseMove(chaserX, chaserY, runnerX, runnerY int) (int, int) {
-	// Use pathfinding based on difficulty
-	switch ai.difficulty {
-	case DifficultyHard:
-		// Full A* pathfinding
-		path := ai.pathfinder.FindPath(chaserX, chaserY, runnerX, runnerY)
-		if len(path) > 1 {
-			return int(path[1].X), int(path[1].Y)
-		}
-	case DifficultyMedium:
-		// Greedy approach with some randomness
-		if randomChance(0.7) {
-			return ai.greedyMove(chaserX, chaserY, runnerX, runnerY)
-		}
-		return ai.randomMove(chaserX, chaserY)
-	case DifficultyEasy:
-		// Random movement with slight bias towards Runner
-		if randomChance(0.3) {
-			return ai.greedyMove(chaserX, chaserY, runnerX, runnerY)
-		}
+	if ai.rng.Float64() >= ai.difficulty.PathAccuracy() {
 		return ai.randomMove(chaserX, chaserY)
 	}
-
-	return chaserX, chaserY
+	return ai.pathMove(chaserX, chaserY, runnerX, runnerY)
 }
 
-// scatterMove calculates scatter behavior (move to corners)
+// scatterMove calculates scatter behavior (move to the chaser's corner, then
+// wander around it)
 func (ai *ChaserAI) scatterMove(chaserX, chaserY int) (int, int) {
-	// Move towards designated corner
-	return ai.randomMove(chaserX, chaserY)
+	targetX, targetY := ai.nearestWalkable(ai.scatterX, ai.scatterY)
+	if abs(chaserX-targetX)+abs(chaserY-targetY) <= 1 {
+		return ai.randomMove(chaserX, chaserY)
+	}
+	return ai.pathMove(chaserX, chaserY, targetX, targetY)
 }
 
 // frightenedMove calculates frightened behavior (run away from Runner)
 func (ai *ChaserAI) frightenedMove(chaserX, chaserY, runnerX, runnerY int) (int, int) {
-	// Move away from Runner
-	dx := chaserX - runnerX
-	dy := chaserY - runnerY
-
-	if abs(dx) > abs(dy) {
-		if dx > 0 {
-			return chaserX + 1, chaserY
+	bestX, bestY := chaserX, chaserY
+	bestDist := -1
+	for _, next := range ai.walkableNeighbors(chaserX, chaserY) {
+		dist := abs(next.X-runnerX) + abs(next.Y-runnerY)
+		if dist > bestDist {
+			bestX, bestY, bestDist = next.X, next.Y, dist
 		}
-		return chaserX - 1, chaserY
 	}
+	return bestX, bestY
+}
 
-	if dy > 0 {
-		return chaserX, chaserY + 1
+// pathMove takes the first step of the shortest path, or a greedy step when
+// there is no path
+func (ai *ChaserAI) pathMove(fromX, fromY, toX, toY int) (int, int) {
+	toX, toY = ai.nearestWalkable(toX, toY)
+	path := ai.pathfinder.FindPath(fromX, fromY, toX, toY)
+	if len(path) > 1 {
+		return int(path[1].X), int(path[1].Y)
 	}
-	return chaserX, chaserY - 1
+	if len(path) == 1 {
+		return fromX, fromY
+	}
+	return ai.greedyMove(fromX, fromY, toX, toY)
 }
 
 // greedyMove makes a greedy move towards target
 func (ai *ChaserAI) greedyMove(fromX, fromY, toX, toY int) (int, int) {
-	dx := toX - fromX
-	dy := toY - fromY
-
-	if abs(dx) > abs(dy) {
-		if dx > 0 {
-			return fromX + 1, fromY
+	bestX, bestY := fromX, fromY
+	bestDist := math.MaxInt
+	for _, next := range ai.walkableNeighbors(fromX, fromY) {
+		dist := abs(next.X-toX) + abs(next.Y-toY)
+		if dist < bestDist {
+			bestX, bestY, bestDist = next.X, next.Y, dist
 		}
-		return fromX - 1, fromY
 	}
-
-	if dy > 0 {
-		return fromX, fromY + 1
-	}
-	return fromX, fromY - 1
+	return bestX, bestY
 }
 
 // randomMove makes a random valid move
 func (ai *ChaserAI) randomMove(x, y int) (int, int) {
-	directions := [][2]int{{0, -1}, {0, 1}, {-1, 0}, {1, 0}}
-	dir := directions[randomInt(4)]
-	return x + dir[0], y + dir[1]
+	neighbors := ai.walkableNeighbors(x, y)
+	if len(neighbors) == 0 {
+		return x, y
+	}
+	next := neighbors[ai.rng.Intn(len(neighbors))]
+	return next.X, next.Y
 }
 
-// Helper functions
-func abs(x int) int {
-	if x < 0 {
-		return -x
+func (ai *ChaserAI) walkableNeighbors(x, y int) []*PathNode {
+	node := ai.pathfinder.grid.GetNode(x, y)
+	if node == nil {
+		return nil
 	}
-	return x
+	return ai.pathfinder.getNeighbors(node)
 }
 
-func randomChance(probability float64) bool {
-	return randomFloat() < probability
+// nearestWalkable returns the walkable tile closest to a target, targets on
+// walls or outside the maze are moved onto the maze
+func (ai *ChaserAI) nearestWalkable(x, y int) (int, int) {
+	return ai.pathfinder.grid.NearestWalkable(x, y)
 }
 
-func randomInt(max int) int {
-	// Simple pseudo-random for game purposes
-	return int(randomFloat() * float64(max))
+// NearestWalkable returns the walkable tile closest to a tile
+func (g *PathGrid) NearestWalkable(x, y int) (int, int) {
+	x = max(0, min(x, g.Width-1))
+	y = max(0, min(y, g.Height-1))
+
+	for radius := 0; radius < g.Width+g.Height; radius++ {
+		for dy := -radius; dy <= radius; dy++ {
+			for dx := -radius; dx <= radius; dx++ {
+				if abs(dx)+abs(dy) != radius {
+					continue
+				}
+				if node := g.GetNode(x+dx, y+dy); node != nil && node.Walkable {
+					return node.X, node.Y
+				}
+			}
+		}
+	}
+	return x, y
 }
 
-func randomFloat() float64 {
-	// Use time-based seed in production
-	return 0.5 // Placeholder - should use proper random
+// Helper functions
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
 }
@@ -0,0 +1,314 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
+	"github.com/olahol/melody"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ProtocolVersion is the version of the game protocol in spec/protos/game/v1,
+// bump it on every change clients have to follow. The server speaks only this
+// version, older clients reload the page.
+const ProtocolVersion = 3
+
+// Encoding is the wire format of the server messages on a connection, clients
+// pick one with ?enc= when they connect
+type Encoding string
+
+const (
+	// EncodingJSON sends text frames, readable in the browser's dev tools
+	EncodingJSON Encoding = "json"
+	// EncodingProto sends binary frames of a protobuf Envelope
+	EncodingProto Encoding = "proto"
+)
+
+const encodingKey = "encoding"
+
+var payloadOneof = (&gamev1.Envelope{}).ProtoReflect().Descriptor().Oneofs().ByName("payload")
+
+// Payloads keep their zero values on the wire, fields without presence would
+// otherwise disappear for clients
+var payloadJSON = protojson.MarshalOptions{EmitDefaultValues: true}
+
+// jsonEnvelope is an Envelope as sent on the JSON wire
+type jsonEnvelope struct {
+	Version uint32          `json:"v,omitempty"`
+	Type    string          `json:"type"`
+	Seq     uint64          `json:"seq,omitempty"`
+	Ts      int64           `json:"ts,omitempty"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// jsonSnapshot is the payload of a snapshot, its messages are envelopes too
+type jsonSnapshot struct {
+	Tick     uint64            `json:"tick"`
+	Messages []json.RawMessage `json:"messages"`
+}
+
+// clientEnvelope is a message of a client, the envelope carries the session's
+// secret token and the payload the fields of the message type
+type clientEnvelope struct {
+	Type        string                 `json:"type"`
+	Seq         uint64                 `json:"seq"`
+	SecretToken string                 `json:"secretToken"`
+	Payload     map[string]interface{} `json:"payload"`
+}
+
+// checkProtocol checks that a client asks for ProtocolVersion with ?v= when
+// it connects
+func checkProtocol(query url.Values) error {
+	version, err := strconv.ParseUint(query.Get("v"), 10, 32)
+	if err != nil || version != ProtocolVersion {
+		return fmt.Errorf("protocolversie %q wordt niet ondersteund, vernieuw de pagina", query.Get("v"))
+	}
+	return nil
+}
+
+// negotiateEncoding checks the encoding a client asks for with ?enc=, JSON
+// when it asks for none
+func negotiateEncoding(query url.Values) (Encoding, error) {
+	switch encoding := Encoding(query.Get("enc")); encoding {
+	case "":
+		return EncodingJSON, nil
+	case EncodingJSON, EncodingProto:
+		return encoding, nil
+	}
+	return "", fmt.Errorf("berichtcodering %q wordt niet ondersteund, vernieuw de pagina", query.Get("enc"))
+}
+
+// sessionEncoding is the encoding a session negotiated, JSON for sessions
+// that did not get that far
+func sessionEncoding(s *melody.Session) Encoding {
+	if value, ok := s.Get(encodingKey); ok {
+		return value.(Encoding)
+	}
+	return EncodingJSON
+}
+
+// MessageType is the type of a message, the name of its payload field
+func MessageType(envelope *gamev1.Envelope) string {
+	field := envelope.ProtoReflect().WhichOneof(payloadOneof)
+	if field == nil {
+		return ""
+	}
+	return string(field.Name())
+}
+
+// stampEnvelope sets the protocol version, sequence number and send time of
+// an outgoing message
+func stampEnvelope(envelope *gamev1.Envelope, seq uint64, now time.Time) *gamev1.Envelope {
+	envelope.Version = ProtocolVersion
+	envelope.Seq = seq
+	envelope.Ts = now.UnixMilli()
+	return envelope
+}
+
+// MarshalEnvelopeJSON encodes a message for the JSON wire
+func MarshalEnvelopeJSON(envelope *gamev1.Envelope) ([]byte, error) {
+	field := envelope.ProtoReflect().WhichOneof(payloadOneof)
+	if field == nil {
+		return nil, fmt.Errorf("message without payload")
+	}
+
+	var payload []byte
+	var err error
+	if snapshot := envelope.GetSnapshot(); snapshot != nil {
+		messages := make([]json.RawMessage, 0, len(snapshot.Messages))
+		for _, message := range snapshot.Messages {
+			inner, err := MarshalEnvelopeJSON(message)
+			if err != nil {
+				return nil, err
+			}
+			messages = append(messages, inner)
+		}
+		payload, err = json.Marshal(jsonSnapshot{Tick: snapshot.Tick, Messages: messages})
+	} else {
+		payload, err = payloadJSON.Marshal(envelope.ProtoReflect().Get(field).Message().Interface())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(jsonEnvelope{
+		Version: envelope.Version,
+		Type:    string(field.Name()),
+		Seq:     envelope.Seq,
+		Ts:      envelope.Ts,
+		Payload: payload,
+	})
+}
+
+// UnmarshalEnvelopeJSON decodes a message from the JSON wire, bots and tests
+// read the server's messages with it
+func UnmarshalEnvelopeJSON(data []byte) (*gamev1.Envelope, error) {
+	var wire jsonEnvelope
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return nil, err
+	}
+
+	field := payloadOneof.Fields().ByName(protoreflect.Name(wire.Type))
+	if field == nil {
+		return nil, fmt.Errorf("unknown message type: %q", wire.Type)
+	}
+
+	envelope := &gamev1.Envelope{Version: wire.Version, Seq: wire.Seq, Ts: wire.Ts}
+	message := envelope.ProtoReflect().NewField(field).Message()
+
+	if snapshot, ok := message.Interface().(*gamev1.Snapshot); ok {
+		var payload jsonSnapshot
+		if err := json.Unmarshal(wire.Payload, &payload); err != nil {
+			return nil, err
+		}
+		snapshot.Tick = payload.Tick
+		for _, raw := range payload.Messages {
+			inner, err := UnmarshalEnvelopeJSON(raw)
+			if err != nil {
+				return nil, err
+			}
+			snapshot.Messages = append(snapshot.Messages, inner)
+		}
+	} else if err := protojson.Unmarshal(wire.Payload, message.Interface()); err != nil {
+		return nil, fmt.Errorf("invalid %s payload: %v", wire.Type, err)
+	}
+
+	envelope.ProtoReflect().Set(field, protoreflect.ValueOfMessage(message))
+	return envelope, nil
+}
+
+// EncodeEnvelope encodes a message for the wire in the given encoding
+func EncodeEnvelope(envelope *gamev1.Envelope, encoding Encoding) ([]byte, error) {
+	if encoding == EncodingProto {
+		return proto.Marshal(envelope)
+	}
+	return MarshalEnvelopeJSON(envelope)
+}
+
+// frames holds a message encoded at most once per encoding, however many
+// sessions it goes to
+type frames struct {
+	envelope *gamev1.Envelope
+	encoded  map[Encoding][]byte
+}
+
+func newFrames(envelope *gamev1.Envelope) *frames {
+	return &frames{envelope: envelope, encoded: map[Encoding][]byte{}}
+}
+
+func (f *frames) encode(encoding Encoding) ([]byte, error) {
+	if frame, ok := f.encoded[encoding]; ok {
+		return frame, nil
+	}
+	frame, err := EncodeEnvelope(f.envelope, encoding)
+	if err != nil {
+		return nil, err
+	}
+	f.encoded[encoding] = frame
+	return frame, nil
+}
+
+// writeFrames sends a message to the sessions, a text frame to the JSON
+// sessions and a binary frame to the others
+func writeFrames(mel *melody.Melody, f *frames, sessions []*melody.Session) error {
+	var jsonSessions, protoSessions []*melody.Session
+	for _, s := range sessions {
+		if sessionEncoding(s) == EncodingProto {
+			protoSessions = append(protoSessions, s)
+		} else {
+			jsonSessions = append(jsonSessions, s)
+		}
+	}
+
+	if len(jsonSessions) > 0 {
+		frame, err := f.encode(EncodingJSON)
+		if err != nil {
+			return err
+		}
+		if err := mel.BroadcastMultiple(frame, jsonSessions); err != nil {
+			return err
+		}
+	}
+
+	if len(protoSessions) > 0 {
+		frame, err := f.encode(EncodingProto)
+		if err != nil {
+			return err
+		}
+		for _, s := range protoSessions {
+			if s.IsClosed() {
+				continue
+			}
+			if err := s.WriteBinary(frame); err != nil {
+				log.Warn().Err(err).Msg("Unable to send binary frame")
+			}
+		}
+	}
+	return nil
+}
+
+// writeEnvelope sends a message to a single session in its encoding
+func writeEnvelope(s *melody.Session, envelope *gamev1.Envelope) error {
+	encoding := sessionEncoding(s)
+	frame, err := EncodeEnvelope(envelope, encoding)
+	if err != nil {
+		return err
+	}
+	if encoding == EncodingProto {
+		return s.WriteBinary(frame)
+	}
+	return s.Write(frame)
+}
+
+// errorMessage is an error message for the players
+func errorMessage(message string) *gamev1.Envelope {
+	return &gamev1.Envelope{Payload: &gamev1.Envelope_Error{Error: &gamev1.ErrorMessage{Error: message}}}
+}
+
+// marshalStandalone encodes a message sent outside a match, like connect
+// errors and replay playback status
+func marshalStandalone(envelope *gamev1.Envelope, now time.Time) []byte {
+	marshal, _ := MarshalEnvelopeJSON(stampEnvelope(envelope, 0, now))
+	return marshal
+}
+
+// stamp gives a message the world's next sequence number
+func (w *World) stamp(envelope *gamev1.Envelope) *gamev1.Envelope {
+	return stampEnvelope(envelope, w.seq.Add(1), w.clock.Now())
+}
+
+// marshal stamps a message and encodes it for the JSON wire
+func (w *World) marshal(envelope *gamev1.Envelope) ([]byte, error) {
+	return MarshalEnvelopeJSON(w.stamp(envelope))
+}
+
+func protoScores(scores map[string]int) map[string]int32 {
+	result := make(map[string]int32, len(scores))
+	for id, score := range scores {
+		result[id] = int32(score)
+	}
+	return result
+}
+
+func protoPoints(points []Point) []*gamev1.Point {
+	result := make([]*gamev1.Point, 0, len(points))
+	for _, point := range points {
+		result = append(result, &gamev1.Point{X: point.X, Y: point.Y})
+	}
+	return result
+}
+
+func spriteNames(sprites []SpriteType) []string {
+	result := make([]string, 0, len(sprites))
+	for _, sprite := range sprites {
+		result = append(result, string(sprite))
+	}
+	return result
+}
@@ -0,0 +1,42 @@
+package game
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// NewRand creates a seeded random generator that is safe for concurrent use.
+// A World shares one with its bots, entities and dynamic world, so the same
+// seed and the same inputs replay a match exactly.
+func NewRand(seed int64) *rand.Rand {
+	return rand.New(&lockedSource{src: rand.NewSource(seed).(rand.Source64)})
+}
+
+// newUnseededRand is the generator of types created without a world
+func newUnseededRand() *rand.Rand {
+	return NewRand(time.Now().UnixNano())
+}
+
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source64
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
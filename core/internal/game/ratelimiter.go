@@ -13,17 +13,24 @@ type RateLimiter struct {
 	refillRate   float64 // tokens per second
 	lastRefill   time.Time
 	blockedUntil time.Time
+	clock        Clock
 }
 
 // NewRateLimiter creates a new rate limiter
 // maxTokens: maximum burst capacity
 // refillRate: tokens added per second
 func NewRateLimiter(maxTokens, refillRate float64) *RateLimiter {
+	return NewRateLimiterWithClock(maxTokens, refillRate, WallClock{})
+}
+
+// NewRateLimiterWithClock creates a rate limiter that refills on the given clock
+func NewRateLimiterWithClock(maxTokens, refillRate float64, clock Clock) *RateLimiter {
 	return &RateLimiter{
 		tokens:     maxTokens,
 		maxTokens:  maxTokens,
 		refillRate: refillRate,
-		lastRefill: time.Now(),
+		lastRefill: clock.Now(),
+		clock:      clock,
 	}
 }
 
@@ -32,7 +39,7 @@ func (rl *RateLimiter) Allow() bool {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	now := time.Now()
+	now := rl.clock.Now()
 
 	// Check if still blocked
 	if now.Before(rl.blockedUntil) {
@@ -63,7 +70,7 @@ func (rl *RateLimiter) AllowN(n int) bool {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	now := time.Now()
+	now := rl.clock.Now()
 
 	if now.Before(rl.blockedUntil) {
 		return false
@@ -97,6 +104,7 @@ type RateLimitConfig struct {
 	RefillRate     float64 // Tokens per second
 	CleanupPeriod  time.Duration
 	MaxIdleTime    time.Duration
+	Clock          Clock // the wall clock when nil
 }
 
 // DefaultRateLimitConfig returns sensible defaults for game messages
@@ -111,6 +119,9 @@ func DefaultRateLimitConfig() RateLimitConfig {
 
 // NewPlayerRateLimiter creates a rate limiter manager for all players
 func NewPlayerRateLimiter(config RateLimitConfig) *PlayerRateLimiter {
+	if config.Clock == nil {
+		config.Clock = WallClock{}
+	}
 	prl := &PlayerRateLimiter{
 		limiters: make(map[string]*RateLimiter),
 		config:   config,
@@ -140,7 +151,7 @@ func (prl *PlayerRateLimiter) GetLimiter(playerID string) *RateLimiter {
 		return limiter
 	}
 
-	limiter = NewRateLimiter(prl.config.MaxTokens, prl.config.RefillRate)
+	limiter = NewRateLimiterWithClock(prl.config.MaxTokens, prl.config.RefillRate, prl.config.Clock)
 	prl.limiters[playerID] = limiter
 	return limiter
 }
@@ -154,10 +165,10 @@ func (prl *PlayerRateLimiter) RemoveLimiter(playerID string) {
 
 // cleanupLoop periodically removes idle rate limiters
 func (prl *PlayerRateLimiter) cleanupLoop() {
-	ticker := time.NewTicker(prl.config.CleanupPeriod)
+	ticker := prl.config.Clock.NewTicker(prl.config.CleanupPeriod)
 	defer ticker.Stop()
 
-	for range ticker.C {
+	for range ticker.C() {
 		prl.cleanup()
 	}
 }
@@ -166,7 +177,7 @@ func (prl *PlayerRateLimiter) cleanup() {
 	prl.mu.Lock()
 	defer prl.mu.Unlock()
 
-	now := time.Now()
+	now := prl.config.Clock.Now()
 	for playerID, limiter := range prl.limiters {
 		limiter.mu.Lock()
 		idle := now.Sub(limiter.lastRefill)
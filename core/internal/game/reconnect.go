@@ -0,0 +1,118 @@
+package game
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
+	"github.com/olahol/melody"
+	"github.com/rs/zerolog/log"
+)
+
+// heldPlayer is a player whose socket dropped, its sprite, position and
+// score are kept until the deadline passes
+type heldPlayer struct {
+	player   *PlayerEntity
+	deadline time.Time
+}
+
+// ResumeToken returns the token a client presents to reclaim its player
+// after a dropped connection, derived from the secret token of the session
+func (p *PlayerEntity) ResumeToken() string {
+	sum := sha256.Sum256([]byte(p.PlayerId + ":" + p.secretToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// HoldDisconnected keeps a dropped player in the match for ReconnectGrace,
+// a bot moves the sprite meanwhile once the match runs. It returns false
+// when the player should leave right away (spectators and unknown players).
+// A session that was already replaced by a resumed one is ignored.
+func (w *World) HoldDisconnected(player *PlayerEntity, session *melody.Session, now time.Time) bool {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+
+	id := player.PlayerId
+	current, connected := w.ConnectedPlayers.Load(id)
+	if !connected || player.IsBot {
+		return false
+	}
+	if current != session {
+		return true
+	}
+	if _, active := w.Players[id]; !active {
+		return false
+	}
+
+	w.ConnectedPlayers.Store(id, nil)
+	w.disconnected[id] = &heldPlayer{player: player, deadline: now.Add(ReconnectGrace)}
+	if w.MatchStarted && w.BotManager != nil {
+		w.BotManager.addStandIn(player)
+	}
+
+	w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Reconnecting{Reconnecting: &gamev1.Reconnecting{
+		PlayerId:   id,
+		SpriteType: string(player.SpriteType),
+		GraceSec:   ReconnectGraceSec,
+	}}})
+	log.Info().Str("player", id).Msg("Player disconnected, holding sprite")
+	return true
+}
+
+// Resume hands a held player back to a new session when the resume token
+// matches, the player keeps its sprite, position and score
+func (w *World) Resume(playerId, token string, session *melody.Session) (*PlayerEntity, error) {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+
+	held, ok := w.disconnected[playerId]
+	if !ok {
+		return nil, fmt.Errorf("sessie kan niet hervat worden")
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(held.player.ResumeToken())) != 1 {
+		return nil, fmt.Errorf("ongeldige hervattingscode")
+	}
+
+	delete(w.disconnected, playerId)
+	if w.BotManager != nil {
+		w.BotManager.removeStandIn(playerId)
+	}
+	w.ConnectedPlayers.Store(playerId, session)
+
+	w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Resumed{Resumed: &gamev1.Resumed{
+		PlayerId:   playerId,
+		SpriteType: string(held.player.SpriteType),
+	}}})
+	log.Info().Str("player", playerId).Msg("Player resumed session")
+	return held.player, nil
+}
+
+// IsDisconnected reports whether a player is held waiting for a reconnect
+func (w *World) IsDisconnected(playerId string) bool {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+	_, ok := w.disconnected[playerId]
+	return ok
+}
+
+// expireDisconnectsLocked lets held players whose grace period ran out leave
+// the match (caller must hold worldLock)
+func (w *World) expireDisconnectsLocked(now time.Time) {
+	ids := make([]string, 0, len(w.disconnected))
+	for id, held := range w.disconnected {
+		if !now.Before(held.deadline) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		player := w.disconnected[id].player
+		w.leaveLocked(player)
+		w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Dis{Dis: player.ToProto()}})
+		log.Info().Str("player", id).Msg("Reconnect grace expired, player left")
+	}
+}
@@ -0,0 +1,198 @@
+package game
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ReplayVersion is the version of the replay file format, frames hold
+// messages of the game protocol so it moves with ProtocolVersion
+const ReplayVersion = 4
+
+// ReplayHeader is the first line of a replay file
+type ReplayHeader struct {
+	Version    int       `json:"version"`
+	MatchID    uint      `json:"matchId"`
+	LobbyID    uint      `json:"lobbyId"`
+	Mode       GameMode  `json:"mode"`
+	Seed       int64     `json:"seed"`
+	StartedAt  time.Time `json:"startedAt"`
+	DurationMs int64     `json:"durationMs"`
+}
+
+// ReplayFrame is one recorded moment, either a message that went out to the
+// clients or an input the world accepted. At is in ms from the match start.
+type ReplayFrame struct {
+	At      int64           `json:"t"`
+	Message json.RawMessage `json:"m,omitempty"`
+	Input   *PlayerInput    `json:"i,omitempty"`
+}
+
+// Replay is a recorded match
+type Replay struct {
+	Header ReplayHeader
+	Frames []ReplayFrame
+}
+
+// ReplayRecorder collects the frames of a match, it ignores everything until
+// Start is called at the match start
+type ReplayRecorder struct {
+	mu      sync.Mutex
+	started time.Time
+	frames  []ReplayFrame
+}
+
+// NewReplayRecorder creates a recorder that waits for the match start
+func NewReplayRecorder() *ReplayRecorder {
+	return &ReplayRecorder{}
+}
+
+// Start begins the recording, the state message lets a client set up the
+// match before the first event
+func (r *ReplayRecorder) Start(now time.Time, state []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.started = now
+	r.frames = []ReplayFrame{{At: 0, Message: state}}
+}
+
+// RecordMessage stores a message sent to the clients
+func (r *ReplayRecorder) RecordMessage(now time.Time, message []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.started.IsZero() {
+		return
+	}
+	r.frames = append(r.frames, ReplayFrame{At: r.sinceStartLocked(now), Message: append(json.RawMessage{}, message...)})
+}
+
+// RecordInput stores an input the world accepted
+func (r *ReplayRecorder) RecordInput(now time.Time, input PlayerInput) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.started.IsZero() {
+		return
+	}
+	r.frames = append(r.frames, ReplayFrame{At: r.sinceStartLocked(now), Input: &input})
+}
+
+// Replay returns what was recorded so far
+func (r *ReplayRecorder) Replay(header ReplayHeader) *Replay {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	header.Version = ReplayVersion
+	header.StartedAt = r.started
+	if len(r.frames) > 0 {
+		header.DurationMs = r.frames[len(r.frames)-1].At
+	}
+	return &Replay{Header: header, Frames: append([]ReplayFrame{}, r.frames...)}
+}
+
+// Started reports whether the match is being recorded
+func (r *ReplayRecorder) Started() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return !r.started.IsZero()
+}
+
+func (r *ReplayRecorder) sinceStartLocked(now time.Time) int64 {
+	// Frames never go back in time, even when the clock does
+	at := now.Sub(r.started).Milliseconds()
+	if last := len(r.frames) - 1; last >= 0 && at < r.frames[last].At {
+		at = r.frames[last].At
+	}
+	return at
+}
+
+// WriteTo writes the replay as gzipped JSON lines: the header, then a frame
+// per line
+func (r *Replay) WriteTo(w io.Writer) (int64, error) {
+	counter := &countingWriter{w: w}
+	zw := gzip.NewWriter(counter)
+	encoder := json.NewEncoder(zw)
+
+	if err := encoder.Encode(r.Header); err != nil {
+		return counter.n, err
+	}
+	for i := range r.Frames {
+		if err := encoder.Encode(&r.Frames[i]); err != nil {
+			return counter.n, err
+		}
+	}
+	err := zw.Close()
+	return counter.n, err
+}
+
+// ReadReplay reads a replay written by WriteTo
+func ReadReplay(r io.Reader) (*Replay, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid replay: %v", err)
+	}
+	defer zr.Close()
+
+	decoder := json.NewDecoder(bufio.NewReader(zr))
+	replay := &Replay{}
+	if err := decoder.Decode(&replay.Header); err != nil {
+		return nil, fmt.Errorf("invalid replay header: %v", err)
+	}
+	if replay.Header.Version != ReplayVersion {
+		return nil, fmt.Errorf("unsupported replay version %d", replay.Header.Version)
+	}
+
+	for decoder.More() {
+		var frame ReplayFrame
+		if err := decoder.Decode(&frame); err != nil {
+			return nil, fmt.Errorf("invalid replay frame %d: %v", len(replay.Frames), err)
+		}
+		replay.Frames = append(replay.Frames, frame)
+	}
+	return replay, nil
+}
+
+// SaveReplayFile writes a replay to dir and returns its path and size
+func SaveReplayFile(dir string, replay *Replay) (string, int64, error) {
+	path := filepath.Join(dir, fmt.Sprintf("match-%d.replay", replay.Header.MatchID))
+	file, err := os.Create(path)
+	if err != nil {
+		return "", 0, err
+	}
+
+	size, err := replay.WriteTo(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return "", 0, err
+	}
+	return path, size, nil
+}
+
+// LoadReplayFile reads a replay from disk
+func LoadReplayFile(path string) (*Replay, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadReplay(file)
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,259 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
+	"github.com/frank2889/mazechase/internal/match"
+	"github.com/olahol/melody"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	replayControlsKey = "replayControls"
+	replayDoneKey     = "replayDone"
+)
+
+// Playback speeds a viewer can pick
+const (
+	replayMinSpeed = 0.25
+	replayMaxSpeed = 8
+)
+
+// replayControl is a playback command of a replay viewer, the payload of a
+// replaycontrol message
+type replayControl struct {
+	Action string  `json:"action"` // pause, play, seek or speed
+	AtMs   int64   `json:"atMs"`
+	Speed  float64 `json:"speed"`
+}
+
+// ReplayHandler streams recorded matches over a WebSocket in the same
+// message formats as a live match, so the game client plays them as a
+// spectator
+type ReplayHandler struct {
+	matchService *match.Service
+}
+
+func newReplayWSHandler(matchService *match.Service) http.Handler {
+	mel := melody.New()
+	handler := ReplayHandler{matchService: matchService}
+
+	mel.HandleConnect(handler.HandleConnect)
+	mel.HandleMessage(handler.HandleMessage)
+	mel.HandleDisconnect(handler.HandleDisconnect)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := mel.HandleRequest(w, r); err != nil {
+			http.Error(w, "WebSocket connection failed", http.StatusInternalServerError)
+		}
+	})
+}
+
+// HandleConnect loads the replay of ?match= and starts playing it, from ?t=
+// ms into the match when given
+func (h *ReplayHandler) HandleConnect(s *melody.Session) {
+	query := s.Request.URL.Query()
+	if err := checkProtocol(query); err != nil {
+		sendMessage(s, wsError(err))
+		return
+	}
+
+	matchId, err := strconv.ParseUint(query.Get("match"), 10, 64)
+	if err != nil {
+		sendMessage(s, wsError(errors.New("ongeldige wedstrijd")))
+		return
+	}
+
+	replay, err := h.loadReplay(uint(matchId))
+	if err != nil {
+		log.Warn().Err(err).Uint64("match", matchId).Msg("Unable to load replay")
+		sendMessage(s, wsError(errors.New("replay niet gevonden")))
+		return
+	}
+
+	startAt, _ := strconv.ParseInt(query.Get("t"), 10, 64)
+	controls := make(chan replayControl, 8)
+	done := make(chan struct{})
+	s.Set(replayControlsKey, controls)
+	s.Set(replayDoneKey, done)
+
+	player := newReplayPlayer(replay, s.Write, WallClock{})
+	go player.run(startAt, controls, done)
+
+	log.Info().Uint64("match", matchId).Int("frames", len(replay.Frames)).Msg("Replay started")
+}
+
+// HandleMessage passes playback commands on to the session's player
+func (h *ReplayHandler) HandleMessage(s *melody.Session, msg []byte) {
+	value, ok := s.Get(replayControlsKey)
+	if !ok {
+		return
+	}
+
+	var envelope struct {
+		Type    string        `json:"type"`
+		Payload replayControl `json:"payload"`
+	}
+	if err := json.Unmarshal(msg, &envelope); err != nil || envelope.Type != "replaycontrol" {
+		return
+	}
+
+	select {
+	case value.(chan replayControl) <- envelope.Payload:
+	default:
+		log.Warn().Msg("Replay control dropped, player is busy")
+	}
+}
+
+// HandleDisconnect stops the session's player
+func (h *ReplayHandler) HandleDisconnect(s *melody.Session) {
+	if value, ok := s.Get(replayDoneKey); ok {
+		close(value.(chan struct{}))
+	}
+}
+
+func (h *ReplayHandler) loadReplay(matchId uint) (*Replay, error) {
+	record, err := h.matchService.GetReplay(matchId)
+	if err != nil {
+		return nil, err
+	}
+	return LoadReplayFile(record.Path)
+}
+
+// replayPlayer sends the messages of a replay with their recorded timing,
+// the position moves with the clock while playing
+type replayPlayer struct {
+	replay *Replay
+	send   func([]byte) error
+	clock  Clock
+	next   int   // index of the next frame to send
+	at     int64 // playback position in ms from the match start
+	paused bool
+	speed  float64
+}
+
+func newReplayPlayer(replay *Replay, send func([]byte) error, clock Clock) *replayPlayer {
+	return &replayPlayer{replay: replay, send: send, clock: clock, speed: 1}
+}
+
+// run plays the replay until it is stopped or the viewer is gone
+func (p *replayPlayer) run(startAt int64, controls <-chan replayControl, done <-chan struct{}) {
+	if err := p.sendEnvelope(p.info()); err != nil {
+		return
+	}
+	if err := p.seek(startAt); err != nil {
+		return
+	}
+	if err := p.sendEnvelope(p.status()); err != nil {
+		return
+	}
+
+	for {
+		var wait <-chan time.Time
+		if !p.paused && p.next < len(p.replay.Frames) {
+			delay := float64(p.replay.Frames[p.next].At-p.at) / p.speed
+			wait = p.clock.After(time.Duration(delay * float64(time.Millisecond)))
+		}
+		from := p.clock.Now()
+
+		var err error
+		select {
+		case <-done:
+			return
+		case control := <-controls:
+			p.advance(p.clock.Now().Sub(from))
+			if err = p.apply(control); err == nil {
+				err = p.sendEnvelope(p.status())
+			}
+		case <-wait:
+			if err = p.sendUntil(p.replay.Frames[p.next].At); err == nil && p.next == len(p.replay.Frames) {
+				err = p.sendEnvelope(p.status())
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// advance moves the position by the time that passed while playing, it never
+// passes the next frame, that one still has to be sent
+func (p *replayPlayer) advance(elapsed time.Duration) {
+	if p.paused || p.next >= len(p.replay.Frames) {
+		return
+	}
+	p.at += int64(float64(elapsed.Milliseconds()) * p.speed)
+	if nextAt := p.replay.Frames[p.next].At; p.at > nextAt {
+		p.at = nextAt
+	}
+}
+
+func (p *replayPlayer) apply(control replayControl) error {
+	switch control.Action {
+	case "pause":
+		p.paused = true
+	case "play":
+		p.paused = false
+	case "speed":
+		p.speed = max(replayMinSpeed, min(replayMaxSpeed, control.Speed))
+	case "seek":
+		return p.seek(control.AtMs)
+	}
+	return nil
+}
+
+// seek jumps to a position, everything up to it is sent at once. Going back
+// starts over from the recorded state at the match start.
+func (p *replayPlayer) seek(atMs int64) error {
+	atMs = max(0, min(atMs, p.replay.Header.DurationMs))
+	if atMs < p.at {
+		p.next, p.at = 0, 0
+	}
+	return p.sendUntil(atMs)
+}
+
+// sendUntil sends every recorded message up to a position and moves there
+func (p *replayPlayer) sendUntil(atMs int64) error {
+	frames := p.replay.Frames
+	for ; p.next < len(frames) && frames[p.next].At <= atMs; p.next++ {
+		if frames[p.next].Message == nil {
+			continue // inputs are kept for re-simulation, clients get their outcome
+		}
+		if err := p.send(frames[p.next].Message); err != nil {
+			return err
+		}
+	}
+	p.at = atMs
+	return nil
+}
+
+func (p *replayPlayer) info() *gamev1.Envelope {
+	header := p.replay.Header
+	return &gamev1.Envelope{Payload: &gamev1.Envelope_Replayinfo{Replayinfo: &gamev1.ReplayInfo{
+		MatchId:    uint32(header.MatchID),
+		Mode:       string(header.Mode),
+		StartedAt:  header.StartedAt.Format(time.RFC3339Nano),
+		DurationMs: uint32(header.DurationMs),
+	}}}
+}
+
+func (p *replayPlayer) status() *gamev1.Envelope {
+	return &gamev1.Envelope{Payload: &gamev1.Envelope_Replaystatus{Replaystatus: &gamev1.ReplayStatus{
+		AtMs:       uint32(p.at),
+		DurationMs: uint32(p.replay.Header.DurationMs),
+		Paused:     p.paused,
+		Speed:      p.speed,
+		Ended:      p.next >= len(p.replay.Frames),
+	}}}
+}
+
+// sendEnvelope sends a playback message, it is not part of the recording
+// so it has no sequence number
+func (p *replayPlayer) sendEnvelope(envelope *gamev1.Envelope) error {
+	return p.send(marshalStandalone(envelope, p.clock.Now()))
+}
@@ -0,0 +1,117 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
+	"github.com/rs/zerolog/log"
+)
+
+// RoundSettings are the round timer settings a host picks before the match
+type RoundSettings struct {
+	Duration    time.Duration // 0 plays without a round timer
+	SuddenDeath bool
+}
+
+// RoundSettings returns the round timer settings of the world
+func (w *World) RoundSettings() RoundSettings {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+	return RoundSettings{Duration: w.RoundDuration, SuddenDeath: w.SuddenDeathEnabled}
+}
+
+// SetRoundSettings changes the round duration in seconds and whether the
+// round ends in sudden death, only before the match
+func (w *World) SetRoundSettings(durationSec int, suddenDeath bool) error {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+	if w.MatchStarted {
+		return fmt.Errorf("de game is al gestart")
+	}
+	if durationSec != 0 && (durationSec < RoundDurationMinSec || durationSec > RoundDurationMaxSec) {
+		return fmt.Errorf("speelduur moet tussen %d en %d seconden liggen", RoundDurationMinSec, RoundDurationMaxSec)
+	}
+
+	w.RoundDuration = time.Duration(durationSec) * time.Second
+	w.SuddenDeathEnabled = suddenDeath && durationSec != 0
+	return nil
+}
+
+// roundRemainingLocked returns the time left in the round, false when the
+// match does not run against a round timer (caller must hold worldLock)
+func (w *World) roundRemainingLocked(now time.Time) (time.Duration, bool) {
+	if !w.MatchStarted || w.MatchStartedAt.IsZero() || w.RoundDuration <= 0 {
+		return 0, false
+	}
+	return max(0, w.RoundDuration-now.Sub(w.MatchStartedAt)), true
+}
+
+// roundOverLocked reports whether the round timer ran out (caller must hold
+// worldLock)
+func (w *World) roundOverLocked(now time.Time) bool {
+	remaining, timed := w.roundRemainingLocked(now)
+	return timed && remaining == 0
+}
+
+// tickRoundTimerLocked sends the timer every TimerBroadcastMs and starts
+// sudden death once the end of the round is near (called from the loop with
+// worldLock held)
+func (w *World) tickRoundTimerLocked(now time.Time) {
+	remaining, timed := w.roundRemainingLocked(now)
+	if !timed {
+		return
+	}
+
+	if w.SuddenDeathEnabled && !w.suddenDeath && remaining <= SuddenDeathSec*time.Second {
+		w.startSuddenDeathLocked()
+	} else if !w.everyMs(TimerBroadcastMs) {
+		return
+	}
+
+	w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Timer{Timer: &gamev1.Timer{
+		RemainingMs: uint32(remaining.Milliseconds()),
+		DurationMs:  uint32(w.RoundDuration.Milliseconds()),
+		SuddenDeath: w.suddenDeath,
+	}}})
+}
+
+// startSuddenDeathLocked speeds everyone up and makes the power-ups vanish,
+// those left in the maze and those running (caller must hold worldLock)
+func (w *World) startSuddenDeathLocked() {
+	w.suddenDeath = true
+	w.EntityManager.SetSpeedFactor(SuddenDeathSpeedFactor)
+
+	vanished := make([]*gamev1.Point, 0)
+	for _, tile := range w.MazeData.ClearPowerUps() {
+		w.PowerUpsCoordsEaten.Add(float64(tile.X), float64(tile.Y))
+		vanished = append(vanished, &gamev1.Point{X: float64(tile.X), Y: float64(tile.Y)})
+	}
+
+	if w.IsPoweredUp {
+		w.IsPoweredUp = false
+		w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Powend{Powend: &gamev1.PowerUpEnd{}}})
+	}
+	w.calmChasersLocked()
+	for _, id := range w.sortedPlayerIdsLocked() {
+		if _, powered := w.PoweredUntil[id]; powered {
+			delete(w.PoweredUntil, id)
+			w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Powend{Powend: &gamev1.PowerUpEnd{PlayerId: id}}})
+		}
+	}
+
+	w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Suddendeath{Suddendeath: &gamev1.SuddenDeath{
+		PowerUps:    vanished,
+		SpeedFactor: SuddenDeathSpeedFactor,
+	}}})
+	log.Info().Int("powerups", len(vanished)).Msg("Sudden death started")
+}
+
+// speedFactorLocked is the factor on every player's and entity's speed
+// (caller must hold worldLock)
+func (w *World) speedFactorLocked() float64 {
+	if w.suddenDeath {
+		return SuddenDeathSpeedFactor
+	}
+	return 1
+}
@@ -0,0 +1,191 @@
+package game
+
+import "math/rand"
+
+// RunnerAI plays the runner: it routes along pellets, keeps away from
+// chasers, heads for a power-up when cornered and hunts while powered up
+type RunnerAI struct {
+	pathfinder *AStarPathfinder
+	difficulty DifficultyLevel
+	rng        *rand.Rand
+}
+
+// Runner AI tuning, distances are path lengths in tiles
+const (
+	runnerDangerTiles    = 6 // a chaser this close makes the runner flee
+	runnerPelletChoices  = 8 // nearest pellets weighed when picking a target
+	runnerHuntRangeTiles = 12
+)
+
+// NewRunnerAI creates a runner AI on a pathfinding grid
+func NewRunnerAI(grid *PathGrid, difficulty DifficultyLevel) *RunnerAI {
+	return &RunnerAI{
+		pathfinder: NewAStarPathfinder(grid),
+		difficulty: difficulty,
+		rng:        newUnseededRand(),
+	}
+}
+
+// SetRand makes the AI draw its random moves from a world's generator
+func (ai *RunnerAI) SetRand(rng *rand.Rand) {
+	ai.rng = rng
+}
+
+// SetDifficulty changes the difficulty of an existing AI
+func (ai *RunnerAI) SetDifficulty(difficulty DifficultyLevel) {
+	ai.difficulty = difficulty
+}
+
+// Difficulty returns the AI's difficulty
+func (ai *RunnerAI) Difficulty() DifficultyLevel {
+	return ai.difficulty
+}
+
+// GetNextMove picks the next tile for the runner. Threats are the chasers it
+// has to avoid, while hunting they are its prey instead.
+func (ai *RunnerAI) GetNextMove(runnerX, runnerY int, threats []TilePoint, hunting bool, maze *MazeData) (int, int) {
+	from := TilePoint{X: runnerX, Y: runnerY}
+	if ai.rng.Float64() >= ai.difficulty.PathAccuracy() {
+		return ai.randomMove(from)
+	}
+
+	if hunting {
+		if prey, ok := ai.nearest(from, threats, runnerHuntRangeTiles); ok {
+			return ai.stepTowards(from, prey)
+		}
+		threats = nil
+	}
+
+	if len(threats) > 0 && ai.threatDistance(from, threats) <= runnerDangerTiles {
+		if powerUp, ok := ai.safePowerUp(from, threats, maze); ok {
+			return ai.stepTowards(from, powerUp)
+		}
+		return ai.flee(from, threats)
+	}
+
+	if pellet, ok := ai.safePellet(from, threats, maze); ok {
+		return ai.stepTowards(from, pellet)
+	}
+	return ai.flee(from, threats)
+}
+
+// safePellet finds the nearest pellets by walking the maze and picks the
+// first one the runner reaches before any chaser
+func (ai *RunnerAI) safePellet(from TilePoint, threats []TilePoint, maze *MazeData) (TilePoint, bool) {
+	candidates := ai.search(from, runnerPelletChoices, maze.HasPellet)
+	for _, candidate := range candidates {
+		if ai.reachesFirst(from, candidate, threats) {
+			return candidate, true
+		}
+	}
+	if len(candidates) > 0 && len(threats) == 0 {
+		return candidates[0], true
+	}
+	return TilePoint{}, false
+}
+
+// safePowerUp returns the nearest power-up the runner reaches before any chaser
+func (ai *RunnerAI) safePowerUp(from TilePoint, threats []TilePoint, maze *MazeData) (TilePoint, bool) {
+	for _, candidate := range ai.search(from, maze.GetPowerUpCount(), maze.HasPowerUp) {
+		if ai.reachesFirst(from, candidate, threats) {
+			return candidate, true
+		}
+	}
+	return TilePoint{}, false
+}
+
+// flee moves to the neighbour furthest from the closest chaser
+func (ai *RunnerAI) flee(from TilePoint, threats []TilePoint) (int, int) {
+	best, bestDist := from, -1
+	for _, next := range ai.neighbours(from) {
+		if dist := ai.threatDistance(next, threats); dist > bestDist {
+			best, bestDist = next, dist
+		}
+	}
+	return best.X, best.Y
+}
+
+// threatDistance is the A* path length from the closest threat
+func (ai *RunnerAI) threatDistance(tile TilePoint, threats []TilePoint) int {
+	closest := runnerHuntRangeTiles * 10
+	for _, threat := range threats {
+		if dist := ai.pathLength(threat, tile); dist >= 0 && dist < closest {
+			closest = dist
+		}
+	}
+	return closest
+}
+
+// reachesFirst reports whether the runner gets to a tile before every threat
+func (ai *RunnerAI) reachesFirst(from, target TilePoint, threats []TilePoint) bool {
+	own := ai.pathLength(from, target)
+	return own >= 0 && own < ai.threatDistance(target, threats)
+}
+
+// nearest returns the target with the shortest path within a range
+func (ai *RunnerAI) nearest(from TilePoint, targets []TilePoint, maxDist int) (TilePoint, bool) {
+	best, bestDist := TilePoint{}, maxDist+1
+	for _, target := range targets {
+		if dist := ai.pathLength(from, target); dist >= 0 && dist < bestDist {
+			best, bestDist = target, dist
+		}
+	}
+	return best, bestDist <= maxDist
+}
+
+// search walks the maze outwards from a tile and returns up to limit other
+// tiles that match, nearest first
+func (ai *RunnerAI) search(from TilePoint, limit int, match func(x, y int) bool) []TilePoint {
+	found := []TilePoint{}
+	seen := map[TilePoint]bool{from: true}
+	queue := []TilePoint{from}
+	for len(queue) > 0 && len(found) < limit {
+		tile := queue[0]
+		queue = queue[1:]
+		if tile != from && match(tile.X, tile.Y) {
+			found = append(found, tile)
+		}
+		for _, next := range ai.neighbours(tile) {
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return found
+}
+
+func (ai *RunnerAI) stepTowards(from, target TilePoint) (int, int) {
+	path := ai.pathfinder.FindPath(from.X, from.Y, target.X, target.Y)
+	if len(path) > 1 {
+		return int(path[1].X), int(path[1].Y)
+	}
+	return from.X, from.Y
+}
+
+// pathLength is the number of steps between two tiles, -1 without a path
+func (ai *RunnerAI) pathLength(from, to TilePoint) int {
+	path := ai.pathfinder.FindPath(from.X, from.Y, to.X, to.Y)
+	return len(path) - 1
+}
+
+func (ai *RunnerAI) randomMove(from TilePoint) (int, int) {
+	neighbours := ai.neighbours(from)
+	if len(neighbours) == 0 {
+		return from.X, from.Y
+	}
+	next := neighbours[ai.rng.Intn(len(neighbours))]
+	return next.X, next.Y
+}
+
+func (ai *RunnerAI) neighbours(tile TilePoint) []TilePoint {
+	node := ai.pathfinder.grid.GetNode(tile.X, tile.Y)
+	if node == nil {
+		return nil
+	}
+	result := []TilePoint{}
+	for _, next := range ai.pathfinder.getNeighbors(node) {
+		result = append(result, TilePoint{X: next.X, Y: next.Y})
+	}
+	return result
+}
@@ -0,0 +1,238 @@
+package game
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
+)
+
+// SimTimeoutWinner is the outcome of a simulated game that hit MaxDuration,
+// kept apart from a draw the rules decided
+const SimTimeoutWinner = "Tijdslimiet"
+
+// SimConfig describes a batch of bot-only matches
+type SimConfig struct {
+	Map  string // a map name or GeneratedMapName
+	Mode GameMode
+	// Bots sets the strategy and aggression of the bots in order of creation,
+	// the default line-up is used when empty. Bots take the sprite slots from
+	// the end, so in classic the first bot is the runner.
+	Bots       []BotProfile
+	Difficulty DifficultyLevel
+	// Seed of the first game, game i seeds its world and generated map with
+	// Seed+i, so the same config always gives the same result
+	Seed  int64
+	Games int
+	// MaxDuration ends a game that is still undecided as a timeout
+	MaxDuration time.Duration
+	// Workers is the number of games played at the same time
+	Workers int
+}
+
+// SimGame is the outcome of a single simulated game
+type SimGame struct {
+	Seed     int64         `json:"seed"`
+	Winner   string        `json:"winner"`
+	Reason   string        `json:"reason"`
+	TimedOut bool          `json:"timedOut"`
+	Duration time.Duration `json:"-"`
+	Pellets  int           `json:"pellets"`
+}
+
+// MarshalJSON writes the duration in seconds, like the summary
+func (g SimGame) MarshalJSON() ([]byte, error) {
+	type simGame SimGame
+	return json.Marshal(struct {
+		simGame
+		DurationSec float64 `json:"duration_sec"`
+	}{simGame(g), g.Duration.Seconds()})
+}
+
+// SimOutcome aggregates the games that ended with the same winner
+type SimOutcome struct {
+	Winner         string  `json:"winner"`
+	TimedOut       bool    `json:"timedOut"`
+	Wins           int     `json:"wins"`
+	WinRate        float64 `json:"winRate"`
+	AvgDurationSec float64 `json:"avgDurationSec"`
+	AvgPellets     float64 `json:"avgPellets"`
+}
+
+// SimResult is the summary of a simulation batch
+type SimResult struct {
+	Map            string       `json:"map"`
+	Mode           GameMode     `json:"mode"`
+	Seed           int64        `json:"seed"`
+	Games          int          `json:"games"`
+	AvgDurationSec float64      `json:"avgDurationSec"`
+	AvgPellets     float64      `json:"avgPellets"`
+	Outcomes       []SimOutcome `json:"outcomes"`
+}
+
+// Simulate plays the configured number of bot-only matches on virtual time,
+// without sockets, and summarizes win rates, durations and pellets eaten
+func Simulate(cfg SimConfig) (*SimResult, error) {
+	if cfg.Games <= 0 {
+		return nil, fmt.Errorf("at least one game is needed")
+	}
+	if cfg.MaxDuration <= 0 {
+		cfg.MaxDuration = SimDefaultMaxDuration
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+
+	games := make([]SimGame, cfg.Games)
+	errs := make([]error, cfg.Games)
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for game := range next {
+				games[game], errs[game] = SimulateGame(cfg, cfg.Seed+int64(game))
+			}
+		}()
+	}
+	for game := 0; game < cfg.Games; game++ {
+		next <- game
+	}
+	close(next)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return summarizeSimulation(cfg, games), nil
+}
+
+// SimulateGame plays one bot-only match with the given seed, stepping the
+// world on a ManualClock until it is decided or MaxDuration has passed
+func SimulateGame(cfg SimConfig, seed int64) (SimGame, error) {
+	mazeMap, err := ResolveMazeMap(cfg.Map, seed)
+	if err != nil {
+		return SimGame{}, err
+	}
+
+	clock := NewManualClock(time.Unix(0, 0).UTC())
+	world := NewWorldStateWithMap(cfg.Mode, mazeMap)
+	world.SetClock(clock)
+	world.SetSeed(seed)
+	world.BotDifficulty = cfg.Difficulty
+	world.BotManager = NewBotManager(world, func(*gamev1.Envelope) error { return nil })
+	world.BotManager.SetProfiles(cfg.Bots)
+	world.BotManager.FillWithBots()
+
+	start := clock.Now()
+	world.StartMatch(start)
+	world.StartDynamicSystems()
+
+	result := SimGame{Seed: seed, Winner: SimTimeoutWinner, Reason: "Tijdslimiet bereikt", TimedOut: true}
+	for decided := false; !decided && clock.Now().Sub(start) < cfg.MaxDuration; {
+		clock.Advance(TickRateMs * time.Millisecond)
+		world.Step(clock.Now())
+
+		select {
+		case info := <-world.gameOverChan:
+			result.Winner, result.Reason, result.TimedOut = info.Winner, info.Reason, false
+			decided = true
+		default:
+		}
+	}
+
+	result.Duration = clock.Now().Sub(start)
+	result.Pellets = world.PelletsCoordEaten.Len()
+	return result, nil
+}
+
+func summarizeSimulation(cfg SimConfig, games []SimGame) *SimResult {
+	result := &SimResult{
+		Map:   cfg.Map,
+		Mode:  RulesForMode(cfg.Mode).Mode(),
+		Seed:  cfg.Seed,
+		Games: len(games),
+	}
+
+	if result.Map == "" {
+		result.Map = DefaultMapName
+	}
+
+	// Timeouts count as their own outcome, whatever a winner is called
+	type outcomeKey struct {
+		winner   string
+		timedOut bool
+	}
+	outcomes := map[outcomeKey]*SimOutcome{}
+	for _, game := range games {
+		key := outcomeKey{game.Winner, game.TimedOut}
+		outcome, ok := outcomes[key]
+		if !ok {
+			outcome = &SimOutcome{Winner: game.Winner, TimedOut: game.TimedOut}
+			outcomes[key] = outcome
+		}
+		outcome.Wins++
+		outcome.AvgDurationSec += game.Duration.Seconds()
+		outcome.AvgPellets += float64(game.Pellets)
+		result.AvgDurationSec += game.Duration.Seconds()
+		result.AvgPellets += float64(game.Pellets)
+	}
+
+	result.AvgDurationSec /= float64(len(games))
+	result.AvgPellets /= float64(len(games))
+	for _, outcome := range outcomes {
+		outcome.WinRate = float64(outcome.Wins) / float64(len(games))
+		outcome.AvgDurationSec /= float64(outcome.Wins)
+		outcome.AvgPellets /= float64(outcome.Wins)
+		result.Outcomes = append(result.Outcomes, *outcome)
+	}
+	sort.Slice(result.Outcomes, func(i, j int) bool {
+		a, b := result.Outcomes[i], result.Outcomes[j]
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+		if a.Winner != b.Winner {
+			return a.Winner < b.Winner
+		}
+		return !a.TimedOut
+	})
+	return result
+}
+
+// WriteJSON writes the result as indented JSON
+func (r *SimResult) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteCSV writes a row per outcome and a final row over all games
+func (r *SimResult) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	row := func(winner string, wins int, rate, duration, pellets float64, timedOut bool) []string {
+		return []string{
+			winner,
+			strconv.Itoa(wins),
+			strconv.FormatFloat(rate, 'f', 4, 64),
+			strconv.FormatFloat(duration, 'f', 2, 64),
+			strconv.FormatFloat(pellets, 'f', 2, 64),
+			strconv.FormatBool(timedOut),
+		}
+	}
+
+	records := [][]string{{"winner", "games", "winRate", "avgDurationSec", "avgPellets", "timedOut"}}
+	for _, outcome := range r.Outcomes {
+		records = append(records, row(outcome.Winner, outcome.Wins, outcome.WinRate, outcome.AvgDurationSec, outcome.AvgPellets, outcome.TimedOut))
+	}
+	records = append(records, row("all", r.Games, 1, r.AvgDurationSec, r.AvgPellets, false))
+	return writer.WriteAll(records)
+}
@@ -25,6 +25,14 @@ func NewInputValidator() *InputValidator {
 	}
 }
 
+// NewInputValidatorForMap creates a validator with the boundaries of a map
+func NewInputValidatorForMap(m *MazeMap) *InputValidator {
+	v := NewInputValidator()
+	v.maxPositionX = float64(m.Width) * TileSizeFloat
+	v.maxPositionY = float64(m.Height) * TileSizeFloat
+	return v
+}
+
 // ValidationError represents a validation failure
 type ValidationError struct {
 	Field   string
@@ -1,13 +1,18 @@
 package game
 
 import (
-	"encoding/json"
 	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync/atomic"
 	"time"
 
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
 	"github.com/frank2889/mazechase/pkg"
 	"github.com/olahol/melody"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
 	"sync"
 )
 
@@ -18,11 +23,32 @@ type GameOverInfo struct {
 }
 
 type World struct {
+	LobbyID             uint
 	gameOverChan        chan GameOverInfo
+	Rules               GameRules
 	MatchStarted        bool
+	MatchStartedAt      time.Time
+	
+	// Round timer, see round_timer.go: the match ends RoundDuration after it
+	// started (never when 0), the end of it is sudden death when enabled
+	RoundDuration       time.Duration
+	SuddenDeathEnabled  bool
+	suddenDeath         bool
+	
+	// Power-up of the runner, it frightens the chasers in play, see
+	// chaser_house.go. Eaten chasers are in ChasersIdsEaten until the house
+	// releases them again
 	IsPoweredUp         bool
 	PowerUpEndTime      time.Time
-	PowerUpTimer        *time.Timer
+	Chasers             map[SpriteType]*ChaserStatus
+	nextReleaseAt       time.Time
+	
+	// Classic progression, see lives.go: the runner's lives left and the
+	// level, a lost life or a new level freezes everyone until frozenUntil
+	Lives               int
+	Level               int
+	frozenUntil         time.Time
+	respawnPending      bool
 	CharactersList      []SpriteType
 	ChasersIdsEaten     []SpriteType
 	ConnectedPlayers    *pkg.Map[string, *melody.Session]
@@ -31,12 +57,17 @@ type World struct {
 	PowerUpsCoordsEaten CoordList
 	worldLock           sync.Mutex
 	BotManager          *BotManager
+	BotDifficulty       DifficultyLevel // picked by the host before the match
 	botFillScheduled    bool
 	HostPlayerId        string
 	CountdownStarted    bool
 	
-	// Maze collision data
+	// Map the match is played on and the collision data built from it,
+	// maze updates change MazeData, PathGrid and the entity layout together
+	Map             *MazeMap
 	MazeData        *MazeData
+	PathGrid        *PathGrid
+	TotalPellets    int
 	
 	// Player positions (for collision detection)
 	PlayerPositions map[string]*PointF
@@ -50,44 +81,160 @@ type World struct {
 	// Score tracking
 	Scores          map[string]int
 	
+	// Players indexes every player entity (humans and bots) by player id
+	Players         map[string]*PlayerEntity
+	
+	// Per-player power-ups and eliminations, for modes without a global power-up
+	PoweredUntil    map[string]time.Time
+	Eliminated      []string
+	
+	// Players caught by a danger entity: stunned ones cannot move, and
+	// entities leave them alone until their grace ends, see entity_catch.go
+	StunnedUntil     map[string]time.Time
+	entityGraceUntil map[string]time.Time
+	
+	// Participants keeps every player that took part in the match, even after
+	// leaving or being eliminated, Stats holds their counters for the match record
+	Participants    map[string]*PlayerEntity
+	Stats           map[string]*PlayerGameStats
+	
+	// Players whose socket dropped, held for a reconnect, see reconnect.go
+	disconnected    map[string]*heldPlayer
+	
+	// Simulation loop state, see loop.go
+	Tick            uint64
+	inputs          []PlayerInput
+	inputLock       sync.Mutex
+	pendingEvents   []*gamev1.Envelope
+	dynamicActive   bool
+	gameEnded       bool
+	stopLoop        chan struct{}
+	
+	// Time source of the world, the wall clock unless SetClock replaces it
+	clock           Clock
+	
+	// Seed of the random generator shared by bots, entities and the dynamic
+	// world, the same seed and inputs replay a match
+	Seed            int64
+	rng             *rand.Rand
+	
+	// Records the match for a replay file when set, see replay.go
+	recorder        *ReplayRecorder
+	
+	// Checks client input against the map and the server state, see anticheat.go
+	Validator       *InputValidator
+	AntiCheat       *AntiCheat
+	
 	// Broadcast function reference
-	broadcastFunc   func([]byte) error
+	broadcastFunc   func(*gamev1.Envelope) error
+	
+	// Sequence number of the last message sent to the clients
+	seq             atomic.Uint64
 }
 
 func NewWorldState() *World {
-	// Default maze dimensions (will be updated when maze loads)
-	mazeWidth := MazeWidth
-	mazeHeight := MazeHeight
+	return NewWorldStateForMode(ModeClassic)
+}
+
+// NewWorldStateForMode creates a world played with the rules of the given mode
+// on the default map
+func NewWorldStateForMode(mode GameMode) *World {
+	return NewWorldStateWithMap(mode, DefaultMazeMap())
+}
+
+// NewWorldStateWithMap creates a world played with the rules of the given mode
+// on the given map
+func NewWorldStateWithMap(mode GameMode, mazeMap *MazeMap) *World {
+	rules := RulesForMode(mode)
 	
-	dynamicWorld := NewDynamicWorld(mazeWidth, mazeHeight)
+	mazeWidth := mazeMap.Width
+	mazeHeight := mazeMap.Height
+	
+	dynamicWorld := NewDynamicWorldForMap(mazeMap)
 	entityManager := NewEntityManager(mazeWidth, mazeHeight, dynamicWorld)
+	entityManager.SetMazeData(mazeMap.WalkGrid())
+	mazeData := NewMazeDataFromMap(mazeMap)
+	
+	seed := time.Now().UnixNano()
+	rng := NewRand(seed)
+	dynamicWorld.SetRand(rng)
+	entityManager.SetRand(rng)
 	
 	return &World{
+		Rules:               rules,
 		MatchStarted:        false,
+		RoundDuration:       RoundDuration,
 		IsPoweredUp:         false,
-		CharactersList:      []SpriteType{Chaser1, Chaser2, Chaser3, Runner},
+		CharactersList:      rules.Sprites(),
 		ConnectedPlayers:    &pkg.Map[string, *melody.Session]{},
 		Spectators:          &pkg.Map[string, *melody.Session]{},
 		PelletsCoordEaten:   NewCordList(),
 		PowerUpsCoordsEaten: NewCordList(),
 		ChasersIdsEaten:     []SpriteType{},
+		Chasers:             make(map[SpriteType]*ChaserStatus),
+		Lives:               RunnerLives,
+		Level:               1,
 		worldLock:           sync.Mutex{},
 		gameOverChan:        make(chan GameOverInfo, 1),
 		BotManager:          nil, // Will be set when broadcast function is available
 		botFillScheduled:    false,
 		HostPlayerId:        "",
 		CountdownStarted:    false,
-		MazeData:            NewMazeData(),
+		Map:                 mazeMap,
+		MazeData:            mazeData,
+		PathGrid:            mazeData.PathGrid(),
+		BotDifficulty:       DifficultyMedium,
+		TotalPellets:        mazeMap.TotalPellets,
 		PlayerPositions:     make(map[string]*PointF),
 		DynamicWorld:        dynamicWorld,
 		EntityManager:       entityManager,
 		MazeWidth:           mazeWidth,
 		MazeHeight:          mazeHeight,
 		Scores:              make(map[string]int),
+		Players:             make(map[string]*PlayerEntity),
+		PoweredUntil:        make(map[string]time.Time),
+		Eliminated:          []string{},
+		StunnedUntil:        make(map[string]time.Time),
+		entityGraceUntil:    make(map[string]time.Time),
+		Participants:        make(map[string]*PlayerEntity),
+		Stats:               make(map[string]*PlayerGameStats),
+		disconnected:        make(map[string]*heldPlayer),
+		clock:               WallClock{},
+		Seed:                seed,
+		rng:                 rng,
+		Validator:           NewInputValidatorForMap(mazeMap),
+		AntiCheat:           NewAntiCheat(),
 	}
 }
 
+// SetSeed reseeds the random generator of the world, call it before bots
+// join and the match starts to make the match reproducible
+func (w *World) SetSeed(seed int64) {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+	w.Seed = seed
+	w.rng.Seed(seed)
+}
+
+// SetClock replaces the time source of the world, call it before the loop
+// starts
+func (w *World) SetClock(clock Clock) {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+	w.clock = clock
+}
+
+// Clock returns the time source of the world
+func (w *World) Clock() Clock {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+	return w.clock
+}
+
 func (w *World) Join(player *PlayerEntity, session *melody.Session) error {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+
 	if w.IsLobbyFull() {
 		log.Error().Msg("lobby is full")
 		return fmt.Errorf("lobby is full")
@@ -105,19 +252,35 @@ func (w *World) Join(player *PlayerEntity, session *melody.Session) error {
 	w.CharactersList = w.CharactersList[:len(w.CharactersList)-1]
 
 	// Initialize player at spawn position
-	w.InitPlayerPosition(player)
+	w.initPlayerPositionLocked(player)
 	
 	// Initialize score
 	w.Scores[player.PlayerId] = 0
 
 	// assign new player to world
+	w.Players[player.PlayerId] = player
+	w.Participants[player.PlayerId] = player
 	w.ConnectedPlayers.Store(player.PlayerId, session)
 
 	return nil
 }
 
 func (w *World) Leave(player *PlayerEntity) {
+	w.Spectators.Delete(player.PlayerId)
+
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+	w.leaveLocked(player)
+}
+
+// leaveLocked frees the sprite of a player and ends the game once everyone
+// left (caller must hold worldLock)
+func (w *World) leaveLocked(player *PlayerEntity) {
 	id := player.PlayerId
+	delete(w.disconnected, id)
+	if w.BotManager != nil {
+		w.BotManager.removeStandIn(id)
+	}
 
 	_, exists := w.ConnectedPlayers.Load(id)
 	if !exists {
@@ -125,16 +288,26 @@ func (w *World) Leave(player *PlayerEntity) {
 	}
 
 	w.CharactersList = append(w.CharactersList, player.SpriteType)
+	delete(w.Players, id)
+	delete(w.PlayerPositions, id)
+	if !w.MatchStarted {
+		delete(w.Participants, id)
+	}
 	w.ConnectedPlayers.Delete(id)
 
-	if len(w.CharactersList) == 4 {
+	if len(w.CharactersList) == len(w.Rules.Sprites()) {
 		w.GameOver("Alle spelers hebben de lobby verlaten", "Niemand")
 	}
 }
 
-func (w *World) GetGameStateReport(secretToken, username, spriteId string, newPlayer *melody.Session) ([]byte, error) {
-	connectedMap := map[string]interface{}{}
-	playersList := []map[string]interface{}{}
+// GetGameStateReport is the state message a player gets on joining or
+// resuming, built under worldLock as the loop keeps changing the world
+func (w *World) GetGameStateReport(secretToken, username, spriteId string, newPlayer *melody.Session) *gamev1.Envelope {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+
+	connectedMap := map[string]*gamev1.ActivePlayer{}
+	playersList := []*gamev1.LobbyPlayer{}
 
 	// Get the requesting player's info
 	requestingPlayer, _ := getPlayerEntityFromSession(newPlayer)
@@ -154,115 +327,175 @@ func (w *World) GetGameStateReport(secretToken, username, spriteId string, newPl
 			continue
 		}
 
-		connectedMap[string(otherPlayerEntity.SpriteType)] = map[string]interface{}{
-			"username": otherPlayerEntity.Username,
-			"x":        otherPlayerEntity.X,
-			"y":        otherPlayerEntity.Y,
+		connectedMap[string(otherPlayerEntity.SpriteType)] = &gamev1.ActivePlayer{
+			Username: otherPlayerEntity.Username,
+			X:        otherPlayerEntity.X,
+			Y:        otherPlayerEntity.Y,
 		}
 
-		playersList = append(playersList, map[string]interface{}{
-			"playerId":   otherPlayerEntity.PlayerId,
-			"username":   otherPlayerEntity.Username,
-			"spriteType": otherPlayerEntity.SpriteType,
-			"isReady":    otherPlayerEntity.IsReady,
-			"isHost":     otherPlayerEntity.IsHost,
-		})
+		playersList = append(playersList, otherPlayerEntity.lobbyPlayer())
 	}
 
 	// Check if this player is the host
 	isHost := w.HostPlayerId != "" && w.HostPlayerId == requestingPlayerId
+	level, lives := w.progressLocked()
 
-	data := map[string]interface{}{
-		"type":           "state",
-		"chasersEaten":   w.ChasersIdsEaten,
-		"activePlayers":  connectedMap,
-		"playersList":    playersList,
-		"pelletsEaten":   w.PelletsCoordEaten.GetList(),
-		"powerUpsEaten":  w.PowerUpsCoordsEaten.GetList(),
-		"secretToken":    secretToken,
-		"spriteId":       spriteId,
-		"spriteType":     spriteId,
-		"username":       username,
-		"playerId":       requestingPlayerId,
-		"matchStarted":   w.MatchStarted,
-		"hostId":         w.HostPlayerId,
-		"isHost":         isHost,
-		"playerCount":    w.GetPlayerCount(),
-		"readyCount":     w.GetReadyCount(),
-		"scores":         w.GetAllScores(),
-		"spawnPositions": getSpawnPositionsPixels(),
-	}
-	return json.Marshal(data)
+	state := &gamev1.State{
+		ProtocolVersion: ProtocolVersion,
+		Mode:            string(w.Rules.Mode()),
+		ChasersEaten:    spriteNames(w.ChasersIdsEaten),
+		Chasers:         w.chaserPhasesLocked(),
+		Level:           level,
+		Lives:           lives,
+		Eliminated:      w.Eliminated,
+		ActivePlayers:   connectedMap,
+		PlayersList:     playersList,
+		PelletsEaten:    protoPoints(w.PelletsCoordEaten.GetList()),
+		PowerUpsEaten:   protoPoints(w.PowerUpsCoordsEaten.GetList()),
+		SecretToken:     secretToken,
+		SpriteId:        spriteId,
+		SpriteType:      spriteId,
+		Username:        username,
+		PlayerId:        requestingPlayerId,
+		MatchStarted:    w.MatchStarted,
+		HostId:          w.HostPlayerId,
+		IsHost:          isHost,
+		PlayerCount:     int32(w.GetPlayerCount()),
+		ReadyCount:      int32(w.GetReadyCount()),
+		Scores:          protoScores(w.allScoresLocked()),
+		SpawnPositions:  w.Map.SpawnPixels(),
+		Map:             w.Map.ClientInfo(),
+	}
+
+	// A (resumed) player gets back where it stands and the token to resume again
+	if requestingPlayer != nil && !requestingPlayer.IsSpectator {
+		resumeToken := requestingPlayer.ResumeToken()
+		state.ResumeToken = &resumeToken
+		state.X = &requestingPlayer.X
+		state.Y = &requestingPlayer.Y
+	}
+	return &gamev1.Envelope{Payload: &gamev1.Envelope_State{State: state}}
 }
 
 func (w *World) MovePlayer(player *PlayerEntity, x, y float64) {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+	w.movePlayerLocked(player, x, y)
+}
+
+// movePlayerLocked updates a player position and the collision cache (caller must hold worldLock)
+func (w *World) movePlayerLocked(player *PlayerEntity, x, y float64) {
 	player.X = x
 	player.Y = y
-	
-	// Update position cache for collision detection
-	w.worldLock.Lock()
 	w.PlayerPositions[player.PlayerId] = &PointF{X: x, Y: y}
-	w.worldLock.Unlock()
 }
 
 // MovePlayerByDirection moves a player in a direction with collision checking
 func (w *World) MovePlayerByDirection(player *PlayerEntity, dir string) (float64, float64, bool) {
-	speed := PlayerSpeed * TickRateSec
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+	
+	_, moved := w.stepPlayerLocked(player, dir, PlayerSpeed*TickRateSec, w.clock.Now())
+	return player.X, player.Y, moved
+}
+
+// stepPlayerLocked moves a player one step in a direction, resolves pellets and
+// power-ups on the destination tile and returns the resulting pos event.
+// Caller must hold worldLock.
+func (w *World) stepPlayerLocked(player *PlayerEntity, dir string, step float64, now time.Time) (*gamev1.Envelope, bool) {
 	newX, newY := player.X, player.Y
 	
 	switch dir {
 	case "up":
-		newY -= speed
+		newY -= step
 	case "down":
-		newY += speed
+		newY += step
 	case "left":
-		newX -= speed
+		newX -= step
 	case "right":
-		newX += speed
+		newX += step
 	default:
-		return player.X, player.Y, false
+		return nil, false
+	}
+	
+	// Walking off the map through a tunnel comes out at its other end
+	if w.MazeData != nil {
+		if exitX, exitY, ok := w.MazeData.TunnelExit(player.X, player.Y, newX, newY); ok {
+			newX, newY = exitX, exitY
+		}
 	}
 	
 	// Check wall collision
-	if !w.MazeData.CanMoveTo(player.X, player.Y, newX, newY) {
-		return player.X, player.Y, false
+	if w.MazeData != nil && !w.MazeData.CanMoveTo(player.X, player.Y, newX, newY) {
+		return nil, false
 	}
 	
 	// Update position
-	w.MovePlayer(player, newX, newY)
+	w.movePlayerLocked(player, newX, newY)
 	player.Dir = dir
 	
+	update := player.ToProto()
+	event := &gamev1.Envelope{Payload: &gamev1.Envelope_Pos{Pos: update}}
+	if w.MazeData == nil {
+		return event, true
+	}
+	
 	// Check pellet collision
 	tileX, tileY := PixelToTile(newX, newY)
-	if w.MazeData.EatPellet(tileX, tileY) {
-		w.PelletsCoordEaten.Add(float64(tileX), float64(tileY))
-		w.addScore(player.PlayerId, PelletScore)
+	if w.eatPelletLocked(player, tileX, tileY) {
+		update.Pellet = &gamev1.TilePos{X: int32(tileX), Y: int32(tileY)}
+		update.Score = proto.Int32(int32(w.Scores[player.PlayerId]))
 	}
 	
-	// Check power-up collision (use EatPowerUp which handles timer)
-	if w.MazeData.EatPowerUp(tileX, tileY) {
-		w.addScore(player.PlayerId, PowerUpScore)
-		w.EatPowerUp(float64(tileX), float64(tileY))
+	// Check power-up collision, only the rules decide whether it powers the player
+	if w.eatPowerUpTileLocked(player, tileX, tileY, now) {
+		update.PowerUp = &gamev1.TilePos{X: int32(tileX), Y: int32(tileY)}
+		if w.isPoweredLocked(player.PlayerId, now) {
+			update.Powered = proto.Bool(true)
+		}
+		update.Score = proto.Int32(int32(w.Scores[player.PlayerId]))
 	}
 	
-	return newX, newY, true
+	return event, true
+}
+
+// eatPelletLocked lets a player eat the pellet on a tile, false when there is
+// none (caller must hold worldLock)
+func (w *World) eatPelletLocked(player *PlayerEntity, tileX, tileY int) bool {
+	if !w.MazeData.EatPellet(tileX, tileY) {
+		return false
+	}
+	w.PelletsCoordEaten.Add(float64(tileX), float64(tileY))
+	w.Scores[player.PlayerId] += PelletScore
+	w.playerStatsLocked(player.PlayerId).PelletsEaten++
+	return true
+}
+
+// eatPowerUpTileLocked lets a player eat the power-up on a tile, false when
+// there is none (caller must hold worldLock)
+func (w *World) eatPowerUpTileLocked(player *PlayerEntity, tileX, tileY int, now time.Time) bool {
+	if !w.MazeData.EatPowerUp(tileX, tileY) {
+		return false
+	}
+	w.Scores[player.PlayerId] += PowerUpScore
+	w.playerStatsLocked(player.PlayerId).PowerUpsUsed++
+	w.Rules.EatPowerUp(w, player, float64(tileX), float64(tileY), now)
+	return true
 }
 
 // InitPlayerPosition sets spawn position based on sprite type
 func (w *World) InitPlayerPosition(player *PlayerEntity) {
-	spawn, ok := SpawnPositions[player.SpriteType]
-	if !ok {
-		spawn = TilePoint{X: 14, Y: 23} // Default to runner spawn
-	}
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+	w.initPlayerPositionLocked(player)
+}
+
+func (w *World) initPlayerPositionLocked(player *PlayerEntity) {
+	spawn := w.Map.Spawn(player.SpriteType)
 	
 	// Convert tile to pixel (center of tile)
 	pixelX, pixelY := TileToPixel(spawn.X, spawn.Y)
-	player.X = pixelX
-	player.Y = pixelY
-	
-	w.worldLock.Lock()
-	w.PlayerPositions[player.PlayerId] = &PointF{X: pixelX, Y: pixelY}
-	w.worldLock.Unlock()
+	w.movePlayerLocked(player, pixelX, pixelY)
 }
 
 // addScore adds points to a player's score
@@ -283,6 +516,12 @@ func (w *World) GetScore(playerId string) int {
 func (w *World) GetAllScores() map[string]int {
 	w.worldLock.Lock()
 	defer w.worldLock.Unlock()
+	return w.allScoresLocked()
+}
+
+// allScoresLocked returns a copy of all player scores (caller must hold
+// worldLock)
+func (w *World) allScoresLocked() map[string]int {
 	scores := make(map[string]int)
 	for k, v := range w.Scores {
 		scores[k] = v
@@ -294,21 +533,15 @@ func (w *World) GetAllScores() map[string]int {
 func (w *World) CheckPlayerCollisions() (collided bool, runnerId string, chaserId SpriteType) {
 	w.worldLock.Lock()
 	defer w.worldLock.Unlock()
-	
+	return w.checkPlayerCollisionsLocked()
+}
+
+func (w *World) checkPlayerCollisionsLocked() (collided bool, runnerId string, chaserId SpriteType) {
 	var runnerPos *PointF
 	var runnerPlayerId string
 	chaserPositions := make(map[SpriteType]*PointF)
 	
-	// Get all player positions
-	for _, session := range w.ConnectedPlayers.GetValues() {
-		if session == nil {
-			continue
-		}
-		player, err := getPlayerEntityFromSession(session)
-		if err != nil {
-			continue
-		}
-		
+	for _, player := range w.Players {
 		pos := w.PlayerPositions[player.PlayerId]
 		if pos == nil {
 			continue
@@ -317,18 +550,8 @@ func (w *World) CheckPlayerCollisions() (collided bool, runnerId string, chaserI
 		if player.SpriteType == Runner {
 			runnerPos = pos
 			runnerPlayerId = player.PlayerId
-		} else {
-			// Check if chaser is eaten
-			eaten := false
-			for _, eatenId := range w.ChasersIdsEaten {
-				if eatenId == player.SpriteType {
-					eaten = true
-					break
-				}
-			}
-			if !eaten {
-				chaserPositions[player.SpriteType] = pos
-			}
+		} else if !w.isChaserEatenLocked(player.SpriteType) {
+			chaserPositions[player.SpriteType] = pos
 		}
 	}
 	
@@ -336,9 +559,10 @@ func (w *World) CheckPlayerCollisions() (collided bool, runnerId string, chaserI
 		return false, "", ""
 	}
 	
-	// Check collision with each chaser
-	for chaserType, chaserPos := range chaserPositions {
-		if CollisionCheck(runnerPos.X, runnerPos.Y, chaserPos.X, chaserPos.Y) {
+	// Check collision with each chaser, in sprite order so the outcome is deterministic
+	for _, chaserType := range []SpriteType{Chaser1, Chaser2, Chaser3} {
+		chaserPos, ok := chaserPositions[chaserType]
+		if ok && CollisionCheck(runnerPos.X, runnerPos.Y, chaserPos.X, chaserPos.Y) {
 			return true, runnerPlayerId, chaserType
 		}
 	}
@@ -346,28 +570,126 @@ func (w *World) CheckPlayerCollisions() (collided bool, runnerId string, chaserI
 	return false, "", ""
 }
 
+func (w *World) isChaserEatenLocked(sprite SpriteType) bool {
+	for _, eatenId := range w.ChasersIdsEaten {
+		if eatenId == sprite {
+			return true
+		}
+	}
+	return false
+}
+
 func (w *World) IsLobbyFull() bool {
 	return len(w.CharactersList) == 0
 }
 
-// TotalPellets is the total number of pellets on the map
-const TotalPellets = 201
-
 func (w *World) checkGameOver() (reason string, winner string) {
-	if len(w.ChasersIdsEaten) == 3 {
-		return "Alle chasers uitgeschakeld", "Runner"
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+	return w.Rules.CheckGameOver(w, w.clock.Now())
+}
+
+// StartMatch marks the match as running, round timers count from now
+func (w *World) StartMatch(now time.Time) {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+	w.MatchStarted = true
+	w.MatchStartedAt = now
+
+	if w.recorder != nil {
+		state := w.spectatorStateLocked()
+		state.Replay = true
+		marshal, err := w.marshal(&gamev1.Envelope{Payload: &gamev1.Envelope_State{State: state}})
+		if err != nil {
+			log.Error().Err(err).Msg("Unable to marshal replay state")
+			return
+		}
+		w.recorder.Start(now, marshal)
 	}
+}
 
-	// Check if all pellets are eaten
-	if w.PelletsCoordEaten.Len() >= TotalPellets {
-		return "Alle pellets verzameld!", "Runner"
+// recordBroadcast adds a message sent to every client to the replay in its
+// JSON encoding, it may be called with worldLock held so it reads the clock,
+// which is fixed before the loop starts, without locking
+func (w *World) recordBroadcast(message *frames) {
+	if w.recorder == nil {
+		return
+	}
+	frame, err := message.encode(EncodingJSON)
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to record message")
+		return
 	}
+	w.recorder.RecordMessage(w.clock.Now(), frame)
+}
+
+// SpectatorStateReport is the state message of a spectator, it shows every
+// player, bots included, where they stand right now
+func (w *World) SpectatorStateReport(spectator *PlayerEntity) *gamev1.Envelope {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
 
-	return "", ""
+	state := w.spectatorStateLocked()
+	state.MatchStarted = w.MatchStarted
+	state.SecretToken = spectator.secretToken
+	state.Username = spectator.Username
+	state.PlayerId = spectator.PlayerId
+	state.IsSpectator = true
+	state.SpectatorCount = int32(w.GetSpectatorCount())
+	return &gamev1.Envelope{Payload: &gamev1.Envelope_State{State: state}}
+}
+
+// spectatorStateLocked is the state message of a spectator and the one a
+// replay starts with, it shows every player, bots included (caller must hold
+// worldLock)
+func (w *World) spectatorStateLocked() *gamev1.State {
+	activePlayers := map[string]*gamev1.ActivePlayer{}
+	playersList := []*gamev1.LobbyPlayer{}
+	for _, id := range w.sortedPlayerIdsLocked() {
+		player := w.Players[id]
+		activePlayers[string(player.SpriteType)] = &gamev1.ActivePlayer{
+			Username: player.Username,
+			X:        player.X,
+			Y:        player.Y,
+		}
+		playersList = append(playersList, &gamev1.LobbyPlayer{
+			PlayerId:   player.PlayerId,
+			Username:   player.Username,
+			SpriteType: string(player.SpriteType),
+			IsReady:    true,
+			IsHost:     player.PlayerId == w.HostPlayerId,
+		})
+	}
+
+	level, lives := w.progressLocked()
+	return &gamev1.State{
+		ProtocolVersion: ProtocolVersion,
+		Mode:            string(w.Rules.Mode()),
+		ChasersEaten:    spriteNames(w.ChasersIdsEaten),
+		Chasers:         w.chaserPhasesLocked(),
+		Level:           level,
+		Lives:           lives,
+		Eliminated:      w.Eliminated,
+		ActivePlayers:   activePlayers,
+		PlayersList:     playersList,
+		PelletsEaten:    protoPoints(w.PelletsCoordEaten.GetList()),
+		PowerUpsEaten:   protoPoints(w.PowerUpsCoordsEaten.GetList()),
+		MatchStarted:    true,
+		HostId:          w.HostPlayerId,
+		PlayerCount:     int32(len(playersList)),
+		ReadyCount:      int32(len(playersList)),
+		Scores:          protoScores(w.Scores),
+		SpawnPositions:  w.Map.SpawnPixels(),
+		Map:             w.Map.ClientInfo(),
+	}
 }
 
 func (w *World) GameOver(reason string, winner string) {
-	w.gameOverChan <- GameOverInfo{Reason: reason, Winner: winner}
+	select {
+	case w.gameOverChan <- GameOverInfo{Reason: reason, Winner: winner}:
+	default:
+		// a game over is already pending, the first outcome wins
+	}
 }
 
 func (w *World) waitForGameOver() GameOverInfo {
@@ -383,20 +705,21 @@ func (w *World) ScheduleBotFill(delaySeconds int) {
 		return
 	}
 	w.botFillScheduled = true
+	fill := w.clock.After(time.Duration(delaySeconds) * time.Second)
 	w.worldLock.Unlock()
 
 	go func() {
-		time.Sleep(time.Duration(delaySeconds) * time.Second)
+		<-fill
 
 		w.worldLock.Lock()
-		defer w.worldLock.Unlock()
+		availableSlots := len(w.CharactersList)
+		w.worldLock.Unlock()
 
 		// Check if there are still empty slots and bot manager exists
 		if w.BotManager == nil {
 			return
 		}
 
-		availableSlots := len(w.CharactersList)
 		if availableSlots > 0 {
 			log.Info().Int("slots", availableSlots).Msg("Auto-filling empty slots with bots")
 			w.BotManager.FillWithBots()
@@ -414,66 +737,279 @@ func (w *World) EatPellet(pelletX, PelletY float64) {
 func (w *World) EatPowerUp(powerUpX, powerUpY float64) {
 	w.worldLock.Lock()
 	defer w.worldLock.Unlock()
-	
+	w.eatPowerUpLocked(powerUpX, powerUpY, w.clock.Now())
+}
+
+// eatPowerUpLocked starts or extends the power-up and frightens the chasers,
+// the loop ends it once PowerUpEndTime has passed (caller must hold worldLock)
+func (w *World) eatPowerUpLocked(powerUpX, powerUpY float64, now time.Time) {
+	w.frightenChasersLocked(now)
 	if w.IsPoweredUp {
 		// Extend the power-up time
-		w.PowerUpEndTime = time.Now().Add(PowerUpDuration)
-		// Restart the timer
-		if w.PowerUpTimer != nil {
-			w.PowerUpTimer.Stop()
-		}
-		w.startPowerUpTimerUnlocked()
+		w.PowerUpEndTime = now.Add(w.powerUpDurationLocked())
 		return
 	}
 
 	w.PowerUpsCoordsEaten.Add(powerUpX, powerUpY)
 	w.IsPoweredUp = true
-	w.PowerUpEndTime = time.Now().Add(PowerUpDuration)
-	
-	// Broadcast power-up start to all clients
-	if w.broadcastFunc != nil {
-		msg, _ := json.Marshal(map[string]interface{}{
-			"type":     "pow",
-			"x":        powerUpX,
-			"y":        powerUpY,
-			"duration": PowerUpDurationSec,
-		})
-		w.broadcastFunc(msg)
-	}
-	log.Info().Float64("x", powerUpX).Float64("y", powerUpY).Msg("Power-up started")
+	w.PowerUpEndTime = now.Add(w.powerUpDurationLocked())
 	
-	// Start power-up timer
-	w.startPowerUpTimerUnlocked()
+	// Power-up start goes out with the next snapshot
+	w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Pow{Pow: &gamev1.PowerUp{
+		X:        powerUpX,
+		Y:        powerUpY,
+		Duration: int32(w.powerUpDurationLocked().Seconds()),
+	}}})
+	log.Info().Float64("x", powerUpX).Float64("y", powerUpY).Msg("Power-up started")
+}
+
+// PlayerEatPowerUp applies a power-up claimed by a player according to the world's rules
+func (w *World) PlayerEatPowerUp(player *PlayerEntity, powerUpX, powerUpY float64) {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+	w.Rules.EatPowerUp(w, player, powerUpX, powerUpY, w.clock.Now())
+}
+
+// powerUpPlayerLocked starts or extends the power-up of a single player, the
+// loop ends it once its time has passed (caller must hold worldLock)
+func (w *World) powerUpPlayerLocked(player *PlayerEntity, powerUpX, powerUpY float64, now time.Time) {
+	_, extended := w.PoweredUntil[player.PlayerId]
+	w.PoweredUntil[player.PlayerId] = now.Add(PowerUpDuration)
+	w.PowerUpsCoordsEaten.Add(powerUpX, powerUpY)
+	if extended {
+		return
+	}
+
+	w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Pow{Pow: &gamev1.PowerUp{
+		PlayerId: player.PlayerId,
+		X:        powerUpX,
+		Y:        powerUpY,
+		Duration: PowerUpDurationSec,
+	}}})
+	log.Info().Str("player", player.PlayerId).Msg("Player power-up started")
 }
 
-// startPowerUpTimerUnlocked starts the power-up duration timer (must be called with lock held)
-func (w *World) startPowerUpTimerUnlocked() {
-	// Cancel existing timer if any
-	if w.PowerUpTimer != nil {
-		w.PowerUpTimer.Stop()
+// isPoweredLocked reports whether a player is powered up, by a power-up of
+// its own or the global runner power-up (caller must hold worldLock)
+func (w *World) isPoweredLocked(playerId string, now time.Time) bool {
+	if until, ok := w.PoweredUntil[playerId]; ok && now.Before(until) {
+		return true
 	}
-	
-	w.PowerUpTimer = time.AfterFunc(PowerUpDuration, func() {
-		w.worldLock.Lock()
-		w.IsPoweredUp = false
-		w.worldLock.Unlock()
-		
-		// Broadcast power-up end to all clients
-		if w.broadcastFunc != nil {
-			msg, _ := json.Marshal(map[string]interface{}{
-				"type": "powend",
-			})
-			w.broadcastFunc(msg)
+	player, ok := w.Players[playerId]
+	return ok && w.IsPoweredUp && w.Rules.IsRunner(player.SpriteType)
+}
+
+// expirePlayerPowerUpsLocked ends per-player power-ups whose time has passed
+// (caller must hold worldLock)
+func (w *World) expirePlayerPowerUpsLocked(now time.Time) {
+	ids := make([]string, 0, len(w.PoweredUntil))
+	for id := range w.PoweredUntil {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		if now.Before(w.PoweredUntil[id]) {
+			continue
+		}
+		delete(w.PoweredUntil, id)
+		w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Powend{Powend: &gamev1.PowerUpEnd{PlayerId: id}}})
+	}
+}
+
+// eliminatePlayerLocked takes a player out of the match, humans keep watching
+// as spectators (caller must hold worldLock)
+func (w *World) eliminatePlayerLocked(playerId, byPlayerId string) {
+	player, ok := w.Players[playerId]
+	if !ok {
+		return
+	}
+
+	delete(w.Players, playerId)
+	delete(w.PlayerPositions, playerId)
+	delete(w.PoweredUntil, playerId)
+	delete(w.StunnedUntil, playerId)
+	w.Eliminated = append(w.Eliminated, playerId)
+	// Danger entities eliminate too, only players score for it
+	if _, isPlayer := w.Participants[byPlayerId]; isPlayer {
+		w.Scores[byPlayerId] += EliminateScore
+		w.playerStatsLocked(byPlayerId).PlayersEliminated++
+	}
+
+	session, _ := w.ConnectedPlayers.Load(playerId)
+	w.ConnectedPlayers.Delete(playerId)
+	if !player.IsBot {
+		player.IsSpectator = true
+		w.Spectators.Store(playerId, session)
+	}
+
+	w.emit(&gamev1.Envelope{Payload: &gamev1.Envelope_Eliminated{Eliminated: &gamev1.Eliminated{
+		PlayerId: playerId,
+		By:       byPlayerId,
+		Score:    int32(w.Scores[byPlayerId]),
+	}}})
+	log.Info().Str("player", playerId).Str("by", byPlayerId).Msg("Player eliminated")
+}
+
+// playerStatsLocked returns the match counters of a player (caller must hold worldLock)
+func (w *World) playerStatsLocked(playerId string) *PlayerGameStats {
+	stats, ok := w.Stats[playerId]
+	if !ok {
+		stats = &PlayerGameStats{}
+		w.Stats[playerId] = stats
+	}
+	return stats
+}
+
+// playerIdBySpriteLocked finds the active player using a sprite (caller must hold worldLock)
+func (w *World) playerIdBySpriteLocked(sprite SpriteType) string {
+	for _, id := range w.sortedPlayerIdsLocked() {
+		if w.Players[id].SpriteType == sprite {
+			return id
 		}
-		log.Info().Msg("Power-up ended")
-	})
+	}
+	return ""
+}
+
+// PlayerResult is the outcome of a finished match for one human player
+type PlayerResult struct {
+	UserID            uint
+	Username          string
+	Score             int
+	PelletsCollected  int
+	PlayersEliminated int
+	ChasersEaten      int
+	Won               bool
+}
+
+// MatchResults returns the result of every human participant, bots are left out
+func (w *World) MatchResults(winner string) []PlayerResult {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+
+	ids := make([]string, 0, len(w.Participants))
+	for id := range w.Participants {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	results := make([]PlayerResult, 0, len(ids))
+	for _, id := range ids {
+		player := w.Participants[id]
+		if player.IsBot {
+			continue
+		}
+		userId, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			log.Warn().Str("player", id).Msg("participant without a user id")
+			continue
+		}
+
+		stats := w.playerStatsLocked(id)
+		results = append(results, PlayerResult{
+			UserID:            uint(userId),
+			Username:          player.Username,
+			Score:             w.Scores[id],
+			PelletsCollected:  stats.PelletsEaten,
+			PlayersEliminated: stats.PlayersEliminated,
+			ChasersEaten:      stats.ChasersEaten,
+			Won:               w.Rules.Won(player, winner),
+		})
+	}
+	return results
+}
+
+// ParticipantNames returns the username of every player that took part in
+// the match by player id
+func (w *World) ParticipantNames() map[string]string {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+	names := make(map[string]string, len(w.Participants))
+	for id, player := range w.Participants {
+		names[id] = player.Username
+	}
+	return names
+}
+
+// MatchDuration returns how long the match has been running
+func (w *World) MatchDuration(now time.Time) time.Duration {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+	if w.MatchStartedAt.IsZero() {
+		return 0
+	}
+	return now.Sub(w.MatchStartedAt)
+}
+
+// SetBotDifficulty sets the difficulty of the bots, it can only change
+// before the match starts
+func (w *World) SetBotDifficulty(difficulty DifficultyLevel) error {
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+	if w.MatchStarted {
+		return fmt.Errorf("de game is al gestart")
+	}
+
+	w.BotDifficulty = difficulty
+	if w.BotManager != nil {
+		w.BotManager.setDifficulty(difficulty)
+	}
+	return nil
+}
+
+// chaserBehaviorLocked tells a chaser bot what to do: flee while it is
+// frightened, otherwise scatter and chase in turns from the match start. The
+// more aggressive a bot, the sooner it cuts the scatter phase short to chase
+// again (caller must hold worldLock)
+func (w *World) chaserBehaviorLocked(sprite SpriteType, now time.Time, aggression float64) ChaserBehavior {
+	if w.isFrightenedLocked(sprite) {
+		return BehaviorFrightened
+	}
+	if w.MatchStartedAt.IsZero() {
+		return BehaviorChase
+	}
+
+	cycle := (BotScatterSec + BotChaseSec) * time.Second
+	scatter := time.Duration(float64(BotScatterSec*time.Second) * (1 - aggression))
+	if now.Sub(w.MatchStartedAt)%cycle < scatter {
+		return BehaviorScatter
+	}
+	return BehaviorChase
+}
+
+// removeBotLocked drops a bot from the world (caller must hold worldLock)
+func (w *World) removeBotLocked(playerId string) {
+	delete(w.Players, playerId)
+	delete(w.PlayerPositions, playerId)
+	w.ConnectedPlayers.Delete(playerId)
 }
 
 func (w *World) ChaserEatenAction(chaserID SpriteType) {
 	w.worldLock.Lock()
 	defer w.worldLock.Unlock()
 
-	w.ChasersIdsEaten = append(w.ChasersIdsEaten, chaserID)
+	w.eatChaserLocked(chaserID)
+}
+
+// Admit makes room for a connecting user: spectators always get in, a player
+// only before the match and then takes the place of a bot if the lobby has no
+// free sprite
+func (w *World) Admit(spectate bool) error {
+	if spectate {
+		return nil
+	}
+	if w.MatchStarted {
+		return fmt.Errorf("de wedstrijd is al begonnen, kijk mee als toeschouwer")
+	}
+
+	if w.BotManager != nil && w.BotManager.GetBotCount() > 0 {
+		w.BotManager.RemoveOneBot()
+	}
+	if w.IsLobbyFull() {
+		log.Warn().Uint("lobby", w.LobbyID).Msg("lobby is full, more players are not allowed")
+		return fmt.Errorf("lobby is vol")
+	}
+	return nil
 }
 
 // JoinAsSpectator adds a player as spectator (no sprite assigned)
@@ -483,6 +1019,76 @@ func (w *World) JoinAsSpectator(player *PlayerEntity, session *melody.Session) {
 	w.Spectators.Store(player.PlayerId, session)
 }
 
+// GetSpectatorCount returns the number of connected spectators, eliminated
+// players included
+func (w *World) GetSpectatorCount() int {
+	count := 0
+	for _, session := range w.Spectators.GetValues() {
+		if session != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// LobbyStatus is the lobbystatus message with the players, their ready state
+// and the spectators
+func (w *World) LobbyStatus() *gamev1.Envelope {
+	players := []*gamev1.LobbyPlayer{}
+	for _, session := range w.ConnectedPlayers.GetValues() {
+		if session == nil {
+			continue
+		}
+		player, err := getPlayerEntityFromSession(session)
+		if err != nil {
+			continue
+		}
+		players = append(players, player.lobbyPlayer())
+	}
+
+	spectators := []*gamev1.LobbyPlayer{}
+	for _, session := range w.Spectators.GetValues() {
+		if session == nil {
+			continue
+		}
+		spectator, err := getPlayerEntityFromSession(session)
+		if err != nil {
+			continue
+		}
+		spectators = append(spectators, &gamev1.LobbyPlayer{
+			PlayerId: spectator.PlayerId,
+			Username: spectator.Username,
+		})
+	}
+
+	return &gamev1.Envelope{Payload: &gamev1.Envelope_Lobbystatus{Lobbystatus: &gamev1.LobbyStatus{
+		Players:        players,
+		Spectators:     spectators,
+		SpectatorCount: int32(len(spectators)),
+		PlayerCount:    int32(w.GetPlayerCount()),
+		ReadyCount:     int32(w.GetReadyCount()),
+		MatchStarted:   w.MatchStarted,
+		HostId:         w.HostPlayerId,
+		BotDifficulty:  w.BotDifficulty.String(),
+		RoundDuration:  int32(w.RoundDuration.Seconds()),
+		SuddenDeath:    w.SuddenDeathEnabled,
+		Lives:          int32(w.Lives),
+	}}}
+}
+
+// sessions returns every connected session of the world, spectators included
+func (w *World) sessions() []*melody.Session {
+	all := append(w.ConnectedPlayers.GetValues(), w.Spectators.GetValues()...)
+	// Filter out nil sessions (bots don't have real sessions)
+	sessions := make([]*melody.Session, 0, len(all))
+	for _, s := range all {
+		if s != nil {
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions
+}
+
 // AreAllPlayersReady checks if all connected players are ready
 func (w *World) AreAllPlayersReady() bool {
 	if len(w.ConnectedPlayers.GetKeys()) == 0 {
@@ -533,52 +1139,44 @@ func (w *World) GetReadyCount() int {
 	return count
 }
 
-// StartDynamicSystems initializes and starts the zone and entity systems
-func (w *World) StartDynamicSystems(broadcastFunc func(msgType string, data interface{})) {
+// StartDynamicSystems initializes the zone and entity systems, the world
+// loop advances them from then on
+func (w *World) StartDynamicSystems() {
 	w.worldLock.Lock()
 	defer w.worldLock.Unlock()
 	
-	// Set broadcast functions
-	w.DynamicWorld.SetBroadcastFunc(broadcastFunc)
-	w.EntityManager.SetBroadcastFunc(broadcastFunc)
+	// Dynamic events are collected into the tick snapshot
+	w.DynamicWorld.SetBroadcastFunc(w.emit)
+	w.DynamicWorld.SetMazeUpdateFunc(w.applyMazeUpdateLocked)
+	w.EntityManager.SetBroadcastFunc(w.emit)
 	
 	// Set player position getter
-	w.EntityManager.SetGetPlayersFunc(w.getPlayerPositions)
+	w.EntityManager.SetGetPlayersFunc(w.getPlayerPositionsLocked)
 	
 	// Spawn initial entities
 	w.EntityManager.SpawnInitialEntities()
 	
-	// Start both systems
-	w.DynamicWorld.Start()
-	w.EntityManager.Start()
+	w.dynamicActive = true
 }
 
-// StopDynamicSystems stops the zone and entity update loops
+// StopDynamicSystems stops advancing zones and entities
 func (w *World) StopDynamicSystems() {
-	if w.DynamicWorld != nil {
-		w.DynamicWorld.Stop()
-	}
-	if w.EntityManager != nil {
-		w.EntityManager.Stop()
-	}
+	w.worldLock.Lock()
+	defer w.worldLock.Unlock()
+	w.dynamicActive = false
 }
 
-// getPlayerPositions returns current player positions for entity AI
-func (w *World) getPlayerPositions() []PlayerPosition {
-	positions := make([]PlayerPosition, 0)
+// getPlayerPositionsLocked returns current player positions for entity AI
+// (called from the loop with worldLock held)
+func (w *World) getPlayerPositionsLocked() []PlayerPosition {
+	positions := make([]PlayerPosition, 0, len(w.Players))
 	
-	for _, session := range w.ConnectedPlayers.GetValues() {
-		if session == nil {
-			continue
-		}
-		player, err := getPlayerEntityFromSession(session)
-		if err != nil {
-			continue
-		}
+	for _, id := range w.sortedPlayerIdsLocked() {
+		player := w.Players[id]
 		positions = append(positions, PlayerPosition{
 			ID: player.PlayerId,
-			X:  player.X,
-			Y:  player.Y,
+			X:  player.X / TileSizeFloat,
+			Y:  player.Y / TileSizeFloat,
 		})
 	}
 	
@@ -586,23 +1184,20 @@ func (w *World) getPlayerPositions() []PlayerPosition {
 }
 
 // GetDynamicState returns the current state of zones and entities for new players
-func (w *World) GetDynamicState() map[string]interface{} {
-	zonesJSON, _ := w.DynamicWorld.GetZonesJSON()
-	var zonesData map[string]interface{}
-	json.Unmarshal(zonesJSON, &zonesData)
-	
-	return map[string]interface{}{
-		"zones":    zonesData,
-		"entities": w.EntityManager.GetEntitiesJSON(),
+func (w *World) GetDynamicState() *gamev1.DynamicState {
+	return &gamev1.DynamicState{
+		Zones:       w.DynamicWorld.ZonesState(),
+		Entities:    w.EntityManager.EntitiesState(),
+		MazeUpdates: protoMazeUpdates(w.DynamicWorld.ActiveMazeUpdates()),
 	}
 }
 
-// CheckEntityCollision checks if a player has collided with an entity
-func (w *World) CheckEntityCollision(playerX, playerY float64) *DangerEntity {
-	return w.EntityManager.CheckPlayerCollision(playerX, playerY)
+// CheckEntityCollision returns the entity touching a player position in pixels
+func (w *World) CheckEntityCollision(pixelX, pixelY float64) *DangerEntity {
+	return w.EntityManager.CheckPlayerCollision(pixelX/TileSizeFloat, pixelY/TileSizeFloat)
 }
 
-// GetCurrentZone returns the zone at the player's position
-func (w *World) GetCurrentZone(x, y int) *Zone {
-	return w.DynamicWorld.GetZoneAt(x, y)
+// GetCurrentZone returns the zone at a player position in pixels
+func (w *World) GetCurrentZone(pixelX, pixelY float64) *Zone {
+	return w.DynamicWorld.GetZoneAt(PixelToTile(pixelX, pixelY))
 }
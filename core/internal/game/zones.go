@@ -1,10 +1,12 @@
 package game
 
 import (
-	"encoding/json"
 	"math/rand"
 	"sync"
 	"time"
+
+	gamev1 "github.com/frank2889/mazechase/generated/game/v1"
+	"google.golang.org/protobuf/proto"
 )
 
 // ZoneType represents the danger level of a zone
@@ -45,6 +47,8 @@ type MazeUpdate struct {
 	TargetX   int    `json:"targetX,omitempty"`
 	TargetY   int    `json:"targetY,omitempty"`
 	Duration  int    `json:"duration"` // Animation duration in ms
+	RevertIn  int    `json:"revertIn,omitempty"` // Ms until the update is undone, 0 for a revert
+	revertAt  time.Time
 }
 
 // DynamicWorld handles zone management and maze updates
@@ -54,12 +58,12 @@ type DynamicWorld struct {
 	CurrentPhase    TimePhase       `json:"currentPhase"`
 	PhaseProgress   float64         `json:"phaseProgress"` // 0-1 progress through current phase
 	PhaseDuration   time.Duration   // How long each phase lasts
-	MazeUpdates     []MazeUpdate    `json:"pendingUpdates"`
+	MazeUpdates     []MazeUpdate    `json:"pendingUpdates"` // Applied updates waiting to be reverted
 	MazeWidth       int
 	MazeHeight      int
-	ticker          *time.Ticker
-	stopChan        chan struct{}
-	broadcastFunc   func(msgType string, data interface{})
+	broadcastFunc   func(*gamev1.Envelope)
+	applyFunc       func(update MazeUpdate) bool
+	rng             *rand.Rand
 }
 
 // NewDynamicWorld creates a new dynamic world system
@@ -72,7 +76,7 @@ func NewDynamicWorld(mazeWidth, mazeHeight int) *DynamicWorld {
 		MazeUpdates:   make([]MazeUpdate, 0),
 		MazeWidth:     mazeWidth,
 		MazeHeight:    mazeHeight,
-		stopChan:      make(chan struct{}),
+		rng:           newUnseededRand(),
 	}
 	
 	// Generate initial zones
@@ -81,13 +85,38 @@ func NewDynamicWorld(mazeWidth, mazeHeight int) *DynamicWorld {
 	return dw
 }
 
+// NewDynamicWorldForMap creates the dynamic world of a map, zones follow the
+// map's zone hints when it has any
+func NewDynamicWorldForMap(mazeMap *MazeMap) *DynamicWorld {
+	dw := NewDynamicWorld(mazeMap.Width, mazeMap.Height)
+	if len(mazeMap.Zones) > 0 {
+		dw.Zones = mazeMap.zones()
+	}
+	return dw
+}
+
 // SetBroadcastFunc sets the function to broadcast updates to clients
-func (dw *DynamicWorld) SetBroadcastFunc(fn func(msgType string, data interface{})) {
+func (dw *DynamicWorld) SetBroadcastFunc(fn func(*gamev1.Envelope)) {
 	dw.mu.Lock()
 	defer dw.mu.Unlock()
 	dw.broadcastFunc = fn
 }
 
+// SetMazeUpdateFunc sets the function that applies a maze update to the
+// server's collision, it returns false when the update is not safe
+func (dw *DynamicWorld) SetMazeUpdateFunc(fn func(update MazeUpdate) bool) {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+	dw.applyFunc = fn
+}
+
+// SetRand makes phase changes and maze updates draw from a world's generator
+func (dw *DynamicWorld) SetRand(rng *rand.Rand) {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+	dw.rng = rng
+}
+
 // generateZones creates the initial zone layout
 func (dw *DynamicWorld) generateZones() {
 	dw.mu.Lock()
@@ -131,33 +160,13 @@ func (dw *DynamicWorld) generateZones() {
 	}
 }
 
-// Start begins the dynamic world update loop
-func (dw *DynamicWorld) Start() {
-	dw.ticker = time.NewTicker(1 * time.Second)
-	
-	go func() {
-		for {
-			select {
-			case <-dw.ticker.C:
-				dw.tick()
-			case <-dw.stopChan:
-				dw.ticker.Stop()
-				return
-			}
-		}
-	}()
-}
-
-// Stop halts the dynamic world updates
-func (dw *DynamicWorld) Stop() {
-	close(dw.stopChan)
-}
-
-// tick updates the world state each second
-func (dw *DynamicWorld) tick() {
+// tick updates the world state each second, driven by the world loop every PhaseTickMs
+func (dw *DynamicWorld) tick(now time.Time) {
 	dw.mu.Lock()
 	defer dw.mu.Unlock()
 	
+	dw.revertMazeUpdates(now)
+	
 	// Update phase progress
 	dw.PhaseProgress += 1.0 / dw.PhaseDuration.Seconds()
 	
@@ -167,17 +176,17 @@ func (dw *DynamicWorld) tick() {
 	
 	// Random chance to modify maze during danger phases
 	if dw.CurrentPhase == PhaseNight || dw.CurrentPhase == PhaseDusk {
-		if rand.Float64() < 0.1 { // 10% chance per second
-			dw.generateMazeUpdate()
+		if dw.rng.Float64() < 0.1 { // 10% chance per second
+			dw.generateMazeUpdate(now)
 		}
 	}
 	
 	// Broadcast phase update periodically
 	if dw.broadcastFunc != nil {
-		dw.broadcastFunc("phase_update", map[string]interface{}{
-			"phase":    dw.CurrentPhase,
-			"progress": dw.PhaseProgress,
-		})
+		dw.broadcastFunc(&gamev1.Envelope{Payload: &gamev1.Envelope_PhaseUpdate{PhaseUpdate: &gamev1.PhaseUpdate{
+			Phase:    string(dw.CurrentPhase),
+			Progress: dw.PhaseProgress,
+		}}})
 	}
 }
 
@@ -200,10 +209,10 @@ func (dw *DynamicWorld) advancePhase() {
 	
 	// Broadcast phase change
 	if dw.broadcastFunc != nil {
-		dw.broadcastFunc("phase_change", map[string]interface{}{
-			"newPhase": dw.CurrentPhase,
-			"zones":    dw.Zones,
-		})
+		dw.broadcastFunc(&gamev1.Envelope{Payload: &gamev1.Envelope_PhaseChange{PhaseChange: &gamev1.PhaseChange{
+			NewPhase: string(dw.CurrentPhase),
+			Zones:    protoZones(dw.Zones),
+		}}})
 	}
 }
 
@@ -236,7 +245,7 @@ func (dw *DynamicWorld) regenerateNeutralZones() {
 	neutralCount := 0
 	for i := range dw.Zones {
 		if dw.Zones[i].Type == ZoneDanger && neutralCount < 2 {
-			if rand.Float64() < 0.5 {
+			if dw.rng.Float64() < 0.5 {
 				dw.Zones[i].Type = ZoneNeutral
 				neutralCount++
 			}
@@ -244,34 +253,90 @@ func (dw *DynamicWorld) regenerateNeutralZones() {
 	}
 }
 
-// generateMazeUpdate creates a random maze modification
-func (dw *DynamicWorld) generateMazeUpdate() {
-	updateTypes := []string{"wall_add", "wall_remove"}
-	updateType := updateTypes[rand.Intn(len(updateTypes))]
+// generateMazeUpdate creates a random maze modification, the update only
+// goes out when the world could apply it safely
+func (dw *DynamicWorld) generateMazeUpdate(now time.Time) {
+	if dw.applyFunc == nil {
+		return
+	}
 	
-	// Random position (avoiding edges and spawn areas)
-	x := rand.Intn(dw.MazeWidth-4) + 2
-	y := rand.Intn(dw.MazeHeight-4) + 2
+	updateTypes := []string{"wall_add", "wall_remove"}
+	updateType := updateTypes[dw.rng.Intn(len(updateTypes))]
 	
-	// Don't modify center safe zone
-	centerX, centerY := dw.MazeWidth/2, dw.MazeHeight/2
-	if abs(x-centerX) < 3 && abs(y-centerY) < 3 {
+	for attempt := 0; attempt < MazeUpdateAttempts; attempt++ {
+		// Random position (avoiding edges and spawn areas)
+		x := dw.rng.Intn(dw.MazeWidth-4) + 2
+		y := dw.rng.Intn(dw.MazeHeight-4) + 2
+		
+		// Don't modify center safe zone
+		centerX, centerY := dw.MazeWidth/2, dw.MazeHeight/2
+		if abs(x-centerX) < 3 && abs(y-centerY) < 3 {
+			continue
+		}
+		
+		update := MazeUpdate{
+			Type:     updateType,
+			X:        x,
+			Y:        y,
+			Duration: MazeUpdateAnimationMs,
+			RevertIn: MazeUpdateLifetimeSec * 1000,
+			revertAt: now.Add(MazeUpdateLifetime),
+		}
+		if !dw.applyFunc(update) {
+			continue
+		}
+		
+		dw.MazeUpdates = append(dw.MazeUpdates, update)
+		
+		// Broadcast maze update
+		if dw.broadcastFunc != nil {
+			dw.broadcastFunc(&gamev1.Envelope{Payload: &gamev1.Envelope_MazeUpdate{MazeUpdate: update.toProto()}})
+		}
 		return
 	}
-	
-	update := MazeUpdate{
-		Type:     updateType,
-		X:        x,
-		Y:        y,
-		Duration: 500, // 500ms animation
+}
+
+// revertMazeUpdates undoes the maze updates whose lifetime is over, an undo
+// that is not safe yet (a player on the tile) is tried again next tick
+func (dw *DynamicWorld) revertMazeUpdates(now time.Time) {
+	remaining := dw.MazeUpdates[:0]
+	for _, update := range dw.MazeUpdates {
+		if now.Before(update.revertAt) || dw.applyFunc == nil {
+			remaining = append(remaining, update)
+			continue
+		}
+		
+		revert := MazeUpdate{
+			Type:     reverseMazeUpdateType(update.Type),
+			X:        update.X,
+			Y:        update.Y,
+			Duration: update.Duration,
+		}
+		if !dw.applyFunc(revert) {
+			remaining = append(remaining, update)
+			continue
+		}
+		
+		if dw.broadcastFunc != nil {
+			dw.broadcastFunc(&gamev1.Envelope{Payload: &gamev1.Envelope_MazeUpdate{MazeUpdate: revert.toProto()}})
+		}
 	}
-	
-	dw.MazeUpdates = append(dw.MazeUpdates, update)
-	
-	// Broadcast maze update
-	if dw.broadcastFunc != nil {
-		dw.broadcastFunc("maze_update", update)
+	dw.MazeUpdates = remaining
+}
+
+func reverseMazeUpdateType(updateType string) string {
+	if updateType == "wall_add" {
+		return "wall_remove"
 	}
+	return "wall_add"
+}
+
+// ActiveMazeUpdates returns the applied maze updates, for clients joining late
+func (dw *DynamicWorld) ActiveMazeUpdates() []MazeUpdate {
+	dw.mu.RLock()
+	defer dw.mu.RUnlock()
+	
+	return append([]MazeUpdate{}, dw.MazeUpdates...)
 }
 
 // GetCurrentZone returns the zone containing the given coordinates
@@ -288,16 +353,63 @@ func (dw *DynamicWorld) GetZoneAt(x, y int) *Zone {
 	return nil
 }
 
-// GetZonesJSON returns zones as JSON for client
-func (dw *DynamicWorld) GetZonesJSON() ([]byte, error) {
+// ZonesState returns the zones and the time phase for clients
+func (dw *DynamicWorld) ZonesState() *gamev1.ZonesState {
 	dw.mu.RLock()
 	defer dw.mu.RUnlock()
 	
-	return json.Marshal(map[string]interface{}{
-		"zones":    dw.Zones,
-		"phase":    dw.CurrentPhase,
-		"progress": dw.PhaseProgress,
-	})
+	return &gamev1.ZonesState{
+		Zones:    protoZones(dw.Zones),
+		Phase:    string(dw.CurrentPhase),
+		Progress: dw.PhaseProgress,
+	}
+}
+
+func (z *Zone) toProto() *gamev1.Zone {
+	return &gamev1.Zone{
+		Id:       int32(z.ID),
+		Type:     string(z.Type),
+		X:        int32(z.X),
+		Y:        int32(z.Y),
+		Width:    int32(z.Width),
+		Height:   int32(z.Height),
+		IsActive: z.IsActive,
+	}
+}
+
+func protoZones(zones []Zone) []*gamev1.Zone {
+	result := make([]*gamev1.Zone, 0, len(zones))
+	for i := range zones {
+		result = append(result, zones[i].toProto())
+	}
+	return result
+}
+
+func (u MazeUpdate) toProto() *gamev1.MazeUpdate {
+	update := &gamev1.MazeUpdate{
+		Type:     u.Type,
+		X:        int32(u.X),
+		Y:        int32(u.Y),
+		Duration: int32(u.Duration),
+	}
+	if u.TargetX != 0 {
+		update.TargetX = proto.Int32(int32(u.TargetX))
+	}
+	if u.TargetY != 0 {
+		update.TargetY = proto.Int32(int32(u.TargetY))
+	}
+	if u.RevertIn > 0 {
+		update.RevertIn = proto.Int32(int32(u.RevertIn))
+	}
+	return update
+}
+
+func protoMazeUpdates(updates []MazeUpdate) []*gamev1.MazeUpdate {
+	result := make([]*gamev1.MazeUpdate, 0, len(updates))
+	for _, update := range updates {
+		result = append(result, update.toProto())
+	}
+	return result
 }
 
 // Note: abs function is defined in pathfinding.go
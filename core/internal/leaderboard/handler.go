@@ -0,0 +1,88 @@
+package leaderboard
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	v1 "github.com/frank2889/mazechase/generated/leaderboard/v1"
+	"github.com/frank2889/mazechase/internal/user"
+)
+
+type Handler struct {
+	lbService *Service
+}
+
+func NewLeaderboardHandler(ls *Service) *Handler {
+	return &Handler{ls}
+}
+
+func (l Handler) GetTopPlayers(_ context.Context, req *connect.Request[v1.TopPlayersRequest]) (*connect.Response[v1.TopPlayersResponse], error) {
+	filter := Filter{GameMode: req.Msg.GetGameMode(), TimeWindow: req.Msg.GetTimeWindow()}
+	entries, err := l.lbService.TopPlayers(filter, int(req.Msg.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+
+	rpcEntries := make([]*v1.LeaderboardEntry, 0, len(entries))
+	for _, entry := range entries {
+		rpcEntries = append(rpcEntries, entryToRPC(entry))
+	}
+
+	return connect.NewResponse(&v1.TopPlayersResponse{Entries: rpcEntries}), nil
+}
+
+func (l Handler) GetPlayerStats(ctx context.Context, req *connect.Request[v1.PlayerStatsRequest]) (*connect.Response[v1.PlayerStatsResponse], error) {
+	userId := uint(req.Msg.GetUserId())
+	if userId == 0 {
+		userInfo, err := user.UserDataFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		userId = userInfo.ID
+	}
+
+	filter := Filter{GameMode: req.Msg.GetGameMode(), TimeWindow: req.Msg.GetTimeWindow()}
+	stats, err := l.lbService.PlayerStats(userId, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&v1.PlayerStatsResponse{Stats: entryToRPC(*stats)}), nil
+}
+
+func (l Handler) GetMyRank(ctx context.Context, req *connect.Request[v1.MyRankRequest]) (*connect.Response[v1.MyRankResponse], error) {
+	userInfo, err := user.UserDataFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := Filter{GameMode: req.Msg.GetGameMode(), TimeWindow: req.Msg.GetTimeWindow()}
+	entry, total, err := l.lbService.PlayerRank(userInfo.ID, filter)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Username == "" {
+		entry.Username = userInfo.Username
+	}
+
+	return connect.NewResponse(&v1.MyRankResponse{
+		Rank:         uint64(entry.Rank),
+		TotalPlayers: uint64(total),
+		Entry:        entryToRPC(*entry),
+	}), nil
+}
+
+func entryToRPC(entry user.LeaderboardEntry) *v1.LeaderboardEntry {
+	return &v1.LeaderboardEntry{
+		Rank:         uint64(entry.Rank),
+		UserId:       uint64(entry.UserID),
+		Username:     entry.Username,
+		GamesPlayed:  uint64(entry.Games),
+		Wins:         uint64(entry.Wins),
+		Losses:       uint64(entry.Games - entry.Wins),
+		HighScore:    int64(entry.HighScore),
+		TotalScore:   int64(entry.Score),
+		PelletsEaten: uint64(entry.PelletsEaten),
+		ChasersEaten: uint64(entry.ChasersEaten),
+	}
+}
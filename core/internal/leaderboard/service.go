@@ -0,0 +1,159 @@
+package leaderboard
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/frank2889/mazechase/internal/user"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultLimit = 10
+	maxLimit     = 100
+)
+
+// timeWindows maps the supported time filters to how far back they reach
+var timeWindows = map[string]time.Duration{
+	"day":   24 * time.Hour,
+	"week":  7 * 24 * time.Hour,
+	"month": 30 * 24 * time.Hour,
+}
+
+// Filter selects the scores a leaderboard is computed over
+type Filter struct {
+	GameMode   string
+	TimeWindow string
+}
+
+type Service struct {
+	Db *gorm.DB
+}
+
+func NewLeaderboardService(db *gorm.DB) *Service {
+	return &Service{Db: db}
+}
+
+// TopPlayers returns the best players ranked by wins, then high score
+func (lbService *Service) TopPlayers(filter Filter, limit int) ([]user.LeaderboardEntry, error) {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	totals, err := lbService.totals(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []user.LeaderboardEntry
+	res := lbService.Db.
+		Table("(?) AS totals", totals).
+		Order("wins DESC, high_score DESC, user_id ASC").
+		Limit(limit).
+		Scan(&entries)
+	if res.Error != nil {
+		log.Error().Err(res.Error).Msg("unable to query leaderboard")
+		return nil, fmt.Errorf("leaderboard ophalen mislukt")
+	}
+
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+	return entries, nil
+}
+
+// PlayerStats returns the totals of a single user, a user without games gets
+// an empty entry
+func (lbService *Service) PlayerStats(userId uint, filter Filter) (*user.LeaderboardEntry, error) {
+	totals, err := lbService.totals(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []user.LeaderboardEntry
+	res := lbService.Db.
+		Table("(?) AS totals", totals).
+		Where("user_id = ?", userId).
+		Scan(&entries)
+	if res.Error != nil {
+		log.Error().Err(res.Error).Uint("user", userId).Msg("unable to query player stats")
+		return nil, fmt.Errorf("statistieken ophalen mislukt")
+	}
+
+	if len(entries) == 0 {
+		return &user.LeaderboardEntry{UserID: userId}, nil
+	}
+	return &entries[0], nil
+}
+
+// PlayerRank returns the stats of a user with their rank filled in, and the
+// number of ranked players. Rank is 0 when the user has no games.
+func (lbService *Service) PlayerRank(userId uint, filter Filter) (*user.LeaderboardEntry, int, error) {
+	entry, err := lbService.PlayerStats(userId, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totals, err := lbService.totals(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	res := lbService.Db.Table("(?) AS totals", totals).Count(&total)
+	if res.Error != nil {
+		log.Error().Err(res.Error).Msg("unable to count ranked players")
+		return nil, 0, fmt.Errorf("rang ophalen mislukt")
+	}
+
+	if entry.Games == 0 {
+		return entry, int(total), nil
+	}
+
+	var above int64
+	res = lbService.Db.
+		Table("(?) AS totals", totals).
+		Where("wins > ? OR (wins = ? AND high_score > ?) OR (wins = ? AND high_score = ? AND user_id < ?)",
+			entry.Wins, entry.Wins, entry.HighScore, entry.Wins, entry.HighScore, userId).
+		Count(&above)
+	if res.Error != nil {
+		log.Error().Err(res.Error).Msg("unable to count players ranked above")
+		return nil, 0, fmt.Errorf("rang ophalen mislukt")
+	}
+
+	entry.Rank = int(above) + 1
+	return entry, int(total), nil
+}
+
+// totals builds the per-user aggregate over the Score table for a filter
+func (lbService *Service) totals(filter Filter) (*gorm.DB, error) {
+	query := lbService.Db.
+		Model(&user.Score{}).
+		Select(`user_id,
+			MAX(username) AS username,
+			COUNT(*) AS games,
+			SUM(CASE WHEN won THEN 1 ELSE 0 END) AS wins,
+			SUM(score) AS score,
+			MAX(score) AS high_score,
+			SUM(pellets_collected) AS pellets_eaten,
+			SUM(chasers_eaten) AS chasers_eaten`).
+		Group("user_id")
+
+	if filter.GameMode != "" {
+		query = query.Where("game_mode = ?", filter.GameMode)
+	}
+
+	if filter.TimeWindow != "" {
+		window, ok := timeWindows[filter.TimeWindow]
+		if !ok {
+			return nil, fmt.Errorf("onbekende periode: %s", filter.TimeWindow)
+		}
+		query = query.Where("created_at >= ?", time.Now().Add(-window))
+	}
+
+	return query, nil
+}
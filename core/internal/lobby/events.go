@@ -0,0 +1,73 @@
+package lobby
+
+import (
+	"sync"
+
+	v1 "github.com/frank2889/mazechase/generated/lobby/v1"
+	"github.com/rs/zerolog/log"
+)
+
+// watcherBuffer is how many events a watcher may lag behind before it is dropped
+const watcherBuffer = 64
+
+// watchers fans lobby events out to every WatchLobbies stream
+type watchers struct {
+	mu   sync.Mutex
+	subs map[chan *v1.LobbyEvent]struct{}
+}
+
+// Subscribe registers a watcher, the channel is closed on unsubscribe or when
+// the watcher falls too far behind (it should then watch again for a fresh list)
+func (lobbyService *Service) Subscribe() (<-chan *v1.LobbyEvent, func()) {
+	events := make(chan *v1.LobbyEvent, watcherBuffer)
+
+	lobbyService.watchers.mu.Lock()
+	lobbyService.watchers.subs[events] = struct{}{}
+	lobbyService.watchers.mu.Unlock()
+
+	unsubscribe := func() {
+		lobbyService.watchers.mu.Lock()
+		defer lobbyService.watchers.mu.Unlock()
+		if _, ok := lobbyService.watchers.subs[events]; ok {
+			delete(lobbyService.watchers.subs, events)
+			close(events)
+		}
+	}
+	return events, unsubscribe
+}
+
+func (lobbyService *Service) publish(eventType v1.LobbyEventType, lobby *v1.Lobby) {
+	event := &v1.LobbyEvent{Type: eventType, Lobby: lobby}
+
+	lobbyService.watchers.mu.Lock()
+	defer lobbyService.watchers.mu.Unlock()
+	for sub := range lobbyService.watchers.subs {
+		select {
+		case sub <- event:
+		default:
+			log.Warn().Msg("lobby watcher too slow, dropping it")
+			delete(lobbyService.watchers.subs, sub)
+			close(sub)
+		}
+	}
+}
+
+// SetLobbyMatchStarted records whether a match runs in a lobby, fed by the game manager
+func (lobbyService *Service) SetLobbyMatchStarted(lobbyId uint, started bool) {
+	previous, _ := lobbyService.MatchStarted.Swap(lobbyId, started)
+	if previous == started || (previous == nil && !started) {
+		return
+	}
+
+	eventType := v1.LobbyEventType_LOBBY_EVENT_TYPE_MATCH_STARTED
+	if !started {
+		eventType = v1.LobbyEventType_LOBBY_EVENT_TYPE_MATCH_ENDED
+	}
+	lobbyService.publish(eventType, &v1.Lobby{ID: uint64(lobbyId), MatchStarted: started})
+}
+
+// IsLobbyMatchStarted reports whether a match runs in a lobby
+func (lobbyService *Service) IsLobbyMatchStarted(lobbyId uint) bool {
+	val, exists := lobbyService.MatchStarted.Load(lobbyId)
+	return exists && val.(bool)
+}
@@ -26,6 +26,37 @@ func (l Handler) ListLobbies(context.Context, *connect.Request[v1.ListLobbiesReq
 	return connect.NewResponse(&v1.ListLobbiesResponse{Lobbies: lobbies}), nil
 }
 
+func (l Handler) WatchLobbies(ctx context.Context, _ *connect.Request[v1.WatchLobbiesRequest], stream *connect.ServerStream[v1.LobbyEvent]) error {
+	// subscribe before listing so no change is lost in between
+	events, unsubscribe := l.lobbyService.Subscribe()
+	defer unsubscribe()
+
+	lobbies, err := l.lobbyService.GetGrpcLobbies()
+	if err != nil {
+		return err
+	}
+	for _, lobby := range lobbies {
+		err = stream.Send(&v1.LobbyEvent{Type: v1.LobbyEventType_LOBBY_EVENT_TYPE_CREATED, Lobby: lobby})
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("lobby updates liepen achter, verbind opnieuw"))
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 func (l Handler) AddLobby(ctx context.Context, req *connect.Request[v1.AddLobbiesRequest]) (*connect.Response[v1.AddLobbiesResponse], error) {
 	userInfo, err := user.UserDataFromContext(ctx)
 	if err != nil {
@@ -34,7 +65,7 @@ func (l Handler) AddLobby(ctx context.Context, req *connect.Request[v1.AddLobbie
 
 	// Allow all users (including guests) to create lobbies
 	lobbyName := req.Msg.GetLobbyName()
-	lobbyId, err := l.lobbyService.CreateLobby(lobbyName, userInfo.Username, userInfo.ID)
+	lobbyId, err := l.lobbyService.CreateLobby(lobbyName, req.Msg.GetGameMode(), req.Msg.GetMapName(), req.Msg.GetMapSeed(), userInfo.Username, userInfo.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -42,6 +73,10 @@ func (l Handler) AddLobby(ctx context.Context, req *connect.Request[v1.AddLobbie
 	return connect.NewResponse(&v1.AddLobbiesResponse{LobbyId: uint64(lobbyId)}), nil
 }
 
+func (l Handler) ListMaps(context.Context, *connect.Request[v1.ListMapsRequest]) (*connect.Response[v1.ListMapsResponse], error) {
+	return connect.NewResponse(&v1.ListMapsResponse{MapNames: l.lobbyService.MapNames()}), nil
+}
+
 func (l Handler) DeleteLobby(ctx context.Context, req *connect.Request[v1.DelLobbiesRequest]) (*connect.Response[v1.DelLobbiesResponse], error) {
 	lobbyInfo := req.Msg.GetLobby()
 	if lobbyInfo == nil {
@@ -13,6 +13,9 @@ type Lobby struct {
 	UserID    int64
 	Joined    int
 	Username  string
+	GameMode  string
+	MapName   string
+	MapSeed   int64
 }
 
 func (l Lobby) FromRPC(lobby *v1.Lobby) *Lobby {
@@ -31,5 +34,8 @@ func (l Lobby) ToRPC() *v1.Lobby {
 		OwnerName: l.Username,
 		OwnerId:   uint64(l.UserID),
 		CreatedAt: l.CreatedAt.Format(time.RFC3339),
+		GameMode:  l.GameMode,
+		MapName:   l.MapName,
+		MapSeed:   l.MapSeed,
 	}
 }
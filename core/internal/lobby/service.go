@@ -2,6 +2,7 @@ package lobby
 
 import (
 	"fmt"
+	"math/rand"
 	"strconv"
 	"sync"
 
@@ -12,16 +13,21 @@ import (
 )
 
 type Service struct {
-	Db          *gorm.DB
-	Mu          *sync.RWMutex
-	PlayerCount sync.Map
+	Db           *gorm.DB
+	Mu           *sync.RWMutex
+	PlayerCount  sync.Map
+	MatchStarted sync.Map
+	watchers     watchers
+	mapNames     []string
 }
 
 func NewLobbyService(db *gorm.DB) *Service {
 	return &Service{
-		Db:          db,
-		Mu:          &sync.RWMutex{},
-		PlayerCount: sync.Map{},
+		Db:           db,
+		Mu:           &sync.RWMutex{},
+		PlayerCount:  sync.Map{},
+		MatchStarted: sync.Map{},
+		watchers:     watchers{subs: map[chan *v1.LobbyEvent]struct{}{}},
 	}
 }
 
@@ -60,16 +66,48 @@ func (lobbyService *Service) GetLobbyByIDOrName(identifier string) (*Lobby, erro
 	return lobbyService.GetLobbyByName(identifier)
 }
 
-func (lobbyService *Service) CreateLobby(lobbyName, username string, userId uint) (uint, error) {
-	err := lobbyService.countUserLobbies(userId)
+// SetMapNames sets the maps a lobby can be played on, the first is the default
+func (lobbyService *Service) SetMapNames(names []string) {
+	lobbyService.mapNames = names
+}
+
+// MapNames lists the maps a lobby can be played on, the first is the default
+func (lobbyService *Service) MapNames() []string {
+	return lobbyService.mapNames
+}
+
+// GameModes lists the game modes a lobby can be created with, the first is the default
+var GameModes = []string{"classic", "race", "battle"}
+
+// CreateLobby stores a new lobby, a lobby without a map seed gets a random one
+// so its generated map can be played again
+func (lobbyService *Service) CreateLobby(lobbyName, gameMode, mapName string, mapSeed int64, username string, userId uint) (uint, error) {
+	gameMode, err := validateGameMode(gameMode)
 	if err != nil {
 		return 0, err
 	}
 
+	mapName, err = lobbyService.validateMapName(mapName)
+	if err != nil {
+		return 0, err
+	}
+
+	err = lobbyService.countUserLobbies(userId)
+	if err != nil {
+		return 0, err
+	}
+
+	if mapSeed == 0 {
+		mapSeed = rand.Int63n(1<<53-1) + 1
+	}
+
 	lobby := &Lobby{
 		LobbyName: lobbyName,
 		UserID:    int64(userId),
 		Username:  username,
+		GameMode:  gameMode,
+		MapName:   mapName,
+		MapSeed:   mapSeed,
 	}
 
 	result := lobbyService.Db.Create(lobby)
@@ -78,6 +116,7 @@ func (lobbyService *Service) CreateLobby(lobbyName, username string, userId uint
 		return 0, fmt.Errorf("lobby aanmaken mislukt")
 	}
 
+	lobbyService.publish(v1.LobbyEventType_LOBBY_EVENT_TYPE_CREATED, lobby.ToRPC())
 	return lobby.ID, nil
 }
 
@@ -88,6 +127,11 @@ func (lobbyService *Service) DeleteLobby(lobbyId uint64, userId uint) error {
 		return fmt.Errorf("lobby verwijderen mislukt")
 	}
 
+	if res.RowsAffected > 0 {
+		lobbyService.PlayerCount.Delete(uint(lobbyId))
+		lobbyService.MatchStarted.Delete(uint(lobbyId))
+		lobbyService.publish(v1.LobbyEventType_LOBBY_EVENT_TYPE_DELETED, &v1.Lobby{ID: lobbyId})
+	}
 	return nil
 }
 
@@ -114,12 +158,40 @@ func (lobbyService *Service) GetGrpcLobbies() ([]*v1.Lobby, error) {
 	for _, lobby := range lobbies {
 		lobbyTmp := lobby.ToRPC()
 		lobbyTmp.PlayerCount = lobbyService.GetLobbyPlayerCount(lobby.ID)
+		lobbyTmp.MatchStarted = lobbyService.IsLobbyMatchStarted(lobby.ID)
 		grpcLobbies = append(grpcLobbies, lobbyTmp)
 	}
 
 	return grpcLobbies, nil
 }
 
+func validateGameMode(gameMode string) (string, error) {
+	if gameMode == "" {
+		return GameModes[0], nil
+	}
+	for _, mode := range GameModes {
+		if mode == gameMode {
+			return mode, nil
+		}
+	}
+	return "", fmt.Errorf("onbekende spelmodus: %s", gameMode)
+}
+
+func (lobbyService *Service) validateMapName(mapName string) (string, error) {
+	if mapName == "" {
+		if len(lobbyService.mapNames) == 0 {
+			return "", nil
+		}
+		return lobbyService.mapNames[0], nil
+	}
+	for _, name := range lobbyService.mapNames {
+		if name == mapName {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("onbekende map: %s", mapName)
+}
+
 func (lobbyService *Service) countUserLobbies(uid uint) error {
 	var count int64
 	result := lobbyService.Db.
@@ -151,5 +223,13 @@ func (lobbyService *Service) GetLobbyPlayerCount(lobbyId uint) uint64 {
 
 func (lobbyService *Service) UpdateLobbyPlayerCount(lobbyId uint, count int) {
 	//log.Debug().Msgf("Updating Lobby: %d, count: %d", lobbyId, count)
-	lobbyService.PlayerCount.Store(lobbyId, count)
+	previous, loaded := lobbyService.PlayerCount.Swap(lobbyId, count)
+	if loaded && previous.(int) == count {
+		return
+	}
+
+	lobbyService.publish(v1.LobbyEventType_LOBBY_EVENT_TYPE_PLAYER_COUNT, &v1.Lobby{
+		ID:          uint64(lobbyId),
+		PlayerCount: uint64(count),
+	})
 }
@@ -0,0 +1,26 @@
+package match
+
+import (
+	"gorm.io/gorm"
+)
+
+// Match stores the outcome of a finished game, the per-player results are
+// user.Score rows pointing back at it
+type Match struct {
+	gorm.Model
+	LobbyID   uint `gorm:"index"`
+	LobbyName string
+	GameMode  string `gorm:"index"` // classic, race, battle
+	Duration  int    // seconds
+	Reason    string
+	Winner    string
+}
+
+// Replay points to the replay file of a recorded match on disk
+type Replay struct {
+	gorm.Model
+	MatchID    uint `gorm:"uniqueIndex"`
+	Path       string
+	Size       int64 // bytes on disk
+	DurationMs int64
+}
@@ -0,0 +1,61 @@
+package match
+
+import (
+	"fmt"
+
+	"github.com/frank2889/mazechase/internal/user"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type Service struct {
+	Db *gorm.DB
+}
+
+func NewMatchService(db *gorm.DB) *Service {
+	return &Service{Db: db}
+}
+
+// RecordMatch stores a finished match together with the score of every player
+func (matchService *Service) RecordMatch(match *Match, scores []user.Score) error {
+	err := matchService.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(match).Error; err != nil {
+			return err
+		}
+
+		for i := range scores {
+			scores[i].MatchID = match.ID
+			scores[i].GameMode = match.GameMode
+			scores[i].GameDuration = match.Duration
+		}
+		if len(scores) == 0 {
+			return nil
+		}
+		return tx.Create(&scores).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Uint("lobby", match.LobbyID).Msg("unable to record match")
+		return fmt.Errorf("wedstrijd opslaan mislukt")
+	}
+
+	log.Info().Uint("match", match.ID).Int("players", len(scores)).Msg("match recorded")
+	return nil
+}
+
+// SaveReplay stores where the replay of a match is kept
+func (matchService *Service) SaveReplay(replay *Replay) error {
+	if err := matchService.Db.Create(replay).Error; err != nil {
+		log.Error().Err(err).Uint("match", replay.MatchID).Msg("unable to save replay")
+		return fmt.Errorf("replay opslaan mislukt")
+	}
+	return nil
+}
+
+// GetReplay returns the replay of a match
+func (matchService *Service) GetReplay(matchID uint) (*Replay, error) {
+	var replay Replay
+	if err := matchService.Db.Where("match_id = ?", matchID).First(&replay).Error; err != nil {
+		return nil, fmt.Errorf("replay niet gevonden")
+	}
+	return &replay, nil
+}
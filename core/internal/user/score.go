@@ -7,22 +7,27 @@ import (
 // Score stores game scores for leaderboard
 type Score struct {
 	gorm.Model
+	MatchID          uint   `gorm:"index"`
 	UserID           uint   `gorm:"index"`
 	Username         string `gorm:"index"`
 	GameMode         string `gorm:"index"` // classic, race, battle
 	Score            int
 	PelletsCollected int
 	PlayersEliminated int
+	ChasersEaten     int
 	Won              bool
 	GameDuration     int // seconds
 }
 
-// LeaderboardEntry for API responses
+// LeaderboardEntry for API responses, filled from aggregates over Score
 type LeaderboardEntry struct {
-	Rank     int    `json:"rank"`
-	UserID   uint   `json:"userId"`
-	Username string `json:"username"`
-	Score    int    `json:"score"`
-	Wins     int    `json:"wins"`
-	Games    int    `json:"games"`
+	Rank         int    `json:"rank"`
+	UserID       uint   `json:"userId"`
+	Username     string `json:"username"`
+	Score        int    `json:"score"`
+	Wins         int    `json:"wins"`
+	Games        int    `json:"games"`
+	HighScore    int    `json:"highScore"`
+	PelletsEaten int    `json:"pelletsEaten"`
+	ChasersEaten int    `json:"chasersEaten"`
 }
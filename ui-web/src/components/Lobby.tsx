@@ -1,12 +1,14 @@
 import {type Component, createEffect, createSignal, For, onCleanup, Show} from 'solid-js';
 import type {Lobby} from "../lib/generated/lobby/v1/lobby_pb.ts";
-import {addLobby, deleteLobby, getRelativeTime, listLobbies} from "../lib/lobby.ts";
+import {addLobby, deleteLobby, getRelativeTime, listLobbies, listMaps, watchLobbies} from "../lib/lobby.ts";
 import {getUserInfo, logout} from "../lib/auth.ts";
 import Snackbar, {type SnackbarMessage} from "./Snackbar.tsx";
 import {GAME_MODES, type GameMode} from "../lib/game/modes.ts";
+import {Leaderboard, type LeaderboardEntry} from "./Leaderboard.tsx";
+import {getTopPlayers, toLeaderboardEntry} from "../lib/leaderboard.ts";
 import {
     Gamepad2, Trophy, Link2, Plus, ClipboardList, Play, Check, 
-    Copy, Trash2, Users, Zap, Ghost, Flag, Swords, LogOut, X
+    Copy, Trash2, Users, Zap, Ghost, Flag, Swords, LogOut
 } from 'lucide-solid';
 
 const LobbyComponent: Component = () => {
@@ -19,10 +21,14 @@ const LobbyComponent: Component = () => {
     const [newLobbyName, setNewLobbyName] = createSignal("");
     const [isCreatingLobby, setIsCreatingLobby] = createSignal(false);
     const [selectedMode, setSelectedMode] = createSignal<GameMode>('classic');
+    const [mapNames, setMapNames] = createSignal<string[]>([]);
+    const [selectedMap, setSelectedMap] = createSignal("");
     const [joinCode, setJoinCode] = createSignal("");
     const [showLeaderboard, setShowLeaderboard] = createSignal(false);
+    const [leaderboardEntries, setLeaderboardEntries] = createSignal<LeaderboardEntry[]>([]);
+    const [leaderboardLoading, setLeaderboardLoading] = createSignal(false);
 
-    let refreshInterval: NodeJS.Timeout | null = null;
+    let watchController: AbortController | null = null;
 
     // Snackbar state
     const [snackbarMessage, setSnackbarMessage] = createSignal<SnackbarMessage | null>(null);
@@ -39,6 +45,19 @@ const LobbyComponent: Component = () => {
     };
 
 
+    const openLeaderboard = async (): Promise<void> => {
+        setShowLeaderboard(true);
+        setLeaderboardLoading(true);
+
+        const {val, err} = await getTopPlayers();
+        if (err) {
+            showSnackbar(`Fout bij ophalen leaderboard: ${err}`, 'error');
+        }
+        setLeaderboardEntries((val?.entries ?? []).map(toLeaderboardEntry));
+
+        setLeaderboardLoading(false);
+    };
+
     const handleCreateLobby = async (): Promise<void> => {
         const lobbyName = newLobbyName().trim();
         if (!lobbyName) return;
@@ -49,7 +68,7 @@ const LobbyComponent: Component = () => {
         const randomNum = Math.floor(1000 + Math.random() * 9000);
         const fullLobbyName = `${lobbyName}#${randomNum}`;
 
-        const {err} = await addLobby(fullLobbyName);
+        const {err} = await addLobby(fullLobbyName, selectedMode(), selectedMap());
         if (err) {
             showSnackbar(`Fout bij aanmaken lobby: ${err}`, 'error');
         }
@@ -97,15 +116,28 @@ const LobbyComponent: Component = () => {
         }
     };
 
+    // Lobby changes are pushed by the server, watch again when the stream drops
     const startAutoRefresh = (): void => {
+        stopAutoRefresh();
+        const controller = new AbortController();
+        watchController = controller;
+
         loadLobbies().then();
-        refreshInterval = setInterval(loadLobbies, 1000);
+        watchLobbies(setLobbies, controller.signal)
+            .catch((error) => console.error('Lobby stream closed:', error))
+            .finally(() => {
+                if (watchController === controller && !controller.signal.aborted) {
+                    setTimeout(() => {
+                        if (watchController === controller) startAutoRefresh();
+                    }, 2000);
+                }
+            });
     };
 
     const stopAutoRefresh = (): void => {
-        if (refreshInterval) {
-            clearInterval(refreshInterval);
-            refreshInterval = null;
+        if (watchController) {
+            watchController.abort();
+            watchController = null;
         }
     };
 
@@ -140,6 +172,9 @@ const LobbyComponent: Component = () => {
             }
             setCurrentUser(username);
             startAutoRefresh();
+
+            const {val} = await listMaps();
+            setMapNames(val?.mapNames ?? []);
         } catch (error) {
             console.error('Failed to get user info:', error);
             // Auth failed, redirect to login
@@ -180,7 +215,7 @@ const LobbyComponent: Component = () => {
 
                 <div class="absolute left-20 flex gap-3 items-center">
                     <button
-                        onClick={() => setShowLeaderboard(true)}
+                        onClick={openLeaderboard}
                         class="bg-purple-600 hover:bg-purple-500 text-white font-semibold py-2 px-4 rounded-lg shadow-lg transition-colors duration-200 flex items-center gap-2"
                     >
                         <Trophy class="w-5 h-5" /> Leaderboard
@@ -203,21 +238,11 @@ const LobbyComponent: Component = () => {
 
             {/* Leaderboard Modal */}
             <Show when={showLeaderboard()}>
-                <div class="fixed inset-0 bg-black/80 flex items-center justify-center z-50" onClick={() => setShowLeaderboard(false)}>
-                    <div class="bg-slate-900 rounded-2xl p-6 max-w-md w-full mx-4 border-2 border-purple-500/50" onClick={(e) => e.stopPropagation()}>
-                        <div class="flex justify-between items-center mb-6">
-                            <h2 class="text-2xl font-bold text-purple-400 flex items-center gap-2"><Trophy class="w-6 h-6" /> Leaderboard</h2>
-                            <button onClick={() => setShowLeaderboard(false)} class="text-gray-400 hover:text-white">
-                                <X class="w-6 h-6" />
-                            </button>
-                        </div>
-                        <div class="text-center py-8 text-gray-400">
-                            <Gamepad2 class="w-12 h-12 mx-auto mb-4 text-purple-400" />
-                            <p>Leaderboard wordt bijgehouden na je eerste game!</p>
-                            <p class="text-sm mt-2 text-gray-500">Speel een potje om je score te registreren.</p>
-                        </div>
-                    </div>
-                </div>
+                <Leaderboard
+                    entries={leaderboardEntries()}
+                    isLoading={leaderboardLoading()}
+                    onClose={() => setShowLeaderboard(false)}
+                />
             </Show>
 
             {/* Main Content */}
@@ -256,7 +281,7 @@ const LobbyComponent: Component = () => {
                     <button
                         onClick={async () => {
                             // Create a lobby for solo play
-                            const {val, err} = await addLobby(`Solo-${currentUser()}-${Date.now()}`);
+                            const {val, err} = await addLobby(`Solo-${currentUser()}-${Date.now()}`, selectedMode(), selectedMap());
                             if (err) {
                                 showSnackbar(`Fout: ${err}`, 'error');
                                 return;
@@ -298,6 +323,21 @@ const LobbyComponent: Component = () => {
                             )}
                         </For>
                     </div>
+                    <Show when={mapNames().length > 1}>
+                        <div class="flex justify-center items-center gap-2 mt-3">
+                            <label for="map-select" class="text-gray-300 text-sm">Map</label>
+                            <select
+                                id="map-select"
+                                value={selectedMap() || mapNames()[0]}
+                                onChange={(e) => setSelectedMap(e.currentTarget.value)}
+                                class="px-3 py-1 bg-slate-800 text-white border border-slate-600 rounded-lg focus:outline-none focus:border-purple-500"
+                            >
+                                <For each={mapNames()}>
+                                    {(name) => <option value={name}>{name}</option>}
+                                </For>
+                            </select>
+                        </div>
+                    </Show>
                 </div>
 
                 {/* Join by Code */}
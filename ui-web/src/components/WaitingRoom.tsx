@@ -1,5 +1,6 @@
 import { type Component, createSignal, For, Show, onCleanup, createEffect } from 'solid-js';
-import { Users, Check, Clock, Crown, Eye, Play, X } from 'lucide-solid';
+import { Users, Check, Clock, Crown, Eye, Play, X, Bot, Heart } from 'lucide-solid';
+import type { BotDifficulty } from '../lib/game/connection';
 
 export interface Player {
     id: string;
@@ -19,8 +20,15 @@ interface WaitingRoomProps {
     playerCount: number;
     readyCount: number;
     countdown: number | null;
+    botDifficulty: BotDifficulty;
+    roundDuration: number;
+    suddenDeath: boolean;
+    lives: number;
     onToggleReady: () => void;
     onStartGame: () => void;
+    onBotDifficulty: (difficulty: BotDifficulty) => void;
+    onRoundSettings: (durationSec: number, suddenDeath: boolean) => void;
+    onLives: (lives: number) => void;
     onLeave: () => void;
 }
 
@@ -30,6 +38,23 @@ const WaitingRoom: Component<WaitingRoomProps> = (props) => {
         return props.isHost && props.playerCount >= 2 && props.readyCount === props.playerCount;
     };
 
+    const difficultyLabels: Record<BotDifficulty, string> = {
+        easy: 'Makkelijk',
+        normal: 'Normaal',
+        hard: 'Moeilijk',
+    };
+
+    const durationLabels: Record<number, string> = {
+        60: '1 minuut',
+        120: '2 minuten',
+        180: '3 minuten',
+        300: '5 minuten',
+        600: '10 minuten',
+        0: 'Geen limiet',
+    };
+
+    const livesOptions = [1, 2, 3, 5, 9];
+
     const getSpriteColor = (spriteType: string) => {
         const colors: Record<string, string> = {
             'runner': 'bg-yellow-500',
@@ -120,6 +145,80 @@ const WaitingRoom: Component<WaitingRoomProps> = (props) => {
                     </For>
                 </div>
 
+                {/* Bot Difficulty */}
+                <div class="flex items-center justify-between bg-slate-700/50 rounded-lg px-4 py-2 mb-6">
+                    <span class="text-gray-300 flex items-center gap-2">
+                        <Bot class="w-4 h-4 text-cyan-400" /> Bots
+                    </span>
+                    <Show when={props.isHost} fallback={
+                        <span class="text-white font-semibold">{difficultyLabels[props.botDifficulty]}</span>
+                    }>
+                        <select
+                            value={props.botDifficulty}
+                            onChange={(e) => props.onBotDifficulty(e.currentTarget.value as BotDifficulty)}
+                            class="bg-slate-800 text-white rounded px-2 py-1 border border-slate-600"
+                        >
+                            <For each={Object.keys(difficultyLabels) as BotDifficulty[]}>
+                                {(difficulty) => <option value={difficulty}>{difficultyLabels[difficulty]}</option>}
+                            </For>
+                        </select>
+                    </Show>
+                </div>
+
+                {/* Round Timer */}
+                <div class="flex items-center justify-between bg-slate-700/50 rounded-lg px-4 py-2 mb-6">
+                    <span class="text-gray-300 flex items-center gap-2">
+                        <Clock class="w-4 h-4 text-cyan-400" /> Speelduur
+                    </span>
+                    <Show when={props.isHost} fallback={
+                        <span class="text-white font-semibold">
+                            {durationLabels[props.roundDuration] ?? `${props.roundDuration} seconden`}
+                            {props.suddenDeath ? ' + sudden death' : ''}
+                        </span>
+                    }>
+                        <div class="flex items-center gap-3">
+                            <select
+                                value={props.roundDuration}
+                                onChange={(e) => props.onRoundSettings(Number(e.currentTarget.value), props.suddenDeath)}
+                                class="bg-slate-800 text-white rounded px-2 py-1 border border-slate-600"
+                            >
+                                <For each={Object.keys(durationLabels).map(Number)}>
+                                    {(duration) => <option value={duration}>{durationLabels[duration]}</option>}
+                                </For>
+                            </select>
+                            <label class="text-gray-300 text-sm flex items-center gap-1">
+                                <input
+                                    type="checkbox"
+                                    checked={props.suddenDeath}
+                                    disabled={props.roundDuration === 0}
+                                    onChange={(e) => props.onRoundSettings(props.roundDuration, e.currentTarget.checked)}
+                                />
+                                Sudden death
+                            </label>
+                        </div>
+                    </Show>
+                </div>
+
+                {/* Runner Lives */}
+                <div class="flex items-center justify-between bg-slate-700/50 rounded-lg px-4 py-2 mb-6">
+                    <span class="text-gray-300 flex items-center gap-2">
+                        <Heart class="w-4 h-4 text-cyan-400" /> Levens
+                    </span>
+                    <Show when={props.isHost} fallback={
+                        <span class="text-white font-semibold">{props.lives}</span>
+                    }>
+                        <select
+                            value={props.lives}
+                            onChange={(e) => props.onLives(Number(e.currentTarget.value))}
+                            class="bg-slate-800 text-white rounded px-2 py-1 border border-slate-600"
+                        >
+                            <For each={livesOptions}>
+                                {(lives) => <option value={lives}>{lives}</option>}
+                            </For>
+                        </select>
+                    </Show>
+                </div>
+
                 {/* Spectators */}
                 <Show when={props.spectators.length > 0}>
                     <div class="space-y-2 mb-6">
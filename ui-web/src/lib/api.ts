@@ -2,6 +2,7 @@ import {createConnectTransport} from "@connectrpc/connect-web";
 import {ConnectError, createClient} from "@connectrpc/connect";
 import {AuthService} from "./generated/auth/v1/auth_pb.ts";
 import {LobbyService} from "./generated/lobby/v1/lobby_pb.ts";
+import {LeaderboardService} from "./generated/leaderboard/v1/leaderboard_pb.ts";
 
 export function getBaseUrl() {
     if (import.meta.env.DEV) {
@@ -25,6 +26,7 @@ const transport = createConnectTransport({
 
 export const authClient = createClient(AuthService, transport);
 export const lobbyClient = createClient(LobbyService, transport);
+export const leaderboardClient = createClient(LeaderboardService, transport);
 
 export async function callRPC<T>(exec: () => Promise<T>): Promise<{ val: T | null; err: string; }> {
     try {
@@ -1,6 +1,8 @@
 import {showError} from "./utils.ts";
 import type {AnimDir, GameMessage, LobbyPlayer} from "./models.ts";
 import {getBaseUrl} from "../api.ts";
+import {fromBinary, toJson, type DescMessage} from "@bufbuild/protobuf";
+import {EnvelopeSchema, type Envelope} from "../generated/game/v1/game_pb.ts";
 
 let ws: WebSocket;
 let resolvePromise: ((value: string | PromiseLike<string>) => void) | undefined;
@@ -9,14 +11,31 @@ let gameStatePromise: Promise<string>
 
 let prevGameState: any = {}
 
+// Version of the game protocol, see spec/protos/game/v1 and docs/ws-protocol.md
+export const PROTOCOL_VERSION = 3;
+
+// Sequence number of our own messages
+let clientSeq = 0;
+
+// Server messages come as binary protobuf frames, ?enc=json on the page keeps
+// them readable in the dev tools
+function messageEncoding(): 'json' | 'proto' {
+    return new URLSearchParams(window.location.search).get('enc') === 'json' ? 'json' : 'proto';
+}
+
 // Game event handlers for 3D scene
 export interface GameEventHandlers {
     onPlayerMove?: (spriteId: string, x: number, y: number, dir: string) => void;
     onPelletEaten?: (tileX: number, tileY: number) => void;
     onPowerUpEaten?: (tileX: number, tileY: number, duration?: number) => void;
     onPowerUpEnd?: () => void;
+    onChaserState?: (spriteId: string, state: string, ms: number) => void;
+    onLifeLost?: (lives: number, freezeMs: number) => void;
+    onRespawn?: (positions: Record<string, {x: number, y: number}>) => void;
+    onLevel?: (level: number, chaserSpeedFactor: number, powerUpDuration: number, freezeMs: number) => void;
     onPlayerCaught?: (runnerId: string, chaserId: string) => void;
-    onGameOver?: (winner: string, scores: Record<string, number>) => void;
+    onPlayerEliminated?: (playerId: string, byPlayerId: string) => void;
+    onGameOver?: (winner: string, scores: Record<string, number>, names: Record<string, string>, level: number, lives: number) => void;
     onScoreUpdate?: (scores: Record<string, number>) => void;
     onPlayerJoin?: (spriteId: string, username: string) => void;
     onPlayerLeave?: (spriteId: string) => void;
@@ -27,8 +46,30 @@ export interface GameEventHandlers {
     onMazeUpdate?: (update: MazeUpdate) => void;
     onEntitiesUpdate?: (entities: DangerEntityData[]) => void;
     onEntityNear?: (entityId: string, warning: boolean) => void;
-    onEntityCollision?: (entityId: string, entityType: string, caught: boolean) => void;
+    onEntityCollision?: (entityId: string, entityType: string, outcome: string, stunMs: number) => void;
     onDynamicStateSync?: (state: DynamicState) => void;
+
+    // Round timer
+    onTimer?: (remainingMs: number, durationMs: number, suddenDeath: boolean) => void;
+    onSuddenDeath?: (powerUps: {x: number, y: number}[], speedFactor: number) => void;
+
+    // Replay playback
+    onReplayStatus?: (status: ReplayStatus) => void;
+}
+
+export interface ReplayInfo {
+    matchId: number;
+    mode: string;
+    startedAt: string;
+    durationMs: number;
+}
+
+export interface ReplayStatus {
+    atMs: number;
+    durationMs: number;
+    paused: boolean;
+    speed: number;
+    ended: boolean;
 }
 
 // Dynamic world types
@@ -49,6 +90,8 @@ export interface MazeUpdate {
     targetX?: number;
     targetY?: number;
     duration: number;
+    // ms until the server undoes the update, absent on the undo itself
+    revertIn?: number;
 }
 
 export interface DangerEntityData {
@@ -73,6 +116,8 @@ export interface DynamicState {
         progress: number;
     };
     entities: DangerEntityData[];
+    // maze updates in effect, not yet reverted
+    mazeUpdates?: MazeUpdate[];
 }
 
 let gameEventHandlers: GameEventHandlers = {};
@@ -90,11 +135,18 @@ export interface LobbyState {
     isHost: boolean;
     isReady: boolean;
     isSpectator: boolean;
+    spectatorCount: number;
     playerCount: number;
     readyCount: number;
     countdown: number | null;
+    botDifficulty: BotDifficulty;
+    roundDuration: number; // Seconds, 0 plays without a round timer
+    suddenDeath: boolean;
+    lives: number; // Lives of the runner in classic mode
 }
 
+export type BotDifficulty = 'easy' | 'normal' | 'hard';
+
 let lobbyState: LobbyState = {
     players: [],
     spectators: [],
@@ -103,9 +155,14 @@ let lobbyState: LobbyState = {
     isHost: false,
     isReady: false,
     isSpectator: false,
+    spectatorCount: 0,
     playerCount: 0,
     readyCount: 0,
-    countdown: null
+    countdown: null,
+    botDifficulty: 'normal',
+    roundDuration: 180,
+    suddenDeath: false,
+    lives: 3
 };
 
 let lobbyStateListeners: ((state: LobbyState) => void)[] = [];
@@ -138,15 +195,24 @@ let messageHandlers: { [key: string]: (json: any) => void } = {
     "active": handleNewPlayerJoin,
     "state": handleGameStateMessage,
     "dis": handleDisconnect,
+    "reconnecting": handlePlayerReconnecting,
+    "resumed": handlePlayerResumed,
     "pos": handlePosMessage,
     "pel": handlePellet,
     "pow": handlePowerPelletStart,
     "powend": handlePowerPelletEnd,
+    "chaser": handleChaserState,
+    "lifelost": handleLifeLost,
+    "respawn": handleRespawn,
+    "level": handleLevel,
     "kill": handlePlayerKilled,
+    "eliminated": handlePlayerEliminated,
     "gameover": handleGameOver,
     "lobbystatus": handleLobbyStatus,
     "countdown": handleCountdown,
     "gamestart": handleGameStart,
+    "snapshot": handleSnapshot,
+    "error": handleServerError,
     // Dynamic world handlers
     "phase_change": handlePhaseChange,
     "phase_update": handlePhaseUpdate,
@@ -154,7 +220,13 @@ let messageHandlers: { [key: string]: (json: any) => void } = {
     "entities_update": handleEntitiesUpdate,
     "entity_near": handleEntityNear,
     "entity_collision": handleEntityCollision,
-    "dynamic_state": handleDynamicState
+    "dynamic_state": handleDynamicState,
+    // Round timer handlers
+    "timer": handleTimer,
+    "suddendeath": handleSuddenDeath,
+    // Replay handlers
+    "replayinfo": handleReplayInfo,
+    "replaystatus": handleReplayStatus
 }
 
 // Reconnection state
@@ -164,11 +236,26 @@ const RECONNECT_DELAY_BASE = 1000; // 1 second base delay
 let reconnectTimeout: ReturnType<typeof setTimeout> | null = null;
 let isReconnecting = false;
 
+// Match id of the replay being watched, from ?replay=
+export function getReplayMatchId(): string | null {
+    return new URLSearchParams(window.location.search).get('replay');
+}
+
 export function connectToWebSocket() {
     let wssProtocol = `${window.location.protocol === 'https:' ? 'wss://' : 'ws://'}`
 
     const queryString = window.location.search;
     const params = new URLSearchParams(queryString);
+
+    // A replay is played back by the server as if it were a live match
+    const replayMatchId = getReplayMatchId();
+    if (replayMatchId !== null) {
+        const startAt = params.get('t') ?? '0';
+        const base = new URL(getBaseUrl())
+        openWebSocket(wssProtocol + base.host + `/api/replay?v=${PROTOCOL_VERSION}&match=${replayMatchId}&t=${startAt}`);
+        return;
+    }
+
     const lobbyId = params.get('lobby');
     if (lobbyId === null) {
         throw new Error("lobbyId must be provided");
@@ -176,10 +263,21 @@ export function connectToWebSocket() {
     
     // Check if solo mode
     const isSingle = params.get('single') === 'true';
+    // Spectators may also join a running match
+    const isSpectate = params.get('spectate') === 'true';
+
+    // After a dropped connection, reclaim our sprite with the token of the last state
+    const resumeToken = prevGameState.resumeToken as string | undefined;
+    const resume = resumeToken ? `&resume=${encodeURIComponent(resumeToken)}` : '';
 
     const base = new URL(getBaseUrl())
-    const url = wssProtocol + base.host + `/api/game?lobby=${lobbyId}${isSingle ? '&single=true' : ''}`;
+    const url = wssProtocol + base.host + `/api/game?v=${PROTOCOL_VERSION}&enc=${messageEncoding()}&lobby=${lobbyId}${isSingle ? '&single=true' : ''}${isSpectate ? '&spectate=true' : ''}${resume}`;
+    openWebSocket(url);
+}
+
+function openWebSocket(url: string) {
     ws = new WebSocket(url);
+    ws.binaryType = 'arraybuffer';
 
     ws.onopen = () => {
         console.log('WebSocket connected');
@@ -256,8 +354,13 @@ function handleMessage(msg: MessageEvent): void {
     }
 
     try {
-        const json = JSON.parse(msg.data);
-        const mType = json["type"] as string
+        const envelope = typeof msg.data === 'string'
+            ? JSON.parse(msg.data)
+            : wireEnvelope(fromBinary(EnvelopeSchema, new Uint8Array(msg.data)));
+        if (envelope.v !== PROTOCOL_VERSION) {
+            console.warn(`Unexpected protocol version: ${envelope.v}`);
+        }
+        const mType = envelope.type as string
 
         const handler = messageHandlers[mType]
         if (!handler) {
@@ -265,13 +368,14 @@ function handleMessage(msg: MessageEvent): void {
             return;
         }
 
-        if (Object.keys(prevGameState).length === 0 && !["state", "lobbystatus", "countdown", "gamestart"].includes(mType)) {
+        if (Object.keys(prevGameState).length === 0 && !["state", "error", "lobbystatus", "countdown", "gamestart", "replayinfo", "replaystatus"].includes(mType)) {
             // state has not been received
             // game has not started, ignore all messages (except lobby-related ones)
             return;
         }
 
-        handler(json)
+        // handlers get the payload, its fields depend on the message type
+        handler(envelope.payload ?? {})
     } catch (e: any) {
         console.log("unable to handle message")
         console.log(e)
@@ -280,11 +384,59 @@ function handleMessage(msg: MessageEvent): void {
     }
 }
 
+// wireEnvelope gives a binary envelope the shape of the JSON wire, so the
+// handlers read both the same way
+function wireEnvelope(envelope: Envelope): any {
+    const field = EnvelopeSchema.oneofs[0].fields.find(f => f.localName === envelope.payload.case);
+    if (!field || field.fieldKind !== 'message' || envelope.payload.value === undefined) {
+        return {};
+    }
+
+    let payload: any;
+    if (envelope.payload.case === 'snapshot') {
+        payload = {
+            tick: Number(envelope.payload.value.tick),
+            messages: envelope.payload.value.messages.map(wireEnvelope)
+        };
+    } else {
+        payload = toJson(field.message as DescMessage, envelope.payload.value as any, {alwaysEmitImplicit: true});
+    }
+
+    return {
+        v: envelope.version,
+        type: field.name,
+        seq: Number(envelope.seq),
+        ts: Number(envelope.ts),
+        payload
+    };
+}
+
+// Tick of the snapshot being dispatched
+let snapshotTick = 0;
+
+// A snapshot carries every event of one server tick, dispatch them in order
+function handleSnapshot(json: any) {
+    snapshotTick = Number(json.tick);
+    for (const message of json.messages ?? []) {
+        const handler = messageHandlers[message.type as string]
+        if (!handler) {
+            console.warn(`No handler found: ${message.type}`);
+            continue;
+        }
+        handler(message.payload ?? {})
+    }
+}
+
 function handleError(ev: Event): void {
     console.log('Error: ', ev.type);
     showError(ev.type);
 }
 
+function handleServerError(json: any): void {
+    console.log('Server error: ', json.error);
+    showError(json.error);
+}
+
 function handleGameStateMessage(msg: any) {
     prevGameState = msg;
     console.log(prevGameState)
@@ -308,6 +460,12 @@ function handleGameStateMessage(msg: any) {
     if (msg.playersList) {
         lobbyState.players = msg.playersList;
     }
+    if (msg.isSpectator !== undefined) {
+        lobbyState.isSpectator = msg.isSpectator;
+    }
+    if (msg.spectatorCount !== undefined) {
+        lobbyState.spectatorCount = msg.spectatorCount;
+    }
     
     notifyLobbyStateListeners();
     
@@ -372,6 +530,15 @@ function handleDisconnect(json: any) {
     gameEventHandlers.onPlayerLeave?.(spriteId);
 }
 
+// A player dropped, the server holds the sprite for a while
+function handlePlayerReconnecting(json: any) {
+    console.log(`Player ${json.playerId} reconnecting (${json.graceSec}s)`)
+}
+
+function handlePlayerResumed(json: any) {
+    console.log(`Player ${json.playerId} resumed`)
+}
+
 
 function handlePellet(json: any) {
     const x = json.x as number
@@ -402,6 +569,27 @@ function handlePowerPelletEnd(_json: any) {
 }
 
 
+// Chasers go frightened, eyes and back through the house on their own timers
+function handleChaserState(json: any) {
+    console.log('Chaser state:', json.spriteId, json.state);
+    gameEventHandlers.onChaserState?.(json.spriteId, json.state, json.ms ?? 0);
+}
+
+// A caught runner with lives left freezes everyone, then they all respawn
+function handleLifeLost(json: any) {
+    console.log('Life lost, lives left:', json.lives);
+    gameEventHandlers.onLifeLost?.(json.lives, json.freezeMs);
+}
+
+function handleRespawn(json: any) {
+    gameEventHandlers.onRespawn?.(json.positions || {});
+}
+
+function handleLevel(json: any) {
+    console.log('Level:', json.level);
+    gameEventHandlers.onLevel?.(json.level, json.chaserSpeedFactor, json.powerUpDuration, json.freezeMs);
+}
+
 function handlePlayerKilled(json: any) {
     let spriteId = json.spriteId;
     let chaserId = json.chaserId || 'unknown';
@@ -412,13 +600,24 @@ function handlePlayerKilled(json: any) {
 }
 
 
+function handlePlayerEliminated(json: any) {
+    const playerId = json.playerId as string;
+    const byPlayerId = json.by as string;
+    console.log(`player ${playerId} eliminated by ${byPlayerId}`)
+
+    // Notify 3D scene
+    gameEventHandlers.onPlayerEliminated?.(playerId, byPlayerId);
+}
+
+
 function handleGameOver(msg: any) {
     console.log(`game over: ${msg.reason}`)
     const winner = msg.winner || 'Onbekend';
     const scores = msg.scores || {};
+    const names = msg.names || {};
     
-    // Notify 3D scene
-    gameEventHandlers.onGameOver?.(winner, scores);
+    // Notify 3D scene, level and lives are 0 outside classic mode
+    gameEventHandlers.onGameOver?.(winner, scores, names, msg.level ?? 0, msg.lives ?? 0);
 }
 
 
@@ -458,9 +657,14 @@ export function sendPosMessage(dirOrX: string | number, y?: number, dir?: AnimDi
 
 
 export function sendWsMessage(messageType: GameMessage, data: any) {
-    data.type = messageType
-    data.secretToken = prevGameState.secretToken
-    const mess = JSON.stringify(data)
+    const mess = JSON.stringify({
+        v: PROTOCOL_VERSION,
+        type: messageType,
+        seq: ++clientSeq,
+        ts: Date.now(),
+        secretToken: prevGameState.secretToken,
+        payload: data
+    })
     ws.send(mess) // do not use json stringify here adds latency when sending messages
 }
 
@@ -489,9 +693,14 @@ function handleLobbyStatus(json: any) {
         isHost: meAsPlayer?.isHost || json.hostId === myPlayerId,
         isReady: meAsPlayer?.isReady || false,
         isSpectator: !meAsPlayer,
+        spectatorCount: json.spectatorCount ?? 0,
         playerCount: json.playerCount,
         readyCount: json.readyCount,
-        countdown: lobbyState.countdown // preserve countdown
+        countdown: lobbyState.countdown, // preserve countdown
+        botDifficulty: json.botDifficulty || lobbyState.botDifficulty,
+        roundDuration: json.roundDuration ?? lobbyState.roundDuration,
+        suddenDeath: json.suddenDeath ?? false,
+        lives: json.lives || lobbyState.lives
     };
     
     notifyLobbyStateListeners();
@@ -518,40 +727,129 @@ function handleGameStart(json: any) {
 
 // Dynamic world handlers
 function handlePhaseChange(json: any) {
-    const data = json.data || json;
-    console.log('Phase change:', data.newPhase);
-    gameEventHandlers.onPhaseChange?.(data.newPhase, data.zones || []);
+    console.log('Phase change:', json.newPhase);
+    gameEventHandlers.onPhaseChange?.(json.newPhase, json.zones || []);
 }
 
 function handlePhaseUpdate(json: any) {
-    const data = json.data || json;
-    gameEventHandlers.onPhaseUpdate?.(data.phase, data.progress);
+    gameEventHandlers.onPhaseUpdate?.(json.phase, json.progress);
 }
 
 function handleMazeUpdate(json: any) {
-    const data = json.data || json;
-    console.log('Maze update:', data);
-    gameEventHandlers.onMazeUpdate?.(data);
+    console.log('Maze update:', json);
+    gameEventHandlers.onMazeUpdate?.(json);
 }
 
+// Entities we know per snapshot tick, updates with a baseline only hold the
+// changes since the tick we acknowledged
+const entityStates = new Map<number, Map<string, DangerEntityData>>();
+const ACK_INTERVAL_MS = 200;
+let lastAckAt = 0;
+
 function handleEntitiesUpdate(json: any) {
-    const data = json.data || json;
-    gameEventHandlers.onEntitiesUpdate?.(data);
+    const baseline = Number(json.baseline ?? 0);
+    const known = baseline ? entityStates.get(baseline) : undefined;
+    if (baseline && !known) {
+        console.warn(`Entity baseline ${baseline} unknown`);
+        return;
+    }
+
+    const entities = new Map(known ?? []);
+    for (const entity of json.entities ?? []) {
+        entities.set(entity.id, entity);
+    }
+    for (const id of json.removed ?? []) {
+        entities.delete(id);
+    }
+
+    // The server never goes back to a tick before the baseline, a full
+    // update starts over
+    for (const tick of entityStates.keys()) {
+        if (tick < baseline || !baseline) {
+            entityStates.delete(tick);
+        }
+    }
+    entityStates.set(snapshotTick, entities);
+
+    gameEventHandlers.onEntitiesUpdate?.([...entities.values()]);
+    ackSnapshot(snapshotTick);
+}
+
+// Acknowledge a snapshot now and then, replays always send full updates
+function ackSnapshot(tick: number) {
+    const now = Date.now();
+    if (now - lastAckAt < ACK_INTERVAL_MS || getReplayMatchId() !== null) {
+        return;
+    }
+    lastAckAt = now;
+    sendWsMessage('ack', {tick});
 }
 
+// Entity warnings and catches are detected by the server for every player,
+// only our own are shown
 function handleEntityNear(json: any) {
+    if (json.playerId !== prevGameState.playerId) {
+        return;
+    }
     console.log('Entity near:', json.entityId);
     gameEventHandlers.onEntityNear?.(json.entityId, json.warning);
 }
 
 function handleEntityCollision(json: any) {
     console.log('Entity collision:', json);
-    gameEventHandlers.onEntityCollision?.(json.entityId, json.entityType, json.caught);
-    
-    // If caught, trigger game over
-    if (json.caught) {
-        gameEventHandlers.onPlayerCaught?.(prevGameState.spriteId, json.entityId);
+    if (json.playerId !== prevGameState.playerId) {
+        return;
     }
+    // Eliminations follow as their own message
+    gameEventHandlers.onEntityCollision?.(json.entityId, json.entityType, json.outcome, json.stunMs);
+}
+
+// Round timer handlers, the server's timer is leading over the local one
+function handleTimer(json: any) {
+    gameEventHandlers.onTimer?.(json.remainingMs, json.durationMs, json.suddenDeath);
+}
+
+function handleSuddenDeath(json: any) {
+    console.log('Sudden death:', json);
+    gameEventHandlers.onSuddenDeath?.(json.powerUps || [], json.speedFactor);
+}
+
+// Replay handlers
+let replayInfo: ReplayInfo | null = null;
+
+export function getReplayInfo(): ReplayInfo | null {
+    return replayInfo;
+}
+
+function handleReplayInfo(json: any) {
+    console.log('Replay info:', json);
+    replayInfo = {
+        matchId: json.matchId,
+        mode: json.mode,
+        startedAt: json.startedAt,
+        durationMs: json.durationMs
+    };
+}
+
+function handleReplayStatus(json: any) {
+    gameEventHandlers.onReplayStatus?.({
+        atMs: json.atMs,
+        durationMs: json.durationMs,
+        paused: json.paused,
+        speed: json.speed,
+        ended: json.ended
+    });
+}
+
+// Control replay playback: pause, play, seek (atMs) or speed
+export function sendReplayControl(action: 'pause' | 'play' | 'seek' | 'speed', value?: number) {
+    const data: any = { action };
+    if (action === 'seek') {
+        data.atMs = value ?? 0;
+    } else if (action === 'speed') {
+        data.speed = value ?? 1;
+    }
+    ws.send(JSON.stringify({ v: PROTOCOL_VERSION, type: 'replaycontrol', seq: ++clientSeq, ts: Date.now(), payload: data }));
 }
 
 // Send ready toggle message
@@ -566,6 +864,24 @@ export function sendStartGame() {
     sendWsMessage('startgame', {});
 }
 
+// Send bot difficulty (host only, before the match)
+export function sendBotDifficulty(difficulty: BotDifficulty) {
+    console.log('Setting bot difficulty', difficulty);
+    sendWsMessage('botdifficulty', { difficulty });
+}
+
+// Send round duration in seconds and sudden death (host only, before the match)
+export function sendRoundSettings(durationSec: number, suddenDeath: boolean) {
+    console.log('Setting round', durationSec, suddenDeath);
+    sendWsMessage('roundsettings', { durationSec, suddenDeath });
+}
+
+// Send the runner's lives (host only, before the match)
+export function sendLives(lives: number) {
+    console.log('Setting lives', lives);
+    sendWsMessage('lives', { lives });
+}
+
 // Request lobby status update
 export function requestLobbyStatus() {
     console.log('Requesting lobby status');
@@ -578,11 +894,6 @@ export function requestDynamicState() {
     sendWsMessage('dynamic_state', {});
 }
 
-// Report entity collision (server verifies)
-export function sendEntityCollision(x: number, y: number) {
-    sendWsMessage('entity_collision', { x, y });
-}
-
 // Query current zone
 export function sendZoneQuery(x: number, y: number) {
     sendWsMessage('zone_query', { x, y });
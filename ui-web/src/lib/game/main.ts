@@ -4,7 +4,7 @@
  * MazeChase 3D using Babylon.js
  */
 
-import {connectToWebSocket, waitForGameState, subscribeLobbyState, subscribeGameEvents} from "./connection.ts";
+import {connectToWebSocket, waitForGameState, subscribeLobbyState, getLobbyState, getGameState, subscribeGameEvents, getReplayMatchId} from "./connection.ts";
 import {showError} from "./utils.ts";
 import {getUserInfo} from "../auth.ts";
 import {mountWaitingRoom, unmountWaitingRoom} from "./waiting-room.tsx";
@@ -45,7 +45,13 @@ export async function initGame() {
     
     connectToWebSocket();
     
-    if (isSoloGame) {
+    if (getReplayMatchId() !== null) {
+        // Replay mode: watch a recorded match as a spectator
+        console.log("Replay mode: waiting for recorded state");
+        await waitForGameState();
+        await start3DGame();
+        addReplayControls();
+    } else if (isSoloGame) {
         // Solo mode: skip waiting room, start game immediately after state received
         console.log("Solo mode: starting game immediately");
         await waitForGameState();
@@ -134,7 +140,13 @@ async function start3DGame() {
         game3d = new Game3DScene(canvas);
         
         updateLoadingProgress(60, 'Loading maze...');
-        await game3d.loadRealMap('/gassets/map.json');
+        const { getGameState } = await import('./connection.ts');
+        const serverMap = getGameState().map;
+        if (serverMap?.tiles) {
+            game3d.loadServerMap(serverMap);
+        } else {
+            await game3d.loadRealMap('/gassets/map.json');
+        }
         
         updateLoadingProgress(80, 'Creating players...');
         game3d.initPlayers();
@@ -149,12 +161,16 @@ async function start3DGame() {
         
         updateLoadingProgress(90, 'Setting up controls...');
         
-        // Setup keyboard input
-        setupKeyboardInput(canvas);
-        
-        // Setup touch input for mobile
-        if (isTouchDevice()) {
-            setupTouchControls(container);
+        // Setup keyboard input, spectators and replays are only watched
+        if (mySprite) {
+            setupKeyboardInput(canvas);
+            
+            // Setup touch input for mobile
+            if (isTouchDevice()) {
+                setupTouchControls(container);
+            }
+        } else {
+            addSpectatorCamera(canvas);
         }
         
         // Initialize audio system on first user interaction
@@ -178,6 +194,12 @@ async function start3DGame() {
         // Start game timer
         startGameTimer();
         
+        // Lives and level, classic mode only
+        const { level, lives } = getGameState();
+        if (level) {
+            updateProgressDisplay(level, lives);
+        }
+        
         // Hide loading screen
         updateLoadingProgress(100, 'Ready!');
         hideLoadingScreen();
@@ -444,6 +466,11 @@ function setupGameEventHandlers() {
                 }
                 hidePowerUpTimer();
             },
+            onChaserState: (spriteId: string, state: string, _ms: number) => {
+                if (game3d) {
+                    game3d.setChaserState(spriteId, state);
+                }
+            },
             onPlayerCaught: (runnerId: string, _chaserId: string) => {
                 console.log(`Player ${runnerId} was caught!`);
                 if (game3d) {
@@ -463,10 +490,28 @@ function setupGameEventHandlers() {
                     game3d.hidePlayer(spriteId);
                 }
             },
-            onGameOver: (winner: string, scores: Record<string, number>) => {
+            onGameOver: (winner: string, scores: Record<string, number>, names: Record<string, string>, level: number, lives: number) => {
                 console.log(`Game over! Winner: ${winner}`, scores);
                 stopGameTimer();
-                showGameOver(winner, scores);
+                showGameOver(winner, scores, names, level, lives);
+            },
+            onLifeLost: (lives: number, _freezeMs: number) => {
+                updateProgressDisplay(currentLevel, lives);
+            },
+            onRespawn: (positions) => {
+                if (game3d) {
+                    for (const [spriteId, pos] of Object.entries(positions)) {
+                        game3d.updatePlayerPositionPixels(spriteId, pos.x, pos.y);
+                        game3d.getPlayer(spriteId)?.setVisible(true);
+                    }
+                }
+            },
+            onLevel: (level: number, _chaserSpeedFactor: number, _powerUpDuration: number, _freezeMs: number) => {
+                if (game3d) {
+                    game3d.restoreMaze(getGameState().map);
+                }
+                hidePowerUpTimer();
+                updateProgressDisplay(level, currentLives);
             },
             onScoreUpdate: (scores: Record<string, number>) => {
                 updateScoreDisplay(scores);
@@ -502,11 +547,20 @@ function setupGameEventHandlers() {
                     showEntityWarning(entityId);
                 }
             },
-            onEntityCollision: (entityId: string, entityType: string, caught: boolean) => {
-                console.log(`Entity collision: ${entityId} (${entityType}), caught: ${caught}`);
-                if (caught) {
-                    showCaughtByEntity(entityType);
+            onEntityCollision: (entityId: string, entityType: string, outcome: string, stunMs: number) => {
+                console.log(`Entity collision: ${entityId} (${entityType}), ${outcome} ${stunMs}ms`);
+                showCaughtByEntity(entityType, outcome === 'stun' ? stunMs : 0);
+            },
+            onTimer: (remainingMs: number, _durationMs: number, suddenDeath: boolean) => {
+                syncGameTimer(remainingMs, suddenDeath);
+            },
+            onSuddenDeath: (powerUps, _speedFactor: number) => {
+                if (game3d) {
+                    for (const tile of powerUps) {
+                        game3d.removePowerUp(tile.x, tile.y);
+                    }
                 }
+                hidePowerUpTimer();
             },
             onDynamicStateSync: (state) => {
                 console.log('Dynamic state sync:', state);
@@ -553,10 +607,181 @@ function addAudioToggle() {
     document.body.appendChild(toggleBtn);
 }
 
+/**
+ * Let spectators switch the camera between players with Tab or the buttons
+ */
+function addSpectatorCamera(canvas: HTMLCanvasElement) {
+    if (!game3d) return;
+    const scene = game3d;
+
+    const bar = document.createElement('div');
+    bar.id = 'spectator-camera';
+    bar.style.cssText = `
+        position: fixed;
+        top: 20px;
+        left: 50%;
+        transform: translateX(-50%);
+        display: flex;
+        align-items: center;
+        gap: 12px;
+        padding: 8px 14px;
+        border-radius: 12px;
+        background: rgba(0, 0, 0, 0.7);
+        border: 2px solid rgba(0, 255, 199, 0.5);
+        color: white;
+        font-family: monospace;
+        z-index: 1000;
+    `;
+
+    const prevBtn = document.createElement('button');
+    prevBtn.innerHTML = '◀';
+    const nextBtn = document.createElement('button');
+    nextBtn.innerHTML = '▶';
+    for (const btn of [prevBtn, nextBtn]) {
+        btn.style.cssText = 'background: none; border: none; color: white; font-size: 18px; cursor: pointer;';
+    }
+    const label = document.createElement('span');
+
+    let index = -1;
+    const follow = (step: number) => {
+        const ids = scene.getPlayerIds();
+        if (ids.length === 0) return;
+        index = (index + step + ids.length) % ids.length;
+        const spriteId = ids[index];
+        scene.setFollowPlayer(spriteId);
+
+        // Show the username of the followed player when known
+        import('./connection.ts').then(({getGameState}) => {
+            const player = (getGameState().playersList ?? []).find((p: any) => p.spriteType === spriteId);
+            label.textContent = `👁 Volgt: ${player?.username ?? spriteId}`;
+        });
+    };
+
+    prevBtn.addEventListener('click', () => follow(-1));
+    nextBtn.addEventListener('click', () => follow(1));
+    canvas.addEventListener('keydown', (e) => {
+        if (e.key === 'Tab') {
+            e.preventDefault();
+            follow(e.shiftKey ? -1 : 1);
+        }
+    });
+    canvas.tabIndex = 1;
+    canvas.focus();
+
+    bar.append(prevBtn, label, nextBtn);
+    document.body.appendChild(bar);
+    follow(1);
+}
+
+/**
+ * Add pause/play, seek and speed controls for replay playback
+ */
+function addReplayControls() {
+    const bar = document.createElement('div');
+    bar.id = 'replay-controls';
+    bar.style.cssText = `
+        position: fixed;
+        bottom: 20px;
+        left: 50%;
+        transform: translateX(-50%);
+        display: flex;
+        align-items: center;
+        gap: 12px;
+        padding: 10px 16px;
+        border-radius: 12px;
+        background: rgba(0, 0, 0, 0.7);
+        border: 2px solid rgba(0, 255, 199, 0.5);
+        color: white;
+        font-family: monospace;
+        z-index: 1000;
+    `;
+
+    const playBtn = document.createElement('button');
+    playBtn.innerHTML = '⏸';
+    playBtn.title = 'Pauze / Afspelen';
+    playBtn.style.cssText = 'background: none; border: none; color: white; font-size: 22px; cursor: pointer;';
+
+    const seek = document.createElement('input');
+    seek.type = 'range';
+    seek.min = '0';
+    seek.max = '0';
+    seek.value = '0';
+    seek.style.width = '320px';
+
+    const time = document.createElement('span');
+    time.textContent = '0:00 / 0:00';
+
+    const speed = document.createElement('select');
+    for (const value of [0.5, 1, 2, 4]) {
+        const option = document.createElement('option');
+        option.value = String(value);
+        option.textContent = `${value}x`;
+        option.selected = value === 1;
+        speed.appendChild(option);
+    }
+
+    const formatMs = (ms: number) => {
+        const seconds = Math.floor(ms / 1000);
+        return `${Math.floor(seconds / 60)}:${String(seconds % 60).padStart(2, '0')}`;
+    };
+
+    // The server reports the position on every change, in between it moves with the clock
+    let paused = false;
+    let atMs = 0;
+    let durationMs = 0;
+    let playbackSpeed = 1;
+    let reportedAt = performance.now();
+    let dragging = false;
+
+    const position = () => paused ? atMs : Math.min(durationMs, atMs + (performance.now() - reportedAt) * playbackSpeed);
+    const render = () => {
+        const now = position();
+        if (!dragging) {
+            seek.value = String(now);
+        }
+        time.textContent = `${formatMs(now)} / ${formatMs(durationMs)}`;
+    };
+    setInterval(render, 250);
+
+    subscribeGameEvents({
+        onReplayStatus: (status) => {
+            paused = status.paused || status.ended;
+            atMs = status.atMs;
+            durationMs = status.durationMs;
+            playbackSpeed = status.speed;
+            reportedAt = performance.now();
+            seek.max = String(durationMs);
+            playBtn.innerHTML = paused ? '▶' : '⏸';
+            render();
+        }
+    });
+
+    import('./connection.ts').then(({sendReplayControl}) => {
+        playBtn.addEventListener('click', () => sendReplayControl(paused ? 'play' : 'pause'));
+        speed.addEventListener('change', () => sendReplayControl('speed', Number(speed.value)));
+        seek.addEventListener('input', () => { dragging = true; });
+        seek.addEventListener('change', () => {
+            dragging = false;
+            const target = Number(seek.value);
+            if (target >= position()) {
+                sendReplayControl('seek', target);
+                return;
+            }
+            // The scene cannot undo events, going back loads the replay again from there
+            const params = new URLSearchParams(window.location.search);
+            params.set('t', String(target));
+            window.location.search = params.toString();
+        });
+    });
+
+    bar.append(playBtn, seek, time, speed);
+    document.body.appendChild(bar);
+}
+
 /**
  * Show game over screen
  */
-function showGameOver(winner: string, scores: Record<string, number>) {
+function showGameOver(winner: string, scores: Record<string, number>, names: Record<string, string> = {}, level: number = 0, lives: number = 0) {
     const spriteNames: Record<string, string> = {
         'runner': '🟡 Runner',
         'ch0': '🔴 Chaser 1',
@@ -573,13 +798,13 @@ function showGameOver(winner: string, scores: Record<string, number>) {
     const sortedScores = Object.entries(scores)
         .sort(([, a], [, b]) => b - a)
         .map(([name, score], index) => {
-            const displayName = spriteNames[name] || name;
+            const displayName = names[name] || spriteNames[name] || name;
             const medal = index === 0 ? '🥇' : index === 1 ? '🥈' : index === 2 ? '🥉' : '';
             return `<div class="score-row">${medal} ${displayName}: <span class="score-value">${score}</span></div>`;
         })
         .join('');
     
-    const winnerDisplay = spriteNames[winner] || winner;
+    const winnerDisplay = names[winner] || spriteNames[winner] || winner;
     
     overlay.innerHTML = `
         <style>
@@ -663,6 +888,7 @@ function showGameOver(winner: string, scores: Record<string, number>) {
         </style>
         <h1>🎮 Game Over!</h1>
         <div class="winner">🏆 Winnaar: ${winnerDisplay}</div>
+        ${level ? `<div class="score-row">Level ${level} · ❤️ ${lives}</div>` : ''}
         <div class="scores-container">
             ${sortedScores || '<div class="score-row">Geen scores beschikbaar</div>'}
         </div>
@@ -715,18 +941,59 @@ function updateScoreDisplay(scores: Record<string, number>) {
             .join('');
 }
 
+/**
+ * Lives and level state, classic mode only
+ */
+let currentLevel = 0;
+let currentLives = 0;
+
+/**
+ * Show the level and the runner's lives
+ */
+function updateProgressDisplay(level: number, lives: number) {
+    currentLevel = level;
+    currentLives = lives;
+    
+    let progressDiv = document.getElementById('progress-display');
+    if (!progressDiv) {
+        progressDiv = document.createElement('div');
+        progressDiv.id = 'progress-display';
+        progressDiv.style.cssText = `
+            position: fixed;
+            top: 90px;
+            right: 20px;
+            background: rgba(0, 0, 0, 0.7);
+            padding: 10px 20px;
+            border-radius: 8px;
+            color: white;
+            font-family: 'Courier New', monospace;
+            font-size: 18px;
+            z-index: 1000;
+        `;
+        document.body.appendChild(progressDiv);
+    }
+    
+    progressDiv.textContent = `Level ${level} ${'❤️'.repeat(lives)}`;
+}
+
 /**
  * Game timer state
  */
-let gameStartTime: number = 0;
+let gameEndTime: number = 0;
+let gameSuddenDeath = false;
 let gameTimerInterval: ReturnType<typeof setInterval> | null = null;
-const GAME_DURATION_SECONDS = 180; // 3 minutes
 
 /**
  * Add and start game timer display
  */
 function startGameTimer() {
-    gameStartTime = Date.now();
+    // The host's round duration until the server sends its timer
+    const durationSeconds = getLobbyState().roundDuration;
+    if (durationSeconds <= 0) {
+        return;
+    }
+    gameEndTime = Date.now() + durationSeconds * 1000;
+    gameSuddenDeath = false;
     
     let timerDiv = document.getElementById('game-timer');
     if (!timerDiv) {
@@ -756,14 +1023,13 @@ function startGameTimer() {
     
     // Update timer every 100ms for smooth display
     gameTimerInterval = setInterval(() => {
-        const elapsed = Math.floor((Date.now() - gameStartTime) / 1000);
-        const remaining = Math.max(0, GAME_DURATION_SECONDS - elapsed);
+        const remaining = Math.max(0, Math.ceil((gameEndTime - Date.now()) / 1000));
         
         const minutes = Math.floor(remaining / 60);
         const seconds = remaining % 60;
         
         if (timerDiv) {
-            timerDiv.textContent = `${minutes}:${seconds.toString().padStart(2, '0')}`;
+            timerDiv.textContent = `${gameSuddenDeath ? '☠️ ' : ''}${minutes}:${seconds.toString().padStart(2, '0')}`;
             
             // Change color when low on time
             if (remaining <= 30) {
@@ -777,6 +1043,14 @@ function startGameTimer() {
     }, 100);
 }
 
+/**
+ * Sync the game timer to the remaining time the server sent
+ */
+function syncGameTimer(remainingMs: number, suddenDeath: boolean) {
+    gameEndTime = Date.now() + remainingMs;
+    gameSuddenDeath = suddenDeath;
+}
+
 /**
  * Stop and hide game timer
  */
@@ -1127,7 +1401,7 @@ function showEntityWarning(entityId: string) {
 /**
  * Show caught by entity screen
  */
-function showCaughtByEntity(entityType: string) {
+function showCaughtByEntity(entityType: string, stunMs: number) {
     const entityNames: Record<string, string> = {
         hunter: 'Hunter',
         scanner: 'Scanner', 
@@ -1140,6 +1414,7 @@ function showCaughtByEntity(entityType: string) {
         sweeper: '#aa33ff'
     };
     
+    document.getElementById('caught-overlay')?.remove();
     const overlay = document.createElement('div');
     overlay.id = 'caught-overlay';
     overlay.innerHTML = `
@@ -1175,9 +1450,15 @@ function showCaughtByEntity(entityType: string) {
         </style>
         <h1>💀 GEVANGEN!</h1>
         <div class="entity-name">Door een ${entityNames[entityType] || entityType}</div>
+        ${stunMs > 0 ? `<div class="entity-name">Verlamd voor ${Math.ceil(stunMs / 1000)}s</div>` : ''}
     `;
     
     document.body.appendChild(overlay);
+
+    // A stun only holds the player for a moment
+    if (stunMs > 0) {
+        setTimeout(() => overlay.remove(), stunMs);
+    }
 }
 
 /**
@@ -5,6 +5,10 @@ import {
     subscribeLobbyState, 
     sendReadyToggle, 
     sendStartGame,
+    sendBotDifficulty,
+    sendRoundSettings,
+    sendLives,
+    type BotDifficulty,
     type LobbyState 
 } from './connection';
 
@@ -36,9 +40,14 @@ function WaitingRoomWrapper() {
         isHost: false,
         isReady: false,
         isSpectator: false,
+        spectatorCount: 0,
         playerCount: 0,
         readyCount: 0,
-        countdown: null
+        countdown: null,
+        botDifficulty: 'normal',
+        roundDuration: 180,
+        suddenDeath: false,
+        lives: 3
     });
 
     createEffect(() => {
@@ -59,6 +68,18 @@ function WaitingRoomWrapper() {
         sendStartGame();
     };
 
+    const handleBotDifficulty = (difficulty: BotDifficulty) => {
+        sendBotDifficulty(difficulty);
+    };
+
+    const handleRoundSettings = (durationSec: number, suddenDeath: boolean) => {
+        sendRoundSettings(durationSec, suddenDeath);
+    };
+
+    const handleLives = (lives: number) => {
+        sendLives(lives);
+    };
+
     const handleLeave = () => {
         window.location.href = '/';
     };
@@ -97,8 +118,15 @@ function WaitingRoomWrapper() {
                 playerCount={lobbyState().playerCount}
                 readyCount={lobbyState().readyCount}
                 countdown={lobbyState().countdown}
+                botDifficulty={lobbyState().botDifficulty}
+                roundDuration={lobbyState().roundDuration}
+                suddenDeath={lobbyState().suddenDeath}
+                lives={lobbyState().lives}
                 onToggleReady={handleToggleReady}
                 onStartGame={handleStartGame}
+                onBotDifficulty={handleBotDifficulty}
+                onRoundSettings={handleRoundSettings}
+                onLives={handleLives}
                 onLeave={handleLeave}
             />
         </Show>
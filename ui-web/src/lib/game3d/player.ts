@@ -308,6 +308,26 @@ export class Player3D {
         }
     }
 
+    /**
+     * Set eyes state (for eaten chasers on their way back to the house)
+     */
+    setEyes(eyes: boolean): void {
+        if (this.spriteType === 'runner') return;
+
+        if (eyes) {
+            // Only a faint white shell is left
+            this.material.alpha = 0.25;
+            this.material.diffuseColor = new Color3(1, 1, 1);
+            this.material.emissiveColor = new Color3(0.4, 0.4, 0.4);
+        } else {
+            this.material.alpha = 1;
+            if (!this.isScared) {
+                this.material.diffuseColor = this.originalColor;
+                this.material.emissiveColor = this.originalEmissive;
+            }
+        }
+    }
+
     /**
      * Get current world position
      */
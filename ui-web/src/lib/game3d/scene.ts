@@ -5,7 +5,7 @@
 import { GameEngine } from './engine';
 import { Maze3D, TileType, type MazeConfig } from './maze';
 import { Player3D, type SpriteType3D } from './player';
-import { loadTiledMap, gameToWorld3D, SPAWN_POSITIONS } from './tilemap-loader';
+import { loadTiledMap, parseServerMap, gameToWorld3D, SPAWN_POSITIONS, type MazeConfig3D, type ServerMap } from './tilemap-loader';
 import { ParticleManager } from './particles';
 import { EntityRenderer } from './entities';
 import { ZoneRenderer, type TimePhase } from './zones';
@@ -78,7 +78,17 @@ export class Game3DScene {
      * Load the real game map from Tiled JSON
      */
     async loadRealMap(mapUrl: string = '/gassets/map.json'): Promise<void> {
-        const config = await loadTiledMap(mapUrl);
+        this.loadMap(await loadTiledMap(mapUrl));
+    }
+
+    /**
+     * Load the map the server plays the match on
+     */
+    loadServerMap(serverMap: ServerMap): void {
+        this.loadMap(parseServerMap(serverMap));
+    }
+
+    private loadMap(config: MazeConfig3D): void {
         this.mazeWidth = config.width;
         this.mazeHeight = config.height;
         this.mazeConfig = config;
@@ -126,6 +136,13 @@ export class Game3DScene {
         this.followPlayerId = playerId;
     }
 
+    /**
+     * Ids of the players in the scene, spectators cycle the camera through them
+     */
+    getPlayerIds(): string[] {
+        return Array.from(this.players.keys());
+    }
+
     /**
      * Initialize with a demo maze (for testing)
      */
@@ -224,6 +241,18 @@ export class Game3DScene {
         }
     }
 
+    /**
+     * Put the pellets and power-ups of a new level back in the maze
+     */
+    restoreMaze(serverMap: ServerMap): void {
+        const config = parseServerMap(serverMap);
+        this.mazeConfig = config;
+        this.buildMaze(config);
+        if (this.minimap) {
+            this.minimap.setMaze(config);
+        }
+    }
+
     /**
      * Remove a pellet
      */
@@ -283,9 +312,19 @@ export class Game3DScene {
         if (runner) {
             runner.setPoweredUp(powered);
         }
-        
-        // Set chasers to scared mode
-        this.setChasersScared(powered);
+    }
+
+    /**
+     * Show a chaser's phase: frightened, eyes on the way to the house, or
+     * back to normal in the house and once active
+     */
+    setChaserState(spriteId: string, state: string): void {
+        const chaser = this.players.get(spriteId);
+        if (!chaser) return;
+
+        chaser.setVisible(true);
+        chaser.setScared(state === 'frightened');
+        chaser.setEyes(state === 'eyes');
     }
 
     /**
@@ -346,6 +385,11 @@ export class Game3DScene {
         if (state.entities) {
             this.entityRenderer.updateEntities(state.entities);
         }
+        
+        // Catch up on maze updates made before we joined
+        for (const update of state.mazeUpdates ?? []) {
+            this.dynamicMaze.handleMazeUpdate({ ...update, duration: 0 });
+        }
     }
 
     /**
@@ -101,6 +101,26 @@ export async function loadTiledMap(url: string): Promise<MazeConfig3D> {
     return parseTiledMap(tiledMap);
 }
 
+// Map as sent by the server in the state message, one character per tile
+export interface ServerMap {
+    name: string;
+    width: number;
+    height: number;
+    tiles: string[];
+}
+
+/**
+ * Parse the server map into MazeConfig for 3D rendering
+ * '#' wall, '.' pellet, 'o' power-up, anything else is floor
+ */
+export function parseServerMap(serverMap: ServerMap): MazeConfig3D {
+    const tileTypes: Record<string, number> = {'#': 1, '.': 2, 'o': 3};
+    const tiles = serverMap.tiles.map((row) =>
+        Array.from(row, (tile) => tileTypes[tile] ?? 0) // TileType.FLOOR
+    );
+    return { width: serverMap.width, height: serverMap.height, tiles };
+}
+
 /**
  * Convert world coordinates to tile coordinates
  * Used for syncing with game logic
@@ -0,0 +1,1824 @@
+// @generated by protoc-gen-es v2.10.2 with parameter "target=ts"
+// @generated from file game/v1/game.proto (package game.v1, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file game/v1/game.proto.
+ */
+export const file_game_v1_game: GenFile = /*@__PURE__*/
+  fileDesc("ChJnYW1lL3YxL2dhbWUucHJvdG8SB2dhbWUudjEi2wsKCEVudmVsb3BlEg8KB3ZlcnNpb24YASABKA0SCwoDc2VxGAIgASgEEgoKAnRzGAMgASgDEh8KBXN0YXRlGAogASgLMg4uZ2FtZS52MS5TdGF0ZUgAEiUKCHNuYXBzaG90GAsgASgLMhEuZ2FtZS52MS5TbmFwc2hvdEgAEiQKA3BvcxgMIAEoCzIVLmdhbWUudjEuUGxheWVyVXBkYXRlSAASJwoGYWN0aXZlGA0gASgLMhUuZ2FtZS52MS5QbGF5ZXJVcGRhdGVIABIkCgNkaXMYDiABKAsyFS5nYW1lLnYxLlBsYXllclVwZGF0ZUgAEh4KA3BlbBgPIAEoCzIPLmdhbWUudjEuUGVsbGV0SAASHwoDcG93GBAgASgLMhAuZ2FtZS52MS5Qb3dlclVwSAASJQoGcG93ZW5kGBEgASgLMhMuZ2FtZS52MS5Qb3dlclVwRW5kSAASHQoEa2lsbBgSIAEoCzINLmdhbWUudjEuS2lsbEgAEikKCmVsaW1pbmF0ZWQYEyABKAsyEy5nYW1lLnYxLkVsaW1pbmF0ZWRIABItCgxyZWNvbm5lY3RpbmcYFCABKAsyFS5nYW1lLnYxLlJlY29ubmVjdGluZ0gAEiMKB3Jlc3VtZWQYFSABKAsyEC5nYW1lLnYxLlJlc3VtZWRIABIrCgtsb2JieXN0YXR1cxgWIAEoCzIULmdhbWUudjEuTG9iYnlTdGF0dXNIABInCgljb3VudGRvd24YFyABKAsyEi5nYW1lLnYxLkNvdW50ZG93bkgAEjUKEGNvdW50ZG93bnN0YXJ0ZWQYGCABKAsyGS5nYW1lLnYxLkNvdW50ZG93blN0YXJ0ZWRIABInCglnYW1lc3RhcnQYGSABKAsyEi5nYW1lLnYxLkdhbWVTdGFydEgAEiUKCGdhbWVvdmVyGBogASgLMhEuZ2FtZS52MS5HYW1lT3ZlckgAEiYKBWVycm9yGBsgASgLMhUuZ2FtZS52MS5FcnJvck1lc3NhZ2VIABIsCgxwaGFzZV91cGRhdGUYHCABKAsyFC5nYW1lLnYxLlBoYXNlVXBkYXRlSAASLAoMcGhhc2VfY2hhbmdlGB0gASgLMhQuZ2FtZS52MS5QaGFzZUNoYW5nZUgAEioKC21hemVfdXBkYXRlGB4gASgLMhMuZ2FtZS52MS5NYXplVXBkYXRlSAASMgoPZW50aXRpZXNfdXBkYXRlGB8gASgLMhcuZ2FtZS52MS5FbnRpdGllc1VwZGF0ZUgAEioKC2VudGl0eV9uZWFyGCAgASgLMhMuZ2FtZS52MS5FbnRpdHlOZWFySAASNAoQZW50aXR5X2NvbGxpc2lvbhghIAEoCzIYLmdhbWUudjEuRW50aXR5Q29sbGlzaW9uSAASKAoKem9uZV9xdWVyeRgiIAEoCzISLmdhbWUudjEuWm9uZVF1ZXJ5SAASLgoNZHluYW1pY19zdGF0ZRgjIAEoCzIVLmdhbWUudjEuRHluYW1pY1N0YXRlSAASHQoEY2hhdBgkIAEoCzINLmdhbWUudjEuQ2hhdEgAEikKCnJlcGxheWluZm8YJSABKAsyEy5nYW1lLnYxLlJlcGxheUluZm9IABItCgxyZXBsYXlzdGF0dXMYJiABKAsyFS5nYW1lLnYxLlJlcGxheVN0YXR1c0gAEh8KBXRpbWVyGCcgASgLMg4uZ2FtZS52MS5UaW1lckgAEisKC3N1ZGRlbmRlYXRoGCggASgLMhQuZ2FtZS52MS5TdWRkZW5EZWF0aEgAEiYKBmNoYXNlchgpIAEoCzIULmdhbWUudjEuQ2hhc2VyU3RhdGVIABIlCghsaWZlbG9zdBgqIAEoCzIRLmdhbWUudjEuTGlmZUxvc3RIABIjCgdyZXNwYXduGCsgASgLMhAuZ2FtZS52MS5SZXNwYXduSAASHwoFbGV2ZWwYLCABKAsyDi5nYW1lLnYxLkxldmVsSABCCQoHcGF5bG9hZCI9CghTbmFwc2hvdBIMCgR0aWNrGAEgASgEEiMKCG1lc3NhZ2VzGAIgAygLMhEuZ2FtZS52MS5FbnZlbG9wZSIdCgVQb2ludBIJCgF4GAEgASgBEgkKAXkYAiABKAEiHwoHVGlsZVBvcxIJCgF4GAEgASgFEgkKAXkYAiABKAUipQIKDFBsYXllclVwZGF0ZRIQCghwbGF5ZXJpZBgBIAEoCRIMCgR1c2VyGAIgASgJEhMKC3Nwcml0ZV90eXBlGAMgASgJEgkKAXgYBCABKAESCQoBeRgFIAEoARILCgNkaXIYBiABKAkSEAoIaXNfcmVhZHkYByABKAgSDwoHaXNfaG9zdBgIIAEoCBIUCgxpc19zcGVjdGF0b3IYCSABKAgSIAoGcGVsbGV0GAogASgLMhAuZ2FtZS52MS5UaWxlUG9zEiIKCHBvd2VyX3VwGAsgASgLMhAuZ2FtZS52MS5UaWxlUG9zEhQKB3Bvd2VyZWQYDCABKAhIAIgBARISCgVzY29yZRgNIAEoBUgBiAEBQgoKCF9wb3dlcmVkQggKBl9zY29yZSJACgZQZWxsZXQSCQoBeBgBIAEoBRIJCgF5GAIgASgFEhEKCXBsYXllcl9pZBgDIAEoCRINCgVzY29yZRgEIAEoBSJECgdQb3dlclVwEhEKCXBsYXllcl9pZBgBIAEoCRIJCgF4GAIgASgBEgkKAXkYAyABKAESEAoIZHVyYXRpb24YBCABKAUiHwoKUG93ZXJVcEVuZBIRCglwbGF5ZXJfaWQYASABKAkiLAoES2lsbBIRCglzcHJpdGVfaWQYASABKAkSEQoJY2hhc2VyX2lkGAIgASgJIjoKCkVsaW1pbmF0ZWQSEQoJcGxheWVyX2lkGAEgASgJEgoKAmJ5GAIgASgJEg0KBXNjb3JlGAMgASgFIkkKDFJlY29ubmVjdGluZxIRCglwbGF5ZXJfaWQYASABKAkSEwoLc3ByaXRlX3R5cGUYAiABKAkSEQoJZ3JhY2Vfc2VjGAMgASgFIjEKB1Jlc3VtZWQSEQoJcGxheWVyX2lkGAEgASgJEhMKC3Nwcml0ZV90eXBlGAIgASgJImoKC0xvYmJ5UGxheWVyEhEKCXBsYXllcl9pZBgBIAEoCRIQCgh1c2VybmFtZRgCIAEoCRITCgtzcHJpdGVfdHlwZRgDIAEoCRIQCghpc19yZWFkeRgEIAEoCBIPCgdpc19ob3N0GAUgASgIIp8CCgtMb2JieVN0YXR1cxIlCgdwbGF5ZXJzGAEgAygLMhQuZ2FtZS52MS5Mb2JieVBsYXllchIoCgpzcGVjdGF0b3JzGAIgAygLMhQuZ2FtZS52MS5Mb2JieVBsYXllchIXCg9zcGVjdGF0b3JfY291bnQYAyABKAUSFAoMcGxheWVyX2NvdW50GAQgASgFEhMKC3JlYWR5X2NvdW50GAUgASgFEhUKDW1hdGNoX3N0YXJ0ZWQYBiABKAgSDwoHaG9zdF9pZBgHIAEoCRIWCg5ib3RfZGlmZmljdWx0eRgIIAEoCRIWCg5yb3VuZF9kdXJhdGlvbhgJIAEoBRIUCgxzdWRkZW5fZGVhdGgYCiABKAgSDQoFbGl2ZXMYCyABKAUiGgoJQ291bnRkb3duEg0KBWNvdW50GAEgASgFIhIKEENvdW50ZG93blN0YXJ0ZWQidwoJR2FtZVN0YXJ0EgwKBG1vZGUYASABKAkSLAoNZHluYW1pY19zdGF0ZRgCIAEoCzIVLmdhbWUudjEuRHluYW1pY1N0YXRlEhsKDnJvdW5kX2R1cmF0aW9uGAMgASgFSACIAQFCEQoPX3JvdW5kX2R1cmF0aW9uIkgKBVRpbWVyEhQKDHJlbWFpbmluZ19tcxgBIAEoDRITCgtkdXJhdGlvbl9tcxgCIAEoDRIUCgxzdWRkZW5fZGVhdGgYAyABKAgiRgoLU3VkZGVuRGVhdGgSIQoJcG93ZXJfdXBzGAEgAygLMg4uZ2FtZS52MS5Qb2ludBIUCgxzcGVlZF9mYWN0b3IYAiABKAEiOwoLQ2hhc2VyU3RhdGUSEQoJc3ByaXRlX2lkGAEgASgJEg0KBXN0YXRlGAIgASgJEgoKAm1zGAMgASgNIiwKCExpZmVMb3N0Eg0KBWxpdmVzGAEgASgFEhEKCWZyZWV6ZV9tcxgCIAEoDSJ/CgdSZXNwYXduEjIKCXBvc2l0aW9ucxgBIAMoCzIfLmdhbWUudjEuUmVzcGF3bi5Qb3NpdGlvbnNFbnRyeRpACg5Qb3NpdGlvbnNFbnRyeRILCgNrZXkYASABKAkSHQoFdmFsdWUYAiABKAsyDi5nYW1lLnYxLlBvaW50OgI4ASJhCgVMZXZlbBINCgVsZXZlbBgBIAEoBRIbChNjaGFzZXJfc3BlZWRfZmFjdG9yGAIgASgBEhkKEXBvd2VyX3VwX2R1cmF0aW9uGAMgASgFEhEKCWZyZWV6ZV9tcxgEIAEoDSKBAgoIR2FtZU92ZXISDgoGcmVhc29uGAEgASgJEg4KBndpbm5lchgCIAEoCRItCgZzY29yZXMYAyADKAsyHS5nYW1lLnYxLkdhbWVPdmVyLlNjb3Jlc0VudHJ5Eg0KBWxldmVsGAQgASgFEg0KBWxpdmVzGAUgASgFEisKBW5hbWVzGAYgAygLMhwuZ2FtZS52MS5HYW1lT3Zlci5OYW1lc0VudHJ5Gi0KC1Njb3Jlc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoBToCOAEaLAoKTmFtZXNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIh0KDEVycm9yTWVzc2FnZRINCgVlcnJvchgBIAEoCSI2CgxBY3RpdmVQbGF5ZXISEAoIdXNlcm5hbWUYASABKAkSCQoBeBgCIAEoARIJCgF5GAMgASgBIkIKBlR1bm5lbBIbCgFhGAEgASgLMhAuZ2FtZS52MS5UaWxlUG9zEhsKAWIYAiABKAsyEC5nYW1lLnYxLlRpbGVQb3MijAEKB01hcEluZm8SDAoEbmFtZRgBIAEoCRINCgV3aWR0aBgCIAEoBRIOCgZoZWlnaHQYAyABKAUSDQoFdGlsZXMYBCADKAkSIAoHdHVubmVscxgFIAMoCzIPLmdhbWUudjEuVHVubmVsEhUKDXRvdGFsX3BlbGxldHMYBiABKAUSDAoEc2VlZBgHIAEoAyK2CAoFU3RhdGUSGAoQcHJvdG9jb2xfdmVyc2lvbhgBIAEoDRIMCgRtb2RlGAIgASgJEhUKDWNoYXNlcnNfZWF0ZW4YAyADKAkSEgoKZWxpbWluYXRlZBgEIAMoCRI5Cg5hY3RpdmVfcGxheWVycxgFIAMoCzIhLmdhbWUudjEuU3RhdGUuQWN0aXZlUGxheWVyc0VudHJ5EioKDHBsYXllcnNfbGlzdBgGIAMoCzIULmdhbWUudjEuTG9iYnlQbGF5ZXISJQoNcGVsbGV0c19lYXRlbhgHIAMoCzIOLmdhbWUudjEuUG9pbnQSJwoPcG93ZXJfdXBzX2VhdGVuGAggAygLMg4uZ2FtZS52MS5Qb2ludBIUCgxzZWNyZXRfdG9rZW4YCSABKAkSEQoJc3ByaXRlX2lkGAogASgJEhMKC3Nwcml0ZV90eXBlGAsgASgJEhAKCHVzZXJuYW1lGAwgASgJEhEKCXBsYXllcl9pZBgNIAEoCRIVCg1tYXRjaF9zdGFydGVkGA4gASgIEg8KB2hvc3RfaWQYDyABKAkSDwoHaXNfaG9zdBgQIAEoCBIUCgxwbGF5ZXJfY291bnQYESABKAUSEwoLcmVhZHlfY291bnQYEiABKAUSKgoGc2NvcmVzGBMgAygLMhouZ2FtZS52MS5TdGF0ZS5TY29yZXNFbnRyeRI7Cg9zcGF3bl9wb3NpdGlvbnMYFCADKAsyIi5nYW1lLnYxLlN0YXRlLlNwYXduUG9zaXRpb25zRW50cnkSHQoDbWFwGBUgASgLMhAuZ2FtZS52MS5NYXBJbmZvEhQKDGlzX3NwZWN0YXRvchgWIAEoCBIXCg9zcGVjdGF0b3JfY291bnQYFyABKAUSDgoGcmVwbGF5GBggASgIEhkKDHJlc3VtZV90b2tlbhgZIAEoCUgAiAEBEg4KAXgYGiABKAFIAYgBARIOCgF5GBsgASgBSAKIAQESLAoHY2hhc2VycxgcIAMoCzIbLmdhbWUudjEuU3RhdGUuQ2hhc2Vyc0VudHJ5Eg0KBWxldmVsGB0gASgFEg0KBWxpdmVzGB4gASgFGksKEkFjdGl2ZVBsYXllcnNFbnRyeRILCgNrZXkYASABKAkSJAoFdmFsdWUYAiABKAsyFS5nYW1lLnYxLkFjdGl2ZVBsYXllcjoCOAEaLQoLU2NvcmVzRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgFOgI4ARpFChNTcGF3blBvc2l0aW9uc0VudHJ5EgsKA2tleRgBIAEoCRIdCgV2YWx1ZRgCIAEoCzIOLmdhbWUudjEuUG9pbnQ6AjgBGi4KDENoYXNlcnNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBQg8KDV9yZXN1bWVfdG9rZW5CBAoCX3hCBAoCX3kiaAoEWm9uZRIKCgJpZBgBIAEoBRIMCgR0eXBlGAIgASgJEgkKAXgYAyABKAUSCQoBeRgEIAEoBRINCgV3aWR0aBgFIAEoBRIOCgZoZWlnaHQYBiABKAUSEQoJaXNfYWN0aXZlGAcgASgIIi4KC1BoYXNlVXBkYXRlEg0KBXBoYXNlGAEgASgJEhAKCHByb2dyZXNzGAIgASgBIj4KC1BoYXNlQ2hhbmdlEhEKCW5ld19waGFzZRgBIAEoCRIcCgV6b25lcxgCIAMoCzINLmdhbWUudjEuWm9uZSKwAQoKTWF6ZVVwZGF0ZRIMCgR0eXBlGAEgASgJEgkKAXgYAiABKAUSCQoBeRgDIAEoBRIVCgh0YXJnZXRfeBgEIAEoBUgAiAEBEhUKCHRhcmdldF95GAUgASgFSAGIAQESEAoIZHVyYXRpb24YBiABKAUSFgoJcmV2ZXJ0X2luGAcgASgFSAKIAQFCCwoJX3RhcmdldF94QgsKCV90YXJnZXRfeUIMCgpfcmV2ZXJ0X2luIsoBCgZFbnRpdHkSCgoCaWQYASABKAkSDAoEdHlwZRgCIAEoCRINCgVzdGF0ZRgDIAEoCRIJCgF4GAQgASgBEgkKAXkYBSABKAESCwoDZGlyGAYgASgJEgwKBGdsb3cYByABKAESEgoKZ2xvd19jb2xvchgIIAEoCRINCgVhbGVydBgJIAEoARIWCg5zY2FuX2RpcmVjdGlvbhgKIAEoARISCgpzY2FuX2FuZ2xlGAsgASgBEhcKD2RldGVjdGlvbl9yYW5nZRgMIAEoASJWCg5FbnRpdGllc1VwZGF0ZRIhCghlbnRpdGllcxgBIAMoCzIPLmdhbWUudjEuRW50aXR5EhAKCGJhc2VsaW5lGAIgASgEEg8KB3JlbW92ZWQYAyADKAkiQwoKRW50aXR5TmVhchIRCgllbnRpdHlfaWQYASABKAkSDwoHd2FybmluZxgCIAEoCBIRCglwbGF5ZXJfaWQYAyABKAkifgoPRW50aXR5Q29sbGlzaW9uEhEKCWVudGl0eV9pZBgBIAEoCRITCgtlbnRpdHlfdHlwZRgCIAEoCRIOCgZjYXVnaHQYAyABKAgSEQoJcGxheWVyX2lkGAQgASgJEg8KB291dGNvbWUYBSABKAkSDwoHc3R1bl9tcxgGIAEoDSIoCglab25lUXVlcnkSGwoEem9uZRgBIAEoCzINLmdhbWUudjEuWm9uZSJLCgpab25lc1N0YXRlEhwKBXpvbmVzGAEgAygLMg0uZ2FtZS52MS5ab25lEg0KBXBoYXNlGAIgASgJEhAKCHByb2dyZXNzGAMgASgBIoABCgxEeW5hbWljU3RhdGUSIgoFem9uZXMYASABKAsyEy5nYW1lLnYxLlpvbmVzU3RhdGUSIQoIZW50aXRpZXMYAiADKAsyDy5nYW1lLnYxLkVudGl0eRIpCgxtYXplX3VwZGF0ZXMYAyADKAsyEy5nYW1lLnYxLk1hemVVcGRhdGUiTwoEQ2hhdBIRCglwbGF5ZXJfaWQYASABKAkSEAoIdXNlcm5hbWUYAiABKAkSDwoHbWVzc2FnZRgDIAEoCRIRCgl0aW1lc3RhbXAYBCABKAMiVQoKUmVwbGF5SW5mbxIQCghtYXRjaF9pZBgBIAEoDRIMCgRtb2RlGAIgASgJEhIKCnN0YXJ0ZWRfYXQYAyABKAkSEwoLZHVyYXRpb25fbXMYBCABKA0iYAoMUmVwbGF5U3RhdHVzEg0KBWF0X21zGAEgASgNEhMKC2R1cmF0aW9uX21zGAIgASgNEg4KBnBhdXNlZBgDIAEoCBINCgVzcGVlZBgEIAEoARINCgVlbmRlZBgFIAEoCEKHAQoLY29tLmdhbWUudjFCCUdhbWVQcm90b1ABWjBnaXRodWIuY29tL2ZyYW5rMjg4OS9tYXplY2hhc2UvZ2VuZXJhdGVkL2dhbWUvdjGiAgNHWFiqAgdHYW1lLlYxygIHR2FtZVxWMeICE0dhbWVcVjFcR1BCTWV0YWRhdGHqAghHYW1lOjpWMWIGcHJvdG8z");
+
+/**
+ * Envelope wraps every message the server sends on the game WebSocket. The
+ * type of a message is the name of its payload field. On the JSON wire the
+ * payload goes under "payload" next to "v", "type", "seq" and "ts", see
+ * docs/ws-protocol.md.
+ *
+ * @generated from message game.v1.Envelope
+ */
+export type Envelope = Message<"game.v1.Envelope"> & {
+  /**
+   * protocol version, clients pick one with ?v= when they connect
+   *
+   * @generated from field: uint32 version = 1;
+   */
+  version: number;
+
+  /**
+   * increases by one per message of a match; 0 outside a match and for the
+   * messages inside a snapshot
+   *
+   * @generated from field: uint64 seq = 2;
+   */
+  seq: bigint;
+
+  /**
+   * server time in ms since the epoch; 0 inside a snapshot
+   *
+   * @generated from field: int64 ts = 3;
+   */
+  ts: bigint;
+
+  /**
+   * @generated from oneof game.v1.Envelope.payload
+   */
+  payload: {
+    /**
+     * @generated from field: game.v1.State state = 10;
+     */
+    value: State;
+    case: "state";
+  } | {
+    /**
+     * @generated from field: game.v1.Snapshot snapshot = 11;
+     */
+    value: Snapshot;
+    case: "snapshot";
+  } | {
+    /**
+     * @generated from field: game.v1.PlayerUpdate pos = 12;
+     */
+    value: PlayerUpdate;
+    case: "pos";
+  } | {
+    /**
+     * @generated from field: game.v1.PlayerUpdate active = 13;
+     */
+    value: PlayerUpdate;
+    case: "active";
+  } | {
+    /**
+     * @generated from field: game.v1.PlayerUpdate dis = 14;
+     */
+    value: PlayerUpdate;
+    case: "dis";
+  } | {
+    /**
+     * @generated from field: game.v1.Pellet pel = 15;
+     */
+    value: Pellet;
+    case: "pel";
+  } | {
+    /**
+     * @generated from field: game.v1.PowerUp pow = 16;
+     */
+    value: PowerUp;
+    case: "pow";
+  } | {
+    /**
+     * @generated from field: game.v1.PowerUpEnd powend = 17;
+     */
+    value: PowerUpEnd;
+    case: "powend";
+  } | {
+    /**
+     * @generated from field: game.v1.Kill kill = 18;
+     */
+    value: Kill;
+    case: "kill";
+  } | {
+    /**
+     * @generated from field: game.v1.Eliminated eliminated = 19;
+     */
+    value: Eliminated;
+    case: "eliminated";
+  } | {
+    /**
+     * @generated from field: game.v1.Reconnecting reconnecting = 20;
+     */
+    value: Reconnecting;
+    case: "reconnecting";
+  } | {
+    /**
+     * @generated from field: game.v1.Resumed resumed = 21;
+     */
+    value: Resumed;
+    case: "resumed";
+  } | {
+    /**
+     * @generated from field: game.v1.LobbyStatus lobbystatus = 22;
+     */
+    value: LobbyStatus;
+    case: "lobbystatus";
+  } | {
+    /**
+     * @generated from field: game.v1.Countdown countdown = 23;
+     */
+    value: Countdown;
+    case: "countdown";
+  } | {
+    /**
+     * @generated from field: game.v1.CountdownStarted countdownstarted = 24;
+     */
+    value: CountdownStarted;
+    case: "countdownstarted";
+  } | {
+    /**
+     * @generated from field: game.v1.GameStart gamestart = 25;
+     */
+    value: GameStart;
+    case: "gamestart";
+  } | {
+    /**
+     * @generated from field: game.v1.GameOver gameover = 26;
+     */
+    value: GameOver;
+    case: "gameover";
+  } | {
+    /**
+     * @generated from field: game.v1.ErrorMessage error = 27;
+     */
+    value: ErrorMessage;
+    case: "error";
+  } | {
+    /**
+     * @generated from field: game.v1.PhaseUpdate phase_update = 28;
+     */
+    value: PhaseUpdate;
+    case: "phaseUpdate";
+  } | {
+    /**
+     * @generated from field: game.v1.PhaseChange phase_change = 29;
+     */
+    value: PhaseChange;
+    case: "phaseChange";
+  } | {
+    /**
+     * @generated from field: game.v1.MazeUpdate maze_update = 30;
+     */
+    value: MazeUpdate;
+    case: "mazeUpdate";
+  } | {
+    /**
+     * @generated from field: game.v1.EntitiesUpdate entities_update = 31;
+     */
+    value: EntitiesUpdate;
+    case: "entitiesUpdate";
+  } | {
+    /**
+     * @generated from field: game.v1.EntityNear entity_near = 32;
+     */
+    value: EntityNear;
+    case: "entityNear";
+  } | {
+    /**
+     * @generated from field: game.v1.EntityCollision entity_collision = 33;
+     */
+    value: EntityCollision;
+    case: "entityCollision";
+  } | {
+    /**
+     * @generated from field: game.v1.ZoneQuery zone_query = 34;
+     */
+    value: ZoneQuery;
+    case: "zoneQuery";
+  } | {
+    /**
+     * @generated from field: game.v1.DynamicState dynamic_state = 35;
+     */
+    value: DynamicState;
+    case: "dynamicState";
+  } | {
+    /**
+     * @generated from field: game.v1.Chat chat = 36;
+     */
+    value: Chat;
+    case: "chat";
+  } | {
+    /**
+     * @generated from field: game.v1.ReplayInfo replayinfo = 37;
+     */
+    value: ReplayInfo;
+    case: "replayinfo";
+  } | {
+    /**
+     * @generated from field: game.v1.ReplayStatus replaystatus = 38;
+     */
+    value: ReplayStatus;
+    case: "replaystatus";
+  } | {
+    /**
+     * @generated from field: game.v1.Timer timer = 39;
+     */
+    value: Timer;
+    case: "timer";
+  } | {
+    /**
+     * @generated from field: game.v1.SuddenDeath suddendeath = 40;
+     */
+    value: SuddenDeath;
+    case: "suddendeath";
+  } | {
+    /**
+     * @generated from field: game.v1.ChaserState chaser = 41;
+     */
+    value: ChaserState;
+    case: "chaser";
+  } | {
+    /**
+     * @generated from field: game.v1.LifeLost lifelost = 42;
+     */
+    value: LifeLost;
+    case: "lifelost";
+  } | {
+    /**
+     * @generated from field: game.v1.Respawn respawn = 43;
+     */
+    value: Respawn;
+    case: "respawn";
+  } | {
+    /**
+     * @generated from field: game.v1.Level level = 44;
+     */
+    value: Level;
+    case: "level";
+  } | { case: undefined; value?: undefined };
+};
+
+/**
+ * Describes the message game.v1.Envelope.
+ * Use `create(EnvelopeSchema)` to create a new message.
+ */
+export const EnvelopeSchema: GenMessage<Envelope> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 0);
+
+/**
+ * Snapshot carries every event of one server tick in the order they happened
+ *
+ * @generated from message game.v1.Snapshot
+ */
+export type Snapshot = Message<"game.v1.Snapshot"> & {
+  /**
+   * @generated from field: uint64 tick = 1;
+   */
+  tick: bigint;
+
+  /**
+   * @generated from field: repeated game.v1.Envelope messages = 2;
+   */
+  messages: Envelope[];
+};
+
+/**
+ * Describes the message game.v1.Snapshot.
+ * Use `create(SnapshotSchema)` to create a new message.
+ */
+export const SnapshotSchema: GenMessage<Snapshot> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 1);
+
+/**
+ * Point is a position in pixels
+ *
+ * @generated from message game.v1.Point
+ */
+export type Point = Message<"game.v1.Point"> & {
+  /**
+   * @generated from field: double x = 1;
+   */
+  x: number;
+
+  /**
+   * @generated from field: double y = 2;
+   */
+  y: number;
+};
+
+/**
+ * Describes the message game.v1.Point.
+ * Use `create(PointSchema)` to create a new message.
+ */
+export const PointSchema: GenMessage<Point> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 2);
+
+/**
+ * TilePos is a position in tiles
+ *
+ * @generated from message game.v1.TilePos
+ */
+export type TilePos = Message<"game.v1.TilePos"> & {
+  /**
+   * @generated from field: int32 x = 1;
+   */
+  x: number;
+
+  /**
+   * @generated from field: int32 y = 2;
+   */
+  y: number;
+};
+
+/**
+ * Describes the message game.v1.TilePos.
+ * Use `create(TilePosSchema)` to create a new message.
+ */
+export const TilePosSchema: GenMessage<TilePos> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 3);
+
+/**
+ * PlayerUpdate is a player that moved (pos), joined (active) or left (dis)
+ *
+ * @generated from message game.v1.PlayerUpdate
+ */
+export type PlayerUpdate = Message<"game.v1.PlayerUpdate"> & {
+  /**
+   * @generated from field: string playerid = 1;
+   */
+  playerid: string;
+
+  /**
+   * @generated from field: string user = 2;
+   */
+  user: string;
+
+  /**
+   * @generated from field: string sprite_type = 3;
+   */
+  spriteType: string;
+
+  /**
+   * @generated from field: double x = 4;
+   */
+  x: number;
+
+  /**
+   * @generated from field: double y = 5;
+   */
+  y: number;
+
+  /**
+   * @generated from field: string dir = 6;
+   */
+  dir: string;
+
+  /**
+   * @generated from field: bool is_ready = 7;
+   */
+  isReady: boolean;
+
+  /**
+   * @generated from field: bool is_host = 8;
+   */
+  isHost: boolean;
+
+  /**
+   * @generated from field: bool is_spectator = 9;
+   */
+  isSpectator: boolean;
+
+  /**
+   * pellet or power-up eaten by the move
+   *
+   * @generated from field: game.v1.TilePos pellet = 10;
+   */
+  pellet?: TilePos;
+
+  /**
+   * @generated from field: game.v1.TilePos power_up = 11;
+   */
+  powerUp?: TilePos;
+
+  /**
+   * @generated from field: optional bool powered = 12;
+   */
+  powered?: boolean;
+
+  /**
+   * score after the move, only set when it changed
+   *
+   * @generated from field: optional int32 score = 13;
+   */
+  score?: number;
+};
+
+/**
+ * Describes the message game.v1.PlayerUpdate.
+ * Use `create(PlayerUpdateSchema)` to create a new message.
+ */
+export const PlayerUpdateSchema: GenMessage<PlayerUpdate> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 4);
+
+/**
+ * @generated from message game.v1.Pellet
+ */
+export type Pellet = Message<"game.v1.Pellet"> & {
+  /**
+   * @generated from field: int32 x = 1;
+   */
+  x: number;
+
+  /**
+   * @generated from field: int32 y = 2;
+   */
+  y: number;
+
+  /**
+   * @generated from field: string player_id = 3;
+   */
+  playerId: string;
+
+  /**
+   * @generated from field: int32 score = 4;
+   */
+  score: number;
+};
+
+/**
+ * Describes the message game.v1.Pellet.
+ * Use `create(PelletSchema)` to create a new message.
+ */
+export const PelletSchema: GenMessage<Pellet> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 5);
+
+/**
+ * @generated from message game.v1.PowerUp
+ */
+export type PowerUp = Message<"game.v1.PowerUp"> & {
+  /**
+   * empty when the power-up is global (classic)
+   *
+   * @generated from field: string player_id = 1;
+   */
+  playerId: string;
+
+  /**
+   * @generated from field: double x = 2;
+   */
+  x: number;
+
+  /**
+   * @generated from field: double y = 3;
+   */
+  y: number;
+
+  /**
+   * seconds
+   *
+   * @generated from field: int32 duration = 4;
+   */
+  duration: number;
+};
+
+/**
+ * Describes the message game.v1.PowerUp.
+ * Use `create(PowerUpSchema)` to create a new message.
+ */
+export const PowerUpSchema: GenMessage<PowerUp> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 6);
+
+/**
+ * @generated from message game.v1.PowerUpEnd
+ */
+export type PowerUpEnd = Message<"game.v1.PowerUpEnd"> & {
+  /**
+   * empty when the power-up is global (classic)
+   *
+   * @generated from field: string player_id = 1;
+   */
+  playerId: string;
+};
+
+/**
+ * Describes the message game.v1.PowerUpEnd.
+ * Use `create(PowerUpEndSchema)` to create a new message.
+ */
+export const PowerUpEndSchema: GenMessage<PowerUpEnd> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 7);
+
+/**
+ * @generated from message game.v1.Kill
+ */
+export type Kill = Message<"game.v1.Kill"> & {
+  /**
+   * sprite that got caught
+   *
+   * @generated from field: string sprite_id = 1;
+   */
+  spriteId: string;
+
+  /**
+   * chaser that caught the runner; empty when the runner ate a chaser
+   *
+   * @generated from field: string chaser_id = 2;
+   */
+  chaserId: string;
+};
+
+/**
+ * Describes the message game.v1.Kill.
+ * Use `create(KillSchema)` to create a new message.
+ */
+export const KillSchema: GenMessage<Kill> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 8);
+
+/**
+ * @generated from message game.v1.Eliminated
+ */
+export type Eliminated = Message<"game.v1.Eliminated"> & {
+  /**
+   * @generated from field: string player_id = 1;
+   */
+  playerId: string;
+
+  /**
+   * @generated from field: string by = 2;
+   */
+  by: string;
+
+  /**
+   * score of the player that eliminated
+   *
+   * @generated from field: int32 score = 3;
+   */
+  score: number;
+};
+
+/**
+ * Describes the message game.v1.Eliminated.
+ * Use `create(EliminatedSchema)` to create a new message.
+ */
+export const EliminatedSchema: GenMessage<Eliminated> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 9);
+
+/**
+ * @generated from message game.v1.Reconnecting
+ */
+export type Reconnecting = Message<"game.v1.Reconnecting"> & {
+  /**
+   * @generated from field: string player_id = 1;
+   */
+  playerId: string;
+
+  /**
+   * @generated from field: string sprite_type = 2;
+   */
+  spriteType: string;
+
+  /**
+   * @generated from field: int32 grace_sec = 3;
+   */
+  graceSec: number;
+};
+
+/**
+ * Describes the message game.v1.Reconnecting.
+ * Use `create(ReconnectingSchema)` to create a new message.
+ */
+export const ReconnectingSchema: GenMessage<Reconnecting> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 10);
+
+/**
+ * @generated from message game.v1.Resumed
+ */
+export type Resumed = Message<"game.v1.Resumed"> & {
+  /**
+   * @generated from field: string player_id = 1;
+   */
+  playerId: string;
+
+  /**
+   * @generated from field: string sprite_type = 2;
+   */
+  spriteType: string;
+};
+
+/**
+ * Describes the message game.v1.Resumed.
+ * Use `create(ResumedSchema)` to create a new message.
+ */
+export const ResumedSchema: GenMessage<Resumed> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 11);
+
+/**
+ * @generated from message game.v1.LobbyPlayer
+ */
+export type LobbyPlayer = Message<"game.v1.LobbyPlayer"> & {
+  /**
+   * @generated from field: string player_id = 1;
+   */
+  playerId: string;
+
+  /**
+   * @generated from field: string username = 2;
+   */
+  username: string;
+
+  /**
+   * @generated from field: string sprite_type = 3;
+   */
+  spriteType: string;
+
+  /**
+   * @generated from field: bool is_ready = 4;
+   */
+  isReady: boolean;
+
+  /**
+   * @generated from field: bool is_host = 5;
+   */
+  isHost: boolean;
+};
+
+/**
+ * Describes the message game.v1.LobbyPlayer.
+ * Use `create(LobbyPlayerSchema)` to create a new message.
+ */
+export const LobbyPlayerSchema: GenMessage<LobbyPlayer> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 12);
+
+/**
+ * @generated from message game.v1.LobbyStatus
+ */
+export type LobbyStatus = Message<"game.v1.LobbyStatus"> & {
+  /**
+   * @generated from field: repeated game.v1.LobbyPlayer players = 1;
+   */
+  players: LobbyPlayer[];
+
+  /**
+   * @generated from field: repeated game.v1.LobbyPlayer spectators = 2;
+   */
+  spectators: LobbyPlayer[];
+
+  /**
+   * @generated from field: int32 spectator_count = 3;
+   */
+  spectatorCount: number;
+
+  /**
+   * @generated from field: int32 player_count = 4;
+   */
+  playerCount: number;
+
+  /**
+   * @generated from field: int32 ready_count = 5;
+   */
+  readyCount: number;
+
+  /**
+   * @generated from field: bool match_started = 6;
+   */
+  matchStarted: boolean;
+
+  /**
+   * @generated from field: string host_id = 7;
+   */
+  hostId: string;
+
+  /**
+   * easy, normal, hard
+   *
+   * @generated from field: string bot_difficulty = 8;
+   */
+  botDifficulty: string;
+
+  /**
+   * seconds, 0 plays without a round timer
+   *
+   * @generated from field: int32 round_duration = 9;
+   */
+  roundDuration: number;
+
+  /**
+   * @generated from field: bool sudden_death = 10;
+   */
+  suddenDeath: boolean;
+
+  /**
+   * lives of the runner in classic mode
+   *
+   * @generated from field: int32 lives = 11;
+   */
+  lives: number;
+};
+
+/**
+ * Describes the message game.v1.LobbyStatus.
+ * Use `create(LobbyStatusSchema)` to create a new message.
+ */
+export const LobbyStatusSchema: GenMessage<LobbyStatus> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 13);
+
+/**
+ * @generated from message game.v1.Countdown
+ */
+export type Countdown = Message<"game.v1.Countdown"> & {
+  /**
+   * @generated from field: int32 count = 1;
+   */
+  count: number;
+};
+
+/**
+ * Describes the message game.v1.Countdown.
+ * Use `create(CountdownSchema)` to create a new message.
+ */
+export const CountdownSchema: GenMessage<Countdown> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 14);
+
+/**
+ * @generated from message game.v1.CountdownStarted
+ */
+export type CountdownStarted = Message<"game.v1.CountdownStarted"> & {
+};
+
+/**
+ * Describes the message game.v1.CountdownStarted.
+ * Use `create(CountdownStartedSchema)` to create a new message.
+ */
+export const CountdownStartedSchema: GenMessage<CountdownStarted> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 15);
+
+/**
+ * @generated from message game.v1.GameStart
+ */
+export type GameStart = Message<"game.v1.GameStart"> & {
+  /**
+   * @generated from field: string mode = 1;
+   */
+  mode: string;
+
+  /**
+   * @generated from field: game.v1.DynamicState dynamic_state = 2;
+   */
+  dynamicState?: DynamicState;
+
+  /**
+   * seconds, only set for matches with a round timer
+   *
+   * @generated from field: optional int32 round_duration = 3;
+   */
+  roundDuration?: number;
+};
+
+/**
+ * Describes the message game.v1.GameStart.
+ * Use `create(GameStartSchema)` to create a new message.
+ */
+export const GameStartSchema: GenMessage<GameStart> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 16);
+
+/**
+ * Timer is the round timer, sent every second of a match with a round timer
+ *
+ * @generated from message game.v1.Timer
+ */
+export type Timer = Message<"game.v1.Timer"> & {
+  /**
+   * @generated from field: uint32 remaining_ms = 1;
+   */
+  remainingMs: number;
+
+  /**
+   * @generated from field: uint32 duration_ms = 2;
+   */
+  durationMs: number;
+
+  /**
+   * @generated from field: bool sudden_death = 3;
+   */
+  suddenDeath: boolean;
+};
+
+/**
+ * Describes the message game.v1.Timer.
+ * Use `create(TimerSchema)` to create a new message.
+ */
+export const TimerSchema: GenMessage<Timer> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 17);
+
+/**
+ * SuddenDeath starts the last part of a round: everyone moves faster and the
+ * power-ups vanish
+ *
+ * @generated from message game.v1.SuddenDeath
+ */
+export type SuddenDeath = Message<"game.v1.SuddenDeath"> & {
+  /**
+   * tiles of the power-ups that vanished from the maze
+   *
+   * @generated from field: repeated game.v1.Point power_ups = 1;
+   */
+  powerUps: Point[];
+
+  /**
+   * @generated from field: double speed_factor = 2;
+   */
+  speedFactor: number;
+};
+
+/**
+ * Describes the message game.v1.SuddenDeath.
+ * Use `create(SuddenDeathSchema)` to create a new message.
+ */
+export const SuddenDeathSchema: GenMessage<SuddenDeath> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 18);
+
+/**
+ * ChaserState follows a chaser through the chaser house: frightened by a
+ * power-up, eyes on the way back after being eaten, waiting in the house and
+ * active again once released
+ *
+ * @generated from message game.v1.ChaserState
+ */
+export type ChaserState = Message<"game.v1.ChaserState"> & {
+  /**
+   * @generated from field: string sprite_id = 1;
+   */
+  spriteId: string;
+
+  /**
+   * active, frightened, eyes, house
+   *
+   * @generated from field: string state = 2;
+   */
+  state: string;
+
+  /**
+   * frightened: until it ends, house: until the chaser can be released
+   *
+   * @generated from field: uint32 ms = 3;
+   */
+  ms: number;
+};
+
+/**
+ * Describes the message game.v1.ChaserState.
+ * Use `create(ChaserStateSchema)` to create a new message.
+ */
+export const ChaserStateSchema: GenMessage<ChaserState> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 19);
+
+/**
+ * LifeLost follows the kill of a runner that has lives left, everyone stands
+ * still for freeze_ms and then respawns
+ *
+ * @generated from message game.v1.LifeLost
+ */
+export type LifeLost = Message<"game.v1.LifeLost"> & {
+  /**
+   * @generated from field: int32 lives = 1;
+   */
+  lives: number;
+
+  /**
+   * @generated from field: uint32 freeze_ms = 2;
+   */
+  freezeMs: number;
+};
+
+/**
+ * Describes the message game.v1.LifeLost.
+ * Use `create(LifeLostSchema)` to create a new message.
+ */
+export const LifeLostSchema: GenMessage<LifeLost> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 20);
+
+/**
+ * Respawn puts every player back on its spawn after a lost life or a new level
+ *
+ * @generated from message game.v1.Respawn
+ */
+export type Respawn = Message<"game.v1.Respawn"> & {
+  /**
+   * by sprite type, in pixels
+   *
+   * @generated from field: map<string, game.v1.Point> positions = 1;
+   */
+  positions: { [key: string]: Point };
+};
+
+/**
+ * Describes the message game.v1.Respawn.
+ * Use `create(RespawnSchema)` to create a new message.
+ */
+export const RespawnSchema: GenMessage<Respawn> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 21);
+
+/**
+ * Level starts the next level: the maze is full again, chasers are faster and
+ * power-ups shorter
+ *
+ * @generated from message game.v1.Level
+ */
+export type Level = Message<"game.v1.Level"> & {
+  /**
+   * @generated from field: int32 level = 1;
+   */
+  level: number;
+
+  /**
+   * @generated from field: double chaser_speed_factor = 2;
+   */
+  chaserSpeedFactor: number;
+
+  /**
+   * seconds
+   *
+   * @generated from field: int32 power_up_duration = 3;
+   */
+  powerUpDuration: number;
+
+  /**
+   * @generated from field: uint32 freeze_ms = 4;
+   */
+  freezeMs: number;
+};
+
+/**
+ * Describes the message game.v1.Level.
+ * Use `create(LevelSchema)` to create a new message.
+ */
+export const LevelSchema: GenMessage<Level> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 22);
+
+/**
+ * @generated from message game.v1.GameOver
+ */
+export type GameOver = Message<"game.v1.GameOver"> & {
+  /**
+   * @generated from field: string reason = 1;
+   */
+  reason: string;
+
+  /**
+   * @generated from field: string winner = 2;
+   */
+  winner: string;
+
+  /**
+   * by player id
+   *
+   * @generated from field: map<string, int32> scores = 3;
+   */
+  scores: { [key: string]: number };
+
+  /**
+   * classic mode: the level reached and the runner's lives left
+   *
+   * @generated from field: int32 level = 4;
+   */
+  level: number;
+
+  /**
+   * @generated from field: int32 lives = 5;
+   */
+  lives: number;
+
+  /**
+   * usernames by player id, race and battle name their winner by player id
+   *
+   * @generated from field: map<string, string> names = 6;
+   */
+  names: { [key: string]: string };
+};
+
+/**
+ * Describes the message game.v1.GameOver.
+ * Use `create(GameOverSchema)` to create a new message.
+ */
+export const GameOverSchema: GenMessage<GameOver> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 23);
+
+/**
+ * @generated from message game.v1.ErrorMessage
+ */
+export type ErrorMessage = Message<"game.v1.ErrorMessage"> & {
+  /**
+   * @generated from field: string error = 1;
+   */
+  error: string;
+};
+
+/**
+ * Describes the message game.v1.ErrorMessage.
+ * Use `create(ErrorMessageSchema)` to create a new message.
+ */
+export const ErrorMessageSchema: GenMessage<ErrorMessage> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 24);
+
+/**
+ * @generated from message game.v1.ActivePlayer
+ */
+export type ActivePlayer = Message<"game.v1.ActivePlayer"> & {
+  /**
+   * @generated from field: string username = 1;
+   */
+  username: string;
+
+  /**
+   * @generated from field: double x = 2;
+   */
+  x: number;
+
+  /**
+   * @generated from field: double y = 3;
+   */
+  y: number;
+};
+
+/**
+ * Describes the message game.v1.ActivePlayer.
+ * Use `create(ActivePlayerSchema)` to create a new message.
+ */
+export const ActivePlayerSchema: GenMessage<ActivePlayer> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 25);
+
+/**
+ * @generated from message game.v1.Tunnel
+ */
+export type Tunnel = Message<"game.v1.Tunnel"> & {
+  /**
+   * @generated from field: game.v1.TilePos a = 1;
+   */
+  a?: TilePos;
+
+  /**
+   * @generated from field: game.v1.TilePos b = 2;
+   */
+  b?: TilePos;
+};
+
+/**
+ * Describes the message game.v1.Tunnel.
+ * Use `create(TunnelSchema)` to create a new message.
+ */
+export const TunnelSchema: GenMessage<Tunnel> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 26);
+
+/**
+ * MapInfo is the map as clients draw it, one character per tile
+ *
+ * @generated from message game.v1.MapInfo
+ */
+export type MapInfo = Message<"game.v1.MapInfo"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: int32 width = 2;
+   */
+  width: number;
+
+  /**
+   * @generated from field: int32 height = 3;
+   */
+  height: number;
+
+  /**
+   * @generated from field: repeated string tiles = 4;
+   */
+  tiles: string[];
+
+  /**
+   * @generated from field: repeated game.v1.Tunnel tunnels = 5;
+   */
+  tunnels: Tunnel[];
+
+  /**
+   * @generated from field: int32 total_pellets = 6;
+   */
+  totalPellets: number;
+
+  /**
+   * seed the map was generated from, 0 for map files
+   *
+   * @generated from field: int64 seed = 7;
+   */
+  seed: bigint;
+};
+
+/**
+ * Describes the message game.v1.MapInfo.
+ * Use `create(MapInfoSchema)` to create a new message.
+ */
+export const MapInfoSchema: GenMessage<MapInfo> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 27);
+
+/**
+ * State is the full match state a client gets when it connects, and the
+ * first message of a replay
+ *
+ * @generated from message game.v1.State
+ */
+export type State = Message<"game.v1.State"> & {
+  /**
+   * @generated from field: uint32 protocol_version = 1;
+   */
+  protocolVersion: number;
+
+  /**
+   * @generated from field: string mode = 2;
+   */
+  mode: string;
+
+  /**
+   * @generated from field: repeated string chasers_eaten = 3;
+   */
+  chasersEaten: string[];
+
+  /**
+   * @generated from field: repeated string eliminated = 4;
+   */
+  eliminated: string[];
+
+  /**
+   * by sprite type
+   *
+   * @generated from field: map<string, game.v1.ActivePlayer> active_players = 5;
+   */
+  activePlayers: { [key: string]: ActivePlayer };
+
+  /**
+   * @generated from field: repeated game.v1.LobbyPlayer players_list = 6;
+   */
+  playersList: LobbyPlayer[];
+
+  /**
+   * @generated from field: repeated game.v1.Point pellets_eaten = 7;
+   */
+  pelletsEaten: Point[];
+
+  /**
+   * @generated from field: repeated game.v1.Point power_ups_eaten = 8;
+   */
+  powerUpsEaten: Point[];
+
+  /**
+   * @generated from field: string secret_token = 9;
+   */
+  secretToken: string;
+
+  /**
+   * @generated from field: string sprite_id = 10;
+   */
+  spriteId: string;
+
+  /**
+   * @generated from field: string sprite_type = 11;
+   */
+  spriteType: string;
+
+  /**
+   * @generated from field: string username = 12;
+   */
+  username: string;
+
+  /**
+   * @generated from field: string player_id = 13;
+   */
+  playerId: string;
+
+  /**
+   * @generated from field: bool match_started = 14;
+   */
+  matchStarted: boolean;
+
+  /**
+   * @generated from field: string host_id = 15;
+   */
+  hostId: string;
+
+  /**
+   * @generated from field: bool is_host = 16;
+   */
+  isHost: boolean;
+
+  /**
+   * @generated from field: int32 player_count = 17;
+   */
+  playerCount: number;
+
+  /**
+   * @generated from field: int32 ready_count = 18;
+   */
+  readyCount: number;
+
+  /**
+   * by player id
+   *
+   * @generated from field: map<string, int32> scores = 19;
+   */
+  scores: { [key: string]: number };
+
+  /**
+   * by sprite type
+   *
+   * @generated from field: map<string, game.v1.Point> spawn_positions = 20;
+   */
+  spawnPositions: { [key: string]: Point };
+
+  /**
+   * @generated from field: game.v1.MapInfo map = 21;
+   */
+  map?: MapInfo;
+
+  /**
+   * @generated from field: bool is_spectator = 22;
+   */
+  isSpectator: boolean;
+
+  /**
+   * @generated from field: int32 spectator_count = 23;
+   */
+  spectatorCount: number;
+
+  /**
+   * @generated from field: bool replay = 24;
+   */
+  replay: boolean;
+
+  /**
+   * set for players, the token to resume the session with after a drop
+   *
+   * @generated from field: optional string resume_token = 25;
+   */
+  resumeToken?: string;
+
+  /**
+   * @generated from field: optional double x = 26;
+   */
+  x?: number;
+
+  /**
+   * @generated from field: optional double y = 27;
+   */
+  y?: number;
+
+  /**
+   * by sprite type, chasers that are not active
+   *
+   * @generated from field: map<string, string> chasers = 28;
+   */
+  chasers: { [key: string]: string };
+
+  /**
+   * classic mode: the current level and the runner's lives left
+   *
+   * @generated from field: int32 level = 29;
+   */
+  level: number;
+
+  /**
+   * @generated from field: int32 lives = 30;
+   */
+  lives: number;
+};
+
+/**
+ * Describes the message game.v1.State.
+ * Use `create(StateSchema)` to create a new message.
+ */
+export const StateSchema: GenMessage<State> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 28);
+
+/**
+ * @generated from message game.v1.Zone
+ */
+export type Zone = Message<"game.v1.Zone"> & {
+  /**
+   * @generated from field: int32 id = 1;
+   */
+  id: number;
+
+  /**
+   * safe, neutral, danger
+   *
+   * @generated from field: string type = 2;
+   */
+  type: string;
+
+  /**
+   * @generated from field: int32 x = 3;
+   */
+  x: number;
+
+  /**
+   * @generated from field: int32 y = 4;
+   */
+  y: number;
+
+  /**
+   * @generated from field: int32 width = 5;
+   */
+  width: number;
+
+  /**
+   * @generated from field: int32 height = 6;
+   */
+  height: number;
+
+  /**
+   * @generated from field: bool is_active = 7;
+   */
+  isActive: boolean;
+};
+
+/**
+ * Describes the message game.v1.Zone.
+ * Use `create(ZoneSchema)` to create a new message.
+ */
+export const ZoneSchema: GenMessage<Zone> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 29);
+
+/**
+ * @generated from message game.v1.PhaseUpdate
+ */
+export type PhaseUpdate = Message<"game.v1.PhaseUpdate"> & {
+  /**
+   * day, dusk, night, dawn
+   *
+   * @generated from field: string phase = 1;
+   */
+  phase: string;
+
+  /**
+   * 0-1 through the phase
+   *
+   * @generated from field: double progress = 2;
+   */
+  progress: number;
+};
+
+/**
+ * Describes the message game.v1.PhaseUpdate.
+ * Use `create(PhaseUpdateSchema)` to create a new message.
+ */
+export const PhaseUpdateSchema: GenMessage<PhaseUpdate> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 30);
+
+/**
+ * @generated from message game.v1.PhaseChange
+ */
+export type PhaseChange = Message<"game.v1.PhaseChange"> & {
+  /**
+   * @generated from field: string new_phase = 1;
+   */
+  newPhase: string;
+
+  /**
+   * @generated from field: repeated game.v1.Zone zones = 2;
+   */
+  zones: Zone[];
+};
+
+/**
+ * Describes the message game.v1.PhaseChange.
+ * Use `create(PhaseChangeSchema)` to create a new message.
+ */
+export const PhaseChangeSchema: GenMessage<PhaseChange> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 31);
+
+/**
+ * @generated from message game.v1.MazeUpdate
+ */
+export type MazeUpdate = Message<"game.v1.MazeUpdate"> & {
+  /**
+   * wall_add, wall_remove, wall_move
+   *
+   * @generated from field: string type = 1;
+   */
+  type: string;
+
+  /**
+   * @generated from field: int32 x = 2;
+   */
+  x: number;
+
+  /**
+   * @generated from field: int32 y = 3;
+   */
+  y: number;
+
+  /**
+   * @generated from field: optional int32 target_x = 4;
+   */
+  targetX?: number;
+
+  /**
+   * @generated from field: optional int32 target_y = 5;
+   */
+  targetY?: number;
+
+  /**
+   * animation duration in ms
+   *
+   * @generated from field: int32 duration = 6;
+   */
+  duration: number;
+
+  /**
+   * ms until the server undoes the update, unset on the undo itself
+   *
+   * @generated from field: optional int32 revert_in = 7;
+   */
+  revertIn?: number;
+};
+
+/**
+ * Describes the message game.v1.MazeUpdate.
+ * Use `create(MazeUpdateSchema)` to create a new message.
+ */
+export const MazeUpdateSchema: GenMessage<MazeUpdate> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 32);
+
+/**
+ * @generated from message game.v1.Entity
+ */
+export type Entity = Message<"game.v1.Entity"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * hunter, scanner, sweeper
+   *
+   * @generated from field: string type = 2;
+   */
+  type: string;
+
+  /**
+   * patrol, alert, chase, return, dormant
+   *
+   * @generated from field: string state = 3;
+   */
+  state: string;
+
+  /**
+   * @generated from field: double x = 4;
+   */
+  x: number;
+
+  /**
+   * @generated from field: double y = 5;
+   */
+  y: number;
+
+  /**
+   * @generated from field: string dir = 6;
+   */
+  dir: string;
+
+  /**
+   * @generated from field: double glow = 7;
+   */
+  glow: number;
+
+  /**
+   * @generated from field: string glow_color = 8;
+   */
+  glowColor: string;
+
+  /**
+   * 0-1
+   *
+   * @generated from field: double alert = 9;
+   */
+  alert: number;
+
+  /**
+   * @generated from field: double scan_direction = 10;
+   */
+  scanDirection: number;
+
+  /**
+   * @generated from field: double scan_angle = 11;
+   */
+  scanAngle: number;
+
+  /**
+   * @generated from field: double detection_range = 12;
+   */
+  detectionRange: number;
+};
+
+/**
+ * Describes the message game.v1.Entity.
+ * Use `create(EntitySchema)` to create a new message.
+ */
+export const EntitySchema: GenMessage<Entity> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 33);
+
+/**
+ * EntitiesUpdate is the entities a player is interested in. With a baseline
+ * it only holds the entities that changed since the snapshot of that tick,
+ * which the client acknowledged, and the ones it no longer gets in removed.
+ *
+ * @generated from message game.v1.EntitiesUpdate
+ */
+export type EntitiesUpdate = Message<"game.v1.EntitiesUpdate"> & {
+  /**
+   * @generated from field: repeated game.v1.Entity entities = 1;
+   */
+  entities: Entity[];
+
+  /**
+   * tick of the acknowledged snapshot, 0 when entities is the full set
+   *
+   * @generated from field: uint64 baseline = 2;
+   */
+  baseline: bigint;
+
+  /**
+   * ids of entities out of interest since the baseline
+   *
+   * @generated from field: repeated string removed = 3;
+   */
+  removed: string[];
+};
+
+/**
+ * Describes the message game.v1.EntitiesUpdate.
+ * Use `create(EntitiesUpdateSchema)` to create a new message.
+ */
+export const EntitiesUpdateSchema: GenMessage<EntitiesUpdate> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 34);
+
+/**
+ * EntityNear warns that an entity touches a player it may not catch, like a
+ * player in an active safe zone
+ *
+ * @generated from message game.v1.EntityNear
+ */
+export type EntityNear = Message<"game.v1.EntityNear"> & {
+  /**
+   * @generated from field: string entity_id = 1;
+   */
+  entityId: string;
+
+  /**
+   * @generated from field: bool warning = 2;
+   */
+  warning: boolean;
+
+  /**
+   * @generated from field: string player_id = 3;
+   */
+  playerId: string;
+};
+
+/**
+ * Describes the message game.v1.EntityNear.
+ * Use `create(EntityNearSchema)` to create a new message.
+ */
+export const EntityNearSchema: GenMessage<EntityNear> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 35);
+
+/**
+ * EntityCollision is a player caught by a danger entity, the server detects
+ * catches every entity tick
+ *
+ * @generated from message game.v1.EntityCollision
+ */
+export type EntityCollision = Message<"game.v1.EntityCollision"> & {
+  /**
+   * @generated from field: string entity_id = 1;
+   */
+  entityId: string;
+
+  /**
+   * @generated from field: string entity_type = 2;
+   */
+  entityType: string;
+
+  /**
+   * @generated from field: bool caught = 3;
+   */
+  caught: boolean;
+
+  /**
+   * @generated from field: string player_id = 4;
+   */
+  playerId: string;
+
+  /**
+   * stun or eliminated, depending on the game mode
+   *
+   * @generated from field: string outcome = 5;
+   */
+  outcome: string;
+
+  /**
+   * how long a stunned player cannot move
+   *
+   * @generated from field: uint32 stun_ms = 6;
+   */
+  stunMs: number;
+};
+
+/**
+ * Describes the message game.v1.EntityCollision.
+ * Use `create(EntityCollisionSchema)` to create a new message.
+ */
+export const EntityCollisionSchema: GenMessage<EntityCollision> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 36);
+
+/**
+ * @generated from message game.v1.ZoneQuery
+ */
+export type ZoneQuery = Message<"game.v1.ZoneQuery"> & {
+  /**
+   * unset outside every zone
+   *
+   * @generated from field: game.v1.Zone zone = 1;
+   */
+  zone?: Zone;
+};
+
+/**
+ * Describes the message game.v1.ZoneQuery.
+ * Use `create(ZoneQuerySchema)` to create a new message.
+ */
+export const ZoneQuerySchema: GenMessage<ZoneQuery> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 37);
+
+/**
+ * @generated from message game.v1.ZonesState
+ */
+export type ZonesState = Message<"game.v1.ZonesState"> & {
+  /**
+   * @generated from field: repeated game.v1.Zone zones = 1;
+   */
+  zones: Zone[];
+
+  /**
+   * @generated from field: string phase = 2;
+   */
+  phase: string;
+
+  /**
+   * @generated from field: double progress = 3;
+   */
+  progress: number;
+};
+
+/**
+ * Describes the message game.v1.ZonesState.
+ * Use `create(ZonesStateSchema)` to create a new message.
+ */
+export const ZonesStateSchema: GenMessage<ZonesState> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 38);
+
+/**
+ * DynamicState is the state of zones, entities and maze changes, for clients
+ * that join or resync
+ *
+ * @generated from message game.v1.DynamicState
+ */
+export type DynamicState = Message<"game.v1.DynamicState"> & {
+  /**
+   * @generated from field: game.v1.ZonesState zones = 1;
+   */
+  zones?: ZonesState;
+
+  /**
+   * @generated from field: repeated game.v1.Entity entities = 2;
+   */
+  entities: Entity[];
+
+  /**
+   * maze updates in effect, not yet reverted
+   *
+   * @generated from field: repeated game.v1.MazeUpdate maze_updates = 3;
+   */
+  mazeUpdates: MazeUpdate[];
+};
+
+/**
+ * Describes the message game.v1.DynamicState.
+ * Use `create(DynamicStateSchema)` to create a new message.
+ */
+export const DynamicStateSchema: GenMessage<DynamicState> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 39);
+
+/**
+ * @generated from message game.v1.Chat
+ */
+export type Chat = Message<"game.v1.Chat"> & {
+  /**
+   * @generated from field: string player_id = 1;
+   */
+  playerId: string;
+
+  /**
+   * @generated from field: string username = 2;
+   */
+  username: string;
+
+  /**
+   * @generated from field: string message = 3;
+   */
+  message: string;
+
+  /**
+   * unix seconds
+   *
+   * @generated from field: int64 timestamp = 4;
+   */
+  timestamp: bigint;
+};
+
+/**
+ * Describes the message game.v1.Chat.
+ * Use `create(ChatSchema)` to create a new message.
+ */
+export const ChatSchema: GenMessage<Chat> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 40);
+
+/**
+ * @generated from message game.v1.ReplayInfo
+ */
+export type ReplayInfo = Message<"game.v1.ReplayInfo"> & {
+  /**
+   * @generated from field: uint32 match_id = 1;
+   */
+  matchId: number;
+
+  /**
+   * @generated from field: string mode = 2;
+   */
+  mode: string;
+
+  /**
+   * RFC 3339
+   *
+   * @generated from field: string started_at = 3;
+   */
+  startedAt: string;
+
+  /**
+   * @generated from field: uint32 duration_ms = 4;
+   */
+  durationMs: number;
+};
+
+/**
+ * Describes the message game.v1.ReplayInfo.
+ * Use `create(ReplayInfoSchema)` to create a new message.
+ */
+export const ReplayInfoSchema: GenMessage<ReplayInfo> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 41);
+
+/**
+ * @generated from message game.v1.ReplayStatus
+ */
+export type ReplayStatus = Message<"game.v1.ReplayStatus"> & {
+  /**
+   * @generated from field: uint32 at_ms = 1;
+   */
+  atMs: number;
+
+  /**
+   * @generated from field: uint32 duration_ms = 2;
+   */
+  durationMs: number;
+
+  /**
+   * @generated from field: bool paused = 3;
+   */
+  paused: boolean;
+
+  /**
+   * @generated from field: double speed = 4;
+   */
+  speed: number;
+
+  /**
+   * @generated from field: bool ended = 5;
+   */
+  ended: boolean;
+};
+
+/**
+ * Describes the message game.v1.ReplayStatus.
+ * Use `create(ReplayStatusSchema)` to create a new message.
+ */
+export const ReplayStatusSchema: GenMessage<ReplayStatus> = /*@__PURE__*/
+  messageDesc(file_game_v1_game, 42);
+
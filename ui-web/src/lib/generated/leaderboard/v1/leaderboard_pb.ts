@@ -0,0 +1,254 @@
+// @generated by protoc-gen-es v2.10.2 with parameter "target=ts"
+// @generated from file leaderboard/v1/leaderboard.proto (package leaderboard.v1, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file leaderboard/v1/leaderboard.proto.
+ */
+export const file_leaderboard_v1_leaderboard: GenFile = /*@__PURE__*/
+  fileDesc("CiBsZWFkZXJib2FyZC92MS9sZWFkZXJib2FyZC5wcm90bxIObGVhZGVyYm9hcmQudjEiSgoRVG9wUGxheWVyc1JlcXVlc3QSEQoJZ2FtZV9tb2RlGAEgASgJEhMKC3RpbWVfd2luZG93GAIgASgJEg0KBWxpbWl0GAMgASgNIkcKElRvcFBsYXllcnNSZXNwb25zZRIxCgdlbnRyaWVzGAEgAygLMiAubGVhZGVyYm9hcmQudjEuTGVhZGVyYm9hcmRFbnRyeSJNChJQbGF5ZXJTdGF0c1JlcXVlc3QSDwoHdXNlcl9pZBgBIAEoBBIRCglnYW1lX21vZGUYAiABKAkSEwoLdGltZV93aW5kb3cYAyABKAkiRgoTUGxheWVyU3RhdHNSZXNwb25zZRIvCgVzdGF0cxgBIAEoCzIgLmxlYWRlcmJvYXJkLnYxLkxlYWRlcmJvYXJkRW50cnkiNwoNTXlSYW5rUmVxdWVzdBIRCglnYW1lX21vZGUYASABKAkSEwoLdGltZV93aW5kb3cYAiABKAkiZQoOTXlSYW5rUmVzcG9uc2USDAoEcmFuaxgBIAEoBBIUCgx0b3RhbFBsYXllcnMYAiABKAQSLwoFZW50cnkYAyABKAsyIC5sZWFkZXJib2FyZC52MS5MZWFkZXJib2FyZEVudHJ5IsgBChBMZWFkZXJib2FyZEVudHJ5EgwKBHJhbmsYASABKAQSDgoGdXNlcklkGAIgASgEEhAKCHVzZXJuYW1lGAMgASgJEhMKC2dhbWVzUGxheWVkGAQgASgEEgwKBHdpbnMYBSABKAQSDgoGbG9zc2VzGAYgASgEEhEKCWhpZ2hTY29yZRgHIAEoAxISCgp0b3RhbFNjb3JlGAggASgDEhQKDHBlbGxldHNFYXRlbhgJIAEoBBIUCgxjaGFzZXJzRWF0ZW4YCiABKAQymQIKEkxlYWRlcmJvYXJkU2VydmljZRJYCg1HZXRUb3BQbGF5ZXJzEiEubGVhZGVyYm9hcmQudjEuVG9wUGxheWVyc1JlcXVlc3QaIi5sZWFkZXJib2FyZC52MS5Ub3BQbGF5ZXJzUmVzcG9uc2UiABJbCg5HZXRQbGF5ZXJTdGF0cxIiLmxlYWRlcmJvYXJkLnYxLlBsYXllclN0YXRzUmVxdWVzdBojLmxlYWRlcmJvYXJkLnYxLlBsYXllclN0YXRzUmVzcG9uc2UiABJMCglHZXRNeVJhbmsSHS5sZWFkZXJib2FyZC52MS5NeVJhbmtSZXF1ZXN0Gh4ubGVhZGVyYm9hcmQudjEuTXlSYW5rUmVzcG9uc2UiAEK4AQoSY29tLmxlYWRlcmJvYXJkLnYxQhBMZWFkZXJib2FyZFByb3RvUAFaN2dpdGh1Yi5jb20vZnJhbmsyODg5L21hemVjaGFzZS9nZW5lcmF0ZWQvbGVhZGVyYm9hcmQvdjGiAgNMWFiqAg5MZWFkZXJib2FyZC5WMcoCDkxlYWRlcmJvYXJkXFYx4gIaTGVhZGVyYm9hcmRcVjFcR1BCTWV0YWRhdGHqAg9MZWFkZXJib2FyZDo6VjFiBnByb3RvMw");
+
+/**
+ * @generated from message leaderboard.v1.TopPlayersRequest
+ */
+export type TopPlayersRequest = Message<"leaderboard.v1.TopPlayersRequest"> & {
+  /**
+   * classic, race, battle; empty means all modes
+   *
+   * @generated from field: string game_mode = 1;
+   */
+  gameMode: string;
+
+  /**
+   * day, week, month; empty means all time
+   *
+   * @generated from field: string time_window = 2;
+   */
+  timeWindow: string;
+
+  /**
+   * @generated from field: uint32 limit = 3;
+   */
+  limit: number;
+};
+
+/**
+ * Describes the message leaderboard.v1.TopPlayersRequest.
+ * Use `create(TopPlayersRequestSchema)` to create a new message.
+ */
+export const TopPlayersRequestSchema: GenMessage<TopPlayersRequest> = /*@__PURE__*/
+  messageDesc(file_leaderboard_v1_leaderboard, 0);
+
+/**
+ * @generated from message leaderboard.v1.TopPlayersResponse
+ */
+export type TopPlayersResponse = Message<"leaderboard.v1.TopPlayersResponse"> & {
+  /**
+   * @generated from field: repeated leaderboard.v1.LeaderboardEntry entries = 1;
+   */
+  entries: LeaderboardEntry[];
+};
+
+/**
+ * Describes the message leaderboard.v1.TopPlayersResponse.
+ * Use `create(TopPlayersResponseSchema)` to create a new message.
+ */
+export const TopPlayersResponseSchema: GenMessage<TopPlayersResponse> = /*@__PURE__*/
+  messageDesc(file_leaderboard_v1_leaderboard, 1);
+
+/**
+ * @generated from message leaderboard.v1.PlayerStatsRequest
+ */
+export type PlayerStatsRequest = Message<"leaderboard.v1.PlayerStatsRequest"> & {
+  /**
+   * 0 means the calling user
+   *
+   * @generated from field: uint64 user_id = 1;
+   */
+  userId: bigint;
+
+  /**
+   * @generated from field: string game_mode = 2;
+   */
+  gameMode: string;
+
+  /**
+   * day, week, month; empty means all time
+   *
+   * @generated from field: string time_window = 3;
+   */
+  timeWindow: string;
+};
+
+/**
+ * Describes the message leaderboard.v1.PlayerStatsRequest.
+ * Use `create(PlayerStatsRequestSchema)` to create a new message.
+ */
+export const PlayerStatsRequestSchema: GenMessage<PlayerStatsRequest> = /*@__PURE__*/
+  messageDesc(file_leaderboard_v1_leaderboard, 2);
+
+/**
+ * @generated from message leaderboard.v1.PlayerStatsResponse
+ */
+export type PlayerStatsResponse = Message<"leaderboard.v1.PlayerStatsResponse"> & {
+  /**
+   * @generated from field: leaderboard.v1.LeaderboardEntry stats = 1;
+   */
+  stats?: LeaderboardEntry;
+};
+
+/**
+ * Describes the message leaderboard.v1.PlayerStatsResponse.
+ * Use `create(PlayerStatsResponseSchema)` to create a new message.
+ */
+export const PlayerStatsResponseSchema: GenMessage<PlayerStatsResponse> = /*@__PURE__*/
+  messageDesc(file_leaderboard_v1_leaderboard, 3);
+
+/**
+ * @generated from message leaderboard.v1.MyRankRequest
+ */
+export type MyRankRequest = Message<"leaderboard.v1.MyRankRequest"> & {
+  /**
+   * @generated from field: string game_mode = 1;
+   */
+  gameMode: string;
+
+  /**
+   * @generated from field: string time_window = 2;
+   */
+  timeWindow: string;
+};
+
+/**
+ * Describes the message leaderboard.v1.MyRankRequest.
+ * Use `create(MyRankRequestSchema)` to create a new message.
+ */
+export const MyRankRequestSchema: GenMessage<MyRankRequest> = /*@__PURE__*/
+  messageDesc(file_leaderboard_v1_leaderboard, 4);
+
+/**
+ * @generated from message leaderboard.v1.MyRankResponse
+ */
+export type MyRankResponse = Message<"leaderboard.v1.MyRankResponse"> & {
+  /**
+   * 0 when the user has not played in this selection yet
+   *
+   * @generated from field: uint64 rank = 1;
+   */
+  rank: bigint;
+
+  /**
+   * @generated from field: uint64 totalPlayers = 2;
+   */
+  totalPlayers: bigint;
+
+  /**
+   * @generated from field: leaderboard.v1.LeaderboardEntry entry = 3;
+   */
+  entry?: LeaderboardEntry;
+};
+
+/**
+ * Describes the message leaderboard.v1.MyRankResponse.
+ * Use `create(MyRankResponseSchema)` to create a new message.
+ */
+export const MyRankResponseSchema: GenMessage<MyRankResponse> = /*@__PURE__*/
+  messageDesc(file_leaderboard_v1_leaderboard, 5);
+
+/**
+ * @generated from message leaderboard.v1.LeaderboardEntry
+ */
+export type LeaderboardEntry = Message<"leaderboard.v1.LeaderboardEntry"> & {
+  /**
+   * @generated from field: uint64 rank = 1;
+   */
+  rank: bigint;
+
+  /**
+   * @generated from field: uint64 userId = 2;
+   */
+  userId: bigint;
+
+  /**
+   * @generated from field: string username = 3;
+   */
+  username: string;
+
+  /**
+   * @generated from field: uint64 gamesPlayed = 4;
+   */
+  gamesPlayed: bigint;
+
+  /**
+   * @generated from field: uint64 wins = 5;
+   */
+  wins: bigint;
+
+  /**
+   * @generated from field: uint64 losses = 6;
+   */
+  losses: bigint;
+
+  /**
+   * @generated from field: int64 highScore = 7;
+   */
+  highScore: bigint;
+
+  /**
+   * @generated from field: int64 totalScore = 8;
+   */
+  totalScore: bigint;
+
+  /**
+   * @generated from field: uint64 pelletsEaten = 9;
+   */
+  pelletsEaten: bigint;
+
+  /**
+   * @generated from field: uint64 chasersEaten = 10;
+   */
+  chasersEaten: bigint;
+};
+
+/**
+ * Describes the message leaderboard.v1.LeaderboardEntry.
+ * Use `create(LeaderboardEntrySchema)` to create a new message.
+ */
+export const LeaderboardEntrySchema: GenMessage<LeaderboardEntry> = /*@__PURE__*/
+  messageDesc(file_leaderboard_v1_leaderboard, 6);
+
+/**
+ * @generated from service leaderboard.v1.LeaderboardService
+ */
+export const LeaderboardService: GenService<{
+  /**
+   * @generated from rpc leaderboard.v1.LeaderboardService.GetTopPlayers
+   */
+  getTopPlayers: {
+    methodKind: "unary";
+    input: typeof TopPlayersRequestSchema;
+    output: typeof TopPlayersResponseSchema;
+  },
+  /**
+   * @generated from rpc leaderboard.v1.LeaderboardService.GetPlayerStats
+   */
+  getPlayerStats: {
+    methodKind: "unary";
+    input: typeof PlayerStatsRequestSchema;
+    output: typeof PlayerStatsResponseSchema;
+  },
+  /**
+   * @generated from rpc leaderboard.v1.LeaderboardService.GetMyRank
+   */
+  getMyRank: {
+    methodKind: "unary";
+    input: typeof MyRankRequestSchema;
+    output: typeof MyRankResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_leaderboard_v1_leaderboard, 0);
+
@@ -2,15 +2,15 @@
 // @generated from file lobby/v1/lobby.proto (package lobby.v1, syntax proto3)
 /* eslint-disable */
 
-import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
-import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { GenEnum, GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
 import type { Message } from "@bufbuild/protobuf";
 
 /**
  * Describes the file lobby/v1/lobby.proto.
  */
 export const file_lobby_v1_lobby: GenFile = /*@__PURE__*/
-  fileDesc("ChRsb2JieS92MS9sb2JieS5wcm90bxIIbG9iYnkudjEiFAoSTGlzdExvYmJpZXNSZXF1ZXN0IjcKE0xpc3RMb2JiaWVzUmVzcG9uc2USIAoHbG9iYmllcxgBIAMoCzIPLmxvYmJ5LnYxLkxvYmJ5IicKEUFkZExvYmJpZXNSZXF1ZXN0EhIKCmxvYmJ5X25hbWUYASABKAkiJgoSQWRkTG9iYmllc1Jlc3BvbnNlEhAKCGxvYmJ5X2lkGAEgASgEIjMKEURlbExvYmJpZXNSZXF1ZXN0Eh4KBWxvYmJ5GAEgASgLMg8ubG9iYnkudjEuTG9iYnkiFAoSRGVsTG9iYmllc1Jlc3BvbnNlInQKBUxvYmJ5EgoKAklEGAEgASgEEhIKCmxvYmJ5X25hbWUYAiABKAkSEQoJb3duZXJOYW1lGAQgASgJEg8KB293bmVySWQYBSABKAQSEgoKY3JlYXRlZF9hdBgDIAEoCRITCgtwbGF5ZXJDb3VudBgGIAEoBDLxAQoMTG9iYnlTZXJ2aWNlEkwKC0xpc3RMb2JiaWVzEhwubG9iYnkudjEuTGlzdExvYmJpZXNSZXF1ZXN0Gh0ubG9iYnkudjEuTGlzdExvYmJpZXNSZXNwb25zZSIAEkcKCEFkZExvYmJ5EhsubG9iYnkudjEuQWRkTG9iYmllc1JlcXVlc3QaHC5sb2JieS52MS5BZGRMb2JiaWVzUmVzcG9uc2UiABJKCgtEZWxldGVMb2JieRIbLmxvYmJ5LnYxLkRlbExvYmJpZXNSZXF1ZXN0GhwubG9iYnkudjEuRGVsTG9iYmllc1Jlc3BvbnNlIgBCjgEKDGNvbS5sb2JieS52MUIKTG9iYnlQcm90b1ABWjFnaXRodWIuY29tL2ZyYW5rMjg4OS9tYXplY2hhc2UvZ2VuZXJhdGVkL2xvYmJ5L3YxogIDTFhYqgIITG9iYnkuVjHKAghMb2JieVxWMeICFExvYmJ5XFYxXEdQQk1ldGFkYXRh6gIJTG9iYnk6OlYxYgZwcm90bzM");
+  fileDesc("ChRsb2JieS92MS9sb2JieS5wcm90bxIIbG9iYnkudjEiFAoSTGlzdExvYmJpZXNSZXF1ZXN0IjcKE0xpc3RMb2JiaWVzUmVzcG9uc2USIAoHbG9iYmllcxgBIAMoCzIPLmxvYmJ5LnYxLkxvYmJ5Il4KEUFkZExvYmJpZXNSZXF1ZXN0EhIKCmxvYmJ5X25hbWUYASABKAkSEQoJZ2FtZV9tb2RlGAIgASgJEhAKCG1hcF9uYW1lGAMgASgJEhAKCG1hcF9zZWVkGAQgASgDIiYKEkFkZExvYmJpZXNSZXNwb25zZRIQCghsb2JieV9pZBgBIAEoBCIzChFEZWxMb2JiaWVzUmVxdWVzdBIeCgVsb2JieRgBIAEoCzIPLmxvYmJ5LnYxLkxvYmJ5IhQKEkRlbExvYmJpZXNSZXNwb25zZSIRCg9MaXN0TWFwc1JlcXVlc3QiJQoQTGlzdE1hcHNSZXNwb25zZRIRCgltYXBfbmFtZXMYASADKAkiFQoTV2F0Y2hMb2JiaWVzUmVxdWVzdCJUCgpMb2JieUV2ZW50EiYKBHR5cGUYASABKA4yGC5sb2JieS52MS5Mb2JieUV2ZW50VHlwZRIeCgVsb2JieRgCIAEoCzIPLmxvYmJ5LnYxLkxvYmJ5IsIBCgVMb2JieRIKCgJJRBgBIAEoBBISCgpsb2JieV9uYW1lGAIgASgJEhEKCW93bmVyTmFtZRgEIAEoCRIPCgdvd25lcklkGAUgASgEEhIKCmNyZWF0ZWRfYXQYAyABKAkSEwoLcGxheWVyQ291bnQYBiABKAQSEQoJZ2FtZV9tb2RlGAcgASgJEhUKDW1hdGNoX3N0YXJ0ZWQYCCABKAgSEAoIbWFwX25hbWUYCSABKAkSEAoIbWFwX3NlZWQYCiABKAMq1wEKDkxvYmJ5RXZlbnRUeXBlEiAKHExPQkJZX0VWRU5UX1RZUEVfVU5TUEVDSUZJRUQQABIcChhMT0JCWV9FVkVOVF9UWVBFX0NSRUFURUQQARIcChhMT0JCWV9FVkVOVF9UWVBFX0RFTEVURUQQAhIhCh1MT0JCWV9FVkVOVF9UWVBFX1BMQVlFUl9DT1VOVBADEiIKHkxPQkJZX0VWRU5UX1RZUEVfTUFUQ0hfU1RBUlRFRBAEEiAKHExPQkJZX0VWRU5UX1RZUEVfTUFUQ0hfRU5ERUQQBTL/AgoMTG9iYnlTZXJ2aWNlEkwKC0xpc3RMb2JiaWVzEhwubG9iYnkudjEuTGlzdExvYmJpZXNSZXF1ZXN0Gh0ubG9iYnkudjEuTGlzdExvYmJpZXNSZXNwb25zZSIAEkcKDFdhdGNoTG9iYmllcxIdLmxvYmJ5LnYxLldhdGNoTG9iYmllc1JlcXVlc3QaFC5sb2JieS52MS5Mb2JieUV2ZW50IgAwARJHCghBZGRMb2JieRIbLmxvYmJ5LnYxLkFkZExvYmJpZXNSZXF1ZXN0GhwubG9iYnkudjEuQWRkTG9iYmllc1Jlc3BvbnNlIgASSgoLRGVsZXRlTG9iYnkSGy5sb2JieS52MS5EZWxMb2JiaWVzUmVxdWVzdBocLmxvYmJ5LnYxLkRlbExvYmJpZXNSZXNwb25zZSIAEkMKCExpc3RNYXBzEhkubG9iYnkudjEuTGlzdE1hcHNSZXF1ZXN0GhoubG9iYnkudjEuTGlzdE1hcHNSZXNwb25zZSIAQo4BCgxjb20ubG9iYnkudjFCCkxvYmJ5UHJvdG9QAVoxZ2l0aHViLmNvbS9mcmFuazI4ODkvbWF6ZWNoYXNlL2dlbmVyYXRlZC9sb2JieS92MaICA0xYWKoCCExvYmJ5LlYxygIITG9iYnlcVjHiAhRMb2JieVxWMVxHUEJNZXRhZGF0YeoCCUxvYmJ5OjpWMWIGcHJvdG8z");
 
 /**
  * @generated from message lobby.v1.ListLobbiesRequest
@@ -50,6 +50,27 @@ export type AddLobbiesRequest = Message<"lobby.v1.AddLobbiesRequest"> & {
    * @generated from field: string lobby_name = 1;
    */
   lobbyName: string;
+
+  /**
+   * classic, race, battle; empty means classic
+   *
+   * @generated from field: string game_mode = 2;
+   */
+  gameMode: string;
+
+  /**
+   * name of a map from ListMaps; empty means the default map
+   *
+   * @generated from field: string map_name = 3;
+   */
+  mapName: string;
+
+  /**
+   * seed of the generated map; 0 lets the server pick one
+   *
+   * @generated from field: int64 map_seed = 4;
+   */
+  mapSeed: bigint;
 };
 
 /**
@@ -106,6 +127,75 @@ export type DelLobbiesResponse = Message<"lobby.v1.DelLobbiesResponse"> & {
 export const DelLobbiesResponseSchema: GenMessage<DelLobbiesResponse> = /*@__PURE__*/
   messageDesc(file_lobby_v1_lobby, 5);
 
+/**
+ * @generated from message lobby.v1.ListMapsRequest
+ */
+export type ListMapsRequest = Message<"lobby.v1.ListMapsRequest"> & {
+};
+
+/**
+ * Describes the message lobby.v1.ListMapsRequest.
+ * Use `create(ListMapsRequestSchema)` to create a new message.
+ */
+export const ListMapsRequestSchema: GenMessage<ListMapsRequest> = /*@__PURE__*/
+  messageDesc(file_lobby_v1_lobby, 6);
+
+/**
+ * @generated from message lobby.v1.ListMapsResponse
+ */
+export type ListMapsResponse = Message<"lobby.v1.ListMapsResponse"> & {
+  /**
+   * the first map is the default
+   *
+   * @generated from field: repeated string map_names = 1;
+   */
+  mapNames: string[];
+};
+
+/**
+ * Describes the message lobby.v1.ListMapsResponse.
+ * Use `create(ListMapsResponseSchema)` to create a new message.
+ */
+export const ListMapsResponseSchema: GenMessage<ListMapsResponse> = /*@__PURE__*/
+  messageDesc(file_lobby_v1_lobby, 7);
+
+/**
+ * @generated from message lobby.v1.WatchLobbiesRequest
+ */
+export type WatchLobbiesRequest = Message<"lobby.v1.WatchLobbiesRequest"> & {
+};
+
+/**
+ * Describes the message lobby.v1.WatchLobbiesRequest.
+ * Use `create(WatchLobbiesRequestSchema)` to create a new message.
+ */
+export const WatchLobbiesRequestSchema: GenMessage<WatchLobbiesRequest> = /*@__PURE__*/
+  messageDesc(file_lobby_v1_lobby, 8);
+
+/**
+ * @generated from message lobby.v1.LobbyEvent
+ */
+export type LobbyEvent = Message<"lobby.v1.LobbyEvent"> & {
+  /**
+   * @generated from field: lobby.v1.LobbyEventType type = 1;
+   */
+  type: LobbyEventType;
+
+  /**
+   * deleted events only carry the ID, player count events the ID and playerCount
+   *
+   * @generated from field: lobby.v1.Lobby lobby = 2;
+   */
+  lobby?: Lobby;
+};
+
+/**
+ * Describes the message lobby.v1.LobbyEvent.
+ * Use `create(LobbyEventSchema)` to create a new message.
+ */
+export const LobbyEventSchema: GenMessage<LobbyEvent> = /*@__PURE__*/
+  messageDesc(file_lobby_v1_lobby, 9);
+
 /**
  * @generated from message lobby.v1.Lobby
  */
@@ -139,6 +229,28 @@ export type Lobby = Message<"lobby.v1.Lobby"> & {
    * @generated from field: uint64 playerCount = 6;
    */
   playerCount: bigint;
+
+  /**
+   * @generated from field: string game_mode = 7;
+   */
+  gameMode: string;
+
+  /**
+   * @generated from field: bool match_started = 8;
+   */
+  matchStarted: boolean;
+
+  /**
+   * @generated from field: string map_name = 9;
+   */
+  mapName: string;
+
+  /**
+   * replays the same layout when the map is generated
+   *
+   * @generated from field: int64 map_seed = 10;
+   */
+  mapSeed: bigint;
 };
 
 /**
@@ -146,15 +258,54 @@ export type Lobby = Message<"lobby.v1.Lobby"> & {
  * Use `create(LobbySchema)` to create a new message.
  */
 export const LobbySchema: GenMessage<Lobby> = /*@__PURE__*/
-  messageDesc(file_lobby_v1_lobby, 6);
+  messageDesc(file_lobby_v1_lobby, 10);
+
+/**
+ * @generated from enum lobby.v1.LobbyEventType
+ */
+export enum LobbyEventType {
+  /**
+   * @generated from enum value: LOBBY_EVENT_TYPE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: LOBBY_EVENT_TYPE_CREATED = 1;
+   */
+  CREATED = 1,
+
+  /**
+   * @generated from enum value: LOBBY_EVENT_TYPE_DELETED = 2;
+   */
+  DELETED = 2,
+
+  /**
+   * @generated from enum value: LOBBY_EVENT_TYPE_PLAYER_COUNT = 3;
+   */
+  PLAYER_COUNT = 3,
+
+  /**
+   * @generated from enum value: LOBBY_EVENT_TYPE_MATCH_STARTED = 4;
+   */
+  MATCH_STARTED = 4,
+
+  /**
+   * @generated from enum value: LOBBY_EVENT_TYPE_MATCH_ENDED = 5;
+   */
+  MATCH_ENDED = 5,
+}
+
+/**
+ * Describes the enum lobby.v1.LobbyEventType.
+ */
+export const LobbyEventTypeSchema: GenEnum<LobbyEventType> = /*@__PURE__*/
+  enumDesc(file_lobby_v1_lobby, 0);
 
 /**
  * @generated from service lobby.v1.LobbyService
  */
 export const LobbyService: GenService<{
   /**
-   * todo figure out lobby streaming
-   *
    * @generated from rpc lobby.v1.LobbyService.ListLobbies
    */
   listLobbies: {
@@ -162,6 +313,17 @@ export const LobbyService: GenService<{
     input: typeof ListLobbiesRequestSchema;
     output: typeof ListLobbiesResponseSchema;
   },
+  /**
+   * WatchLobbies first sends every existing lobby as a created event, then
+   * pushes lobby changes as they happen
+   *
+   * @generated from rpc lobby.v1.LobbyService.WatchLobbies
+   */
+  watchLobbies: {
+    methodKind: "server_streaming";
+    input: typeof WatchLobbiesRequestSchema;
+    output: typeof LobbyEventSchema;
+  },
   /**
    * @generated from rpc lobby.v1.LobbyService.AddLobby
    */
@@ -178,6 +340,14 @@ export const LobbyService: GenService<{
     input: typeof DelLobbiesRequestSchema;
     output: typeof DelLobbiesResponseSchema;
   },
+  /**
+   * @generated from rpc lobby.v1.LobbyService.ListMaps
+   */
+  listMaps: {
+    methodKind: "unary";
+    input: typeof ListMapsRequestSchema;
+    output: typeof ListMapsResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_lobby_v1_lobby, 0);
 
@@ -0,0 +1,22 @@
+import {callRPC, leaderboardClient} from "./api.ts";
+import type {LeaderboardEntry as RpcLeaderboardEntry} from "./generated/leaderboard/v1/leaderboard_pb.ts";
+import type {LeaderboardEntry} from "../components/Leaderboard.tsx";
+
+export const getTopPlayers = async (gameMode: string = "", timeWindow: string = "", limit: number = 10) => {
+    return await callRPC(() => leaderboardClient.getTopPlayers({gameMode, timeWindow, limit}))
+}
+
+export const getMyRank = async (gameMode: string = "", timeWindow: string = "") => {
+    return await callRPC(() => leaderboardClient.getMyRank({gameMode, timeWindow}))
+}
+
+export const toLeaderboardEntry = (entry: RpcLeaderboardEntry): LeaderboardEntry => ({
+    userId: Number(entry.userId),
+    username: entry.username,
+    wins: Number(entry.wins),
+    losses: Number(entry.losses),
+    chasersEaten: Number(entry.chasersEaten),
+    pelletsEaten: Number(entry.pelletsEaten),
+    gamesPlayed: Number(entry.gamesPlayed),
+    highScore: Number(entry.highScore),
+})
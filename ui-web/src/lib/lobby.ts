@@ -1,5 +1,5 @@
 import {callRPC, lobbyClient} from "./api.ts";
-import type {Lobby} from "./generated/lobby/v1/lobby_pb.ts";
+import {type Lobby, type LobbyEvent, LobbyEventType} from "./generated/lobby/v1/lobby_pb.ts";
 import type {Component} from "solid-js";
 
 export const listLobbies = async () => {
@@ -7,8 +7,44 @@ export const listLobbies = async () => {
 }
 
 
-export const addLobby = async (name: string) => {
-    return await callRPC(() => lobbyClient.addLobby({lobbyName: name}))
+// watchLobbies streams lobby changes into onChange until the signal aborts,
+// the server starts the stream with every existing lobby
+export const watchLobbies = async (onChange: (lobbies: Lobby[]) => void, signal: AbortSignal) => {
+    const lobbies = new Map<bigint, Lobby>()
+    for await (const event of lobbyClient.watchLobbies({}, {signal})) {
+        applyLobbyEvent(lobbies, event)
+        onChange([...lobbies.values()])
+    }
+}
+
+const applyLobbyEvent = (lobbies: Map<bigint, Lobby>, event: LobbyEvent) => {
+    const lobby = event.lobby
+    if (!lobby) return
+
+    const existing = lobbies.get(lobby.ID)
+    switch (event.type) {
+        case LobbyEventType.CREATED:
+            lobbies.set(lobby.ID, lobby)
+            break
+        case LobbyEventType.DELETED:
+            lobbies.delete(lobby.ID)
+            break
+        case LobbyEventType.PLAYER_COUNT:
+            if (existing) lobbies.set(lobby.ID, {...existing, playerCount: lobby.playerCount})
+            break
+        case LobbyEventType.MATCH_STARTED:
+        case LobbyEventType.MATCH_ENDED:
+            if (existing) lobbies.set(lobby.ID, {...existing, matchStarted: lobby.matchStarted})
+            break
+    }
+}
+
+export const addLobby = async (name: string, gameMode: string = "", mapName: string = "") => {
+    return await callRPC(() => lobbyClient.addLobby({lobbyName: name, gameMode, mapName}))
+}
+
+export const listMaps = async () => {
+    return await callRPC(() => lobbyClient.listMaps({}))
 }
 
 export const deleteLobby = async (lobby: Lobby) => {